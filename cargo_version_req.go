@@ -0,0 +1,438 @@
+package versions
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrCargoVersionReqInvalid 表示 Cargo 版本要求格式无效的错误
+	//
+	// 当尝试解析不符合 Cargo.toml 语法的版本要求时返回此错误
+	ErrCargoVersionReqInvalid = errors.New("cargo version requirement invalid")
+)
+
+// Cargo 版本要求中支持的运算符
+const (
+	cargoOpExact     = "="
+	cargoOpGreater   = ">"
+	cargoOpGreaterEq = ">="
+	cargoOpLess      = "<"
+	cargoOpLessEq    = "<="
+	cargoOpTilde     = "~"
+	cargoOpCaret     = "^"
+	cargoOpWildcard  = "*"
+)
+
+// cargoComparator Cargo 版本要求中的一个比较条件，次版本号和修订号可以省略
+type cargoComparator struct {
+
+	// operator 比较运算符，没有写运算符时为 cargoOpCaret，有通配符时为 cargoOpWildcard
+	operator string
+
+	major int
+
+	// minor 次版本号，为 nil 时表示省略或者是通配符
+	minor *int
+
+	// patch 修订号，为 nil 时表示省略或者是通配符
+	patch *int
+
+	prerelease []string
+}
+
+// CargoVersionReq 表示 Cargo.toml 中的一个版本要求
+//
+// 语法和语义与 Rust 的 semver crate 中的 VersionReq 保持一致：
+//   - 多个比较条件用逗号分隔，它们之间是"并且"的关系
+//   - 没有运算符的版本号表示默认的"^"要求，如 "1.2" 等价于 ">=1.2.0, <2.0.0"
+//   - "~1.2.3" 等价于 ">=1.2.3, <1.3.0"，"1.*"、"1.2.*"、"*" 是通配符要求
+//   - 省略的部分按照 semver crate 的规则处理，如 ">1.2" 等价于 ">=1.3.0"，"<=1.2" 等价于 "<1.3.0"
+//
+// 与 semver crate 一样，预发布版本只有在某个比较条件带有预发布标识符，
+// 并且该比较条件的 major.minor.patch 与版本相同时才可能被匹配。
+//
+// 使用示例:
+//
+//	req, err := versions.ParseCargoVersionReq("1.2")
+//	if err != nil {
+//	    log.Fatalf("不是合法的Cargo版本要求: %v", err)
+//	}
+//	fmt.Println(req.Check(versions.MustParse("1.9.0", versions.SchemeCargo))) // 输出: true
+//	fmt.Println(req.Check(versions.MustParse("2.0.0", versions.SchemeCargo))) // 输出: false
+type CargoVersionReq struct {
+	comparators []*cargoComparator
+}
+
+var _ BoundedVersionMatcher = &CargoVersionReq{}
+
+// ParseCargoVersionReq 按照 Cargo 的规则解析版本要求
+//
+// 参数:
+//   - reqStr: 版本要求，如 "1.2"、">=1.2.0, <1.5"、"~0.3"、"1.*"
+//
+// 返回:
+//   - *CargoVersionReq: 解析后的版本要求
+//   - error: 版本要求无效时返回包装了 ErrCargoVersionReqInvalid 的错误
+func ParseCargoVersionReq(reqStr string) (*CargoVersionReq, error) {
+	s := strings.TrimSpace(reqStr)
+	if s == "" {
+		return nil, fmt.Errorf("%w: %q is empty", ErrCargoVersionReqInvalid, reqStr)
+	}
+	req := &CargoVersionReq{
+		comparators: make([]*cargoComparator, 0),
+	}
+	if s == "*" || s == "x" || s == "X" {
+		return req, nil
+	}
+	for _, part := range strings.Split(s, ",") {
+		c, err := parseCargoComparator(strings.TrimSpace(part))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q %s", ErrCargoVersionReqInvalid, reqStr, err.Error())
+		}
+		req.comparators = append(req.comparators, c)
+	}
+	return req, nil
+}
+
+// MustParseCargoVersionReq 与 ParseCargoVersionReq 相同，但是在解析失败时会 panic
+func MustParseCargoVersionReq(reqStr string) *CargoVersionReq {
+	req, err := ParseCargoVersionReq(reqStr)
+	if err != nil {
+		panic(err)
+	}
+	return req
+}
+
+// parseCargoComparator 解析一个比较条件，如 ">=1.2.3-beta"、"1.*"
+func parseCargoComparator(s string) (*cargoComparator, error) {
+	if s == "" {
+		return nil, fmt.Errorf("empty comparator")
+	}
+	c := &cargoComparator{}
+	for _, operator := range []string{cargoOpGreaterEq, cargoOpLessEq, cargoOpExact, cargoOpGreater, cargoOpLess, cargoOpTilde, cargoOpCaret} {
+		if strings.HasPrefix(s, operator) {
+			c.operator = operator
+			s = strings.TrimSpace(s[len(operator):])
+			break
+		}
+	}
+
+	if i := strings.IndexByte(s, '+'); i >= 0 {
+		return nil, fmt.Errorf("unexpected build metadata in %q", s)
+	}
+	if i := strings.IndexByte(s, '-'); i >= 0 {
+		prerelease, err := splitSemVerIdentifiers(s, s[i+1:], true)
+		if err != nil {
+			return nil, err
+		}
+		c.prerelease = prerelease
+		s = s[:i]
+	}
+
+	parts := strings.Split(s, ".")
+	if len(parts) > 3 {
+		return nil, fmt.Errorf("too many numeric parts in %q", s)
+	}
+	wildcard := false
+	numbers := make([]*int, 0, 3)
+	for i, part := range parts {
+		if part == "*" || part == "x" || part == "X" {
+			if i == 0 {
+				return nil, fmt.Errorf("wildcard must be the only comparator")
+			}
+			wildcard = true
+			continue
+		}
+		if wildcard {
+			return nil, fmt.Errorf("unexpected %q after wildcard", part)
+		}
+		if !isNumericIdentifier(part) || (len(part) > 1 && part[0] == '0') {
+			return nil, fmt.Errorf("invalid numeric part %q", part)
+		}
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("numeric part %q overflows", part)
+		}
+		numbers = append(numbers, &n)
+	}
+	c.major = *numbers[0]
+	if len(numbers) > 1 {
+		c.minor = numbers[1]
+	}
+	if len(numbers) > 2 {
+		c.patch = numbers[2]
+	}
+	if c.prerelease != nil && c.patch == nil {
+		return nil, fmt.Errorf("prerelease requires major.minor.patch")
+	}
+
+	if wildcard {
+		if c.operator != "" && c.operator != cargoOpExact {
+			return nil, fmt.Errorf("unexpected wildcard after %s", c.operator)
+		}
+		c.operator = cargoOpWildcard
+	} else if c.operator == "" {
+		c.operator = cargoOpCaret
+	}
+	return c, nil
+}
+
+// matches 判断 SemVer 是否满足比较条件，与 semver crate 的 matches_impl 一致
+func (x *cargoComparator) matches(sv *SemVer) bool {
+	switch x.operator {
+	case cargoOpExact, cargoOpWildcard:
+		return x.matchesExact(sv)
+	case cargoOpGreater:
+		return x.matchesGreater(sv)
+	case cargoOpGreaterEq:
+		return x.matchesExact(sv) || x.matchesGreater(sv)
+	case cargoOpLess:
+		return x.matchesLess(sv)
+	case cargoOpLessEq:
+		return x.matchesExact(sv) || x.matchesLess(sv)
+	case cargoOpTilde:
+		return x.matchesTilde(sv)
+	case cargoOpCaret:
+		return x.matchesCaret(sv)
+	}
+	return false
+}
+
+func (x *cargoComparator) matchesExact(sv *SemVer) bool {
+	if sv.Major != x.major {
+		return false
+	}
+	if x.minor != nil && sv.Minor != *x.minor {
+		return false
+	}
+	if x.patch != nil && sv.Patch != *x.patch {
+		return false
+	}
+	return compareSemVerPrerelease(sv.Prerelease, x.prerelease) == 0
+}
+
+func (x *cargoComparator) matchesGreater(sv *SemVer) bool {
+	if sv.Major != x.major {
+		return sv.Major > x.major
+	}
+	if x.minor == nil {
+		return false
+	} else if sv.Minor != *x.minor {
+		return sv.Minor > *x.minor
+	}
+	if x.patch == nil {
+		return false
+	} else if sv.Patch != *x.patch {
+		return sv.Patch > *x.patch
+	}
+	return compareSemVerPrerelease(sv.Prerelease, x.prerelease) > 0
+}
+
+func (x *cargoComparator) matchesLess(sv *SemVer) bool {
+	if sv.Major != x.major {
+		return sv.Major < x.major
+	}
+	if x.minor == nil {
+		return false
+	} else if sv.Minor != *x.minor {
+		return sv.Minor < *x.minor
+	}
+	if x.patch == nil {
+		return false
+	} else if sv.Patch != *x.patch {
+		return sv.Patch < *x.patch
+	}
+	return compareSemVerPrerelease(sv.Prerelease, x.prerelease) < 0
+}
+
+func (x *cargoComparator) matchesTilde(sv *SemVer) bool {
+	if sv.Major != x.major {
+		return false
+	}
+	if x.minor != nil && sv.Minor != *x.minor {
+		return false
+	}
+	if x.patch != nil && sv.Patch != *x.patch {
+		return sv.Patch > *x.patch
+	}
+	return compareSemVerPrerelease(sv.Prerelease, x.prerelease) >= 0
+}
+
+func (x *cargoComparator) matchesCaret(sv *SemVer) bool {
+	if sv.Major != x.major {
+		return false
+	}
+	if x.minor == nil {
+		return true
+	}
+	minor := *x.minor
+	if x.patch == nil {
+		if x.major > 0 {
+			return sv.Minor >= minor
+		}
+		return sv.Minor == minor
+	}
+	patch := *x.patch
+	if x.major > 0 {
+		if sv.Minor != minor {
+			return sv.Minor > minor
+		} else if sv.Patch != patch {
+			return sv.Patch > patch
+		}
+	} else if minor > 0 {
+		if sv.Minor != minor {
+			return false
+		} else if sv.Patch != patch {
+			return sv.Patch > patch
+		}
+	} else if sv.Minor != minor || sv.Patch != patch {
+		return false
+	}
+	return compareSemVerPrerelease(sv.Prerelease, x.prerelease) >= 0
+}
+
+// prereleaseCompatible 判断预发布版本能否被这个比较条件匹配，与 semver crate 的 pre_is_compatible 一致
+func (x *cargoComparator) prereleaseCompatible(sv *SemVer) bool {
+	return x.major == sv.Major && x.minor != nil && *x.minor == sv.Minor &&
+		x.patch != nil && *x.patch == sv.Patch && len(x.prerelease) > 0
+}
+
+// floor 返回比较条件涉及的最小版本，省略的部分补0，没有写完整时使用最小的预发布标识符"0"
+func (x *cargoComparator) floor() *Version {
+	if x.patch != nil {
+		return newCargoBoundVersion(x.major, *x.minor, *x.patch, x.prerelease)
+	}
+	minor := 0
+	if x.minor != nil {
+		minor = *x.minor
+	}
+	return newCargoBoundVersion(x.major, minor, 0, []string{"0"})
+}
+
+// bump 把下标为 index 的部分加1并丢弃右边的部分，返回带有最小预发布标识符的版本，可以作为不包含的上界
+func (x *cargoComparator) bump(index int) *Version {
+	switch {
+	case index == 0 || x.minor == nil:
+		return newCargoBoundVersion(x.major+1, 0, 0, []string{"0"})
+	case index == 1 || x.patch == nil:
+		return newCargoBoundVersion(x.major, *x.minor+1, 0, []string{"0"})
+	default:
+		return newCargoBoundVersion(x.major, *x.minor, *x.patch+1, []string{"0"})
+	}
+}
+
+// interval 返回比较条件的外接区间
+func (x *cargoComparator) interval() *VersionInterval {
+	full := x.patch != nil
+	switch x.operator {
+	case cargoOpExact, cargoOpWildcard:
+		if full {
+			return NewVersionInterval(x.floor(), ContainsPolicyYes, x.floor(), ContainsPolicyYes)
+		}
+		return NewVersionInterval(x.floor(), ContainsPolicyYes, x.bump(2), ContainsPolicyNo)
+	case cargoOpGreater:
+		if full {
+			return NewVersionInterval(x.floor(), ContainsPolicyNo, nil, ContainsPolicyNone)
+		}
+		return NewVersionInterval(x.bump(2), ContainsPolicyYes, nil, ContainsPolicyNone)
+	case cargoOpGreaterEq:
+		return NewVersionInterval(x.floor(), ContainsPolicyYes, nil, ContainsPolicyNone)
+	case cargoOpLess:
+		return NewVersionInterval(nil, ContainsPolicyNone, x.floor(), ContainsPolicyNo)
+	case cargoOpLessEq:
+		if full {
+			return NewVersionInterval(nil, ContainsPolicyNone, x.floor(), ContainsPolicyYes)
+		}
+		return NewVersionInterval(nil, ContainsPolicyNone, x.bump(2), ContainsPolicyNo)
+	case cargoOpTilde:
+		return NewVersionInterval(x.floor(), ContainsPolicyYes, x.bump(1), ContainsPolicyNo)
+	default:
+		index := 2
+		if x.major > 0 {
+			index = 0
+		} else if x.minor != nil && *x.minor > 0 {
+			index = 1
+		}
+		return NewVersionInterval(x.floor(), ContainsPolicyYes, x.bump(index), ContainsPolicyNo)
+	}
+}
+
+// String 返回比较条件的字符串形式，与 semver crate 的 Display 一致
+func (x *cargoComparator) String() string {
+	s := strings.Builder{}
+	if x.operator != cargoOpCaret && x.operator != cargoOpWildcard {
+		s.WriteString(x.operator)
+	}
+	s.WriteString(strconv.Itoa(x.major))
+	if x.minor != nil {
+		s.WriteString("." + strconv.Itoa(*x.minor))
+	} else if x.operator == cargoOpWildcard {
+		s.WriteString(".*")
+	}
+	if x.patch != nil {
+		s.WriteString("." + strconv.Itoa(*x.patch))
+	} else if x.operator == cargoOpWildcard && x.minor != nil {
+		s.WriteString(".*")
+	}
+	if len(x.prerelease) > 0 {
+		s.WriteString("-" + strings.Join(x.prerelease, "."))
+	}
+	if x.operator == cargoOpCaret {
+		return "^" + s.String()
+	}
+	return s.String()
+}
+
+// newCargoBoundVersion 构造用作区间边界的 Cargo 版本
+func newCargoBoundVersion(major, minor, patch int, prerelease []string) *Version {
+	sv := &SemVer{Major: major, Minor: minor, Patch: patch, Prerelease: prerelease}
+	return MustParse(sv.String(), SchemeCargo)
+}
+
+// Check 判断版本是否满足版本要求，版本应该使用 SchemeCargo 或 SchemeSemVer 解析
+func (x *CargoVersionReq) Check(v *Version) bool {
+	sv := semVerOf(v)
+	for _, c := range x.comparators {
+		if !c.matches(sv) {
+			return false
+		}
+	}
+	if len(sv.Prerelease) == 0 {
+		return true
+	}
+	for _, c := range x.comparators {
+		if c.prereleaseCompatible(sv) {
+			return true
+		}
+	}
+	return false
+}
+
+// Filter 过滤出满足版本要求的版本，返回的版本保持输入时的顺序
+func (x *CargoVersionReq) Filter(versions []*Version) []*Version {
+	return FilterVersions(versions, x)
+}
+
+// BoundingIntervals 返回所有比较条件的外接区间的交集
+func (x *CargoVersionReq) BoundingIntervals() []*VersionInterval {
+	interval := &VersionInterval{}
+	for _, c := range x.comparators {
+		interval = interval.Intersect(c.interval())
+	}
+	return []*VersionInterval{interval}
+}
+
+// String 返回版本要求的规范形式，与 semver crate 的 Display 一致，如 "1.2" 返回 "^1.2"
+func (x *CargoVersionReq) String() string {
+	if len(x.comparators) == 0 {
+		return "*"
+	}
+	comparators := make([]string, 0, len(x.comparators))
+	for _, c := range x.comparators {
+		comparators = append(comparators, c.String())
+	}
+	return strings.Join(comparators, ", ")
+}
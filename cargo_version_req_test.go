@@ -0,0 +1,91 @@
+package versions
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// cargoVersionReqCases 来自 Rust 的 semver crate 的 tests/test_version_req.rs
+var cargoVersionReqCases = []struct {
+	req      string
+	includes []string
+	excludes []string
+}{
+	{"1.0.0", []string{"1.0.0", "1.0.1", "1.1.0"}, []string{"0.9.9", "0.10.0", "0.1.0", "1.0.0-pre", "1.0.1-pre"}},
+	{">= 1.0.0", []string{"1.0.0", "2.0.0"}, []string{"0.1.0", "0.0.1", "1.0.0-pre", "2.0.0-pre"}},
+	{">= 2.1.0-alpha2", []string{"2.1.0-alpha2", "2.1.0-alpha3", "2.1.0", "3.0.0"}, []string{"2.0.0", "2.1.0-alpha1", "2.0.0-alpha2", "3.0.0-alpha2"}},
+	{"< 1.0.0", []string{"0.1.0", "0.0.1"}, []string{"1.0.0", "1.0.0-beta", "1.0.1", "0.9.9-alpha"}},
+	{"<= 2.1.0-alpha2", []string{"2.1.0-alpha2", "2.1.0-alpha1", "2.0.0", "1.0.0"}, []string{"2.1.0", "2.2.0-alpha1", "2.0.0-alpha2", "1.0.0-alpha2"}},
+	{">1.0.0-alpha, <1.0.0", []string{"1.0.0-beta"}, []string{"1.0.0"}},
+	{"=0.1.0", []string{"0.1.0"}, []string{"0.1.1", "0.1.0-pre", "0.2.0"}},
+	{"=0.1", []string{"0.1.0", "0.1.9"}, []string{"0.2.0", "0.1.0-pre"}},
+	{"~1", []string{"1.0.0", "1.0.1", "1.1.1"}, []string{"0.9.1", "2.9.0", "0.0.9"}},
+	{"~1.2", []string{"1.2.0", "1.2.1"}, []string{"1.1.1", "1.3.0", "0.0.9"}},
+	{"~1.2.2", []string{"1.2.2", "1.2.4"}, []string{"1.2.1", "1.9.0", "1.0.9", "2.0.1", "0.1.3"}},
+	{"~1.2.3-beta.2", []string{"1.2.3", "1.2.4", "1.2.3-beta.2", "1.2.3-beta.4"}, []string{"1.3.3", "1.1.4", "1.2.3-beta.1", "1.2.4-beta.2"}},
+	{"^1", []string{"1.1.2", "1.1.0", "1.2.1", "1.0.1"}, []string{"0.9.1", "2.9.0", "0.1.4", "1.0.0-beta1", "0.1.0-alpha", "1.0.1-pre"}},
+	{"^1.1", []string{"1.1.2", "1.1.0", "1.2.1"}, []string{"0.9.1", "2.9.0", "1.0.1", "0.1.4"}},
+	{"^1.1.2", []string{"1.1.2", "1.1.4", "1.2.1"}, []string{"0.9.1", "2.9.0", "1.1.1", "0.0.1", "1.1.2-alpha1", "1.1.3-alpha1", "2.9.0-alpha1"}},
+	{"^0.1.2", []string{"0.1.2", "0.1.4"}, []string{"0.9.1", "2.9.0", "1.1.1", "0.0.1", "0.1.2-beta", "0.1.3-alpha", "0.2.0-pre"}},
+	{"^0.5.1-alpha3", []string{"0.5.1-alpha3", "0.5.1-alpha4", "0.5.1-beta", "0.5.1", "0.5.5"}, []string{"0.5.1-alpha1", "0.5.2-alpha3", "0.5.5-pre", "0.5.0-pre", "0.6.0"}},
+	{"^0.0.2", []string{"0.0.2"}, []string{"0.9.1", "2.9.0", "1.1.1", "0.0.1", "0.1.4"}},
+	{"^0.0", []string{"0.0.2", "0.0.0"}, []string{"0.9.1", "2.9.0", "1.1.1", "0.1.4"}},
+	{"^0", []string{"0.9.1", "0.0.2", "0.0.0"}, []string{"2.9.0", "1.1.1"}},
+	{"^1.4.2-beta.5", []string{"1.4.2", "1.4.3", "1.4.2-beta.5", "1.4.2-beta.6", "1.4.2-c"}, []string{"0.9.9", "2.0.0", "1.4.2-alpha", "1.4.2-beta.4", "1.4.3-beta.5"}},
+	{"*", []string{"0.9.1", "2.9.0", "0.0.9", "1.0.1", "1.1.1"}, []string{"1.0.0-pre"}},
+	{"1.*", []string{"1.2.0", "1.2.1", "1.1.1", "1.3.0"}, []string{"0.0.9", "2.0.0"}},
+	{"1.2.*", []string{"1.2.0", "1.2.2", "1.2.4"}, []string{"1.9.0", "1.0.9", "2.0.1", "0.1.3"}},
+	{">1.2", []string{"1.3.0", "2.0.0"}, []string{"1.2.9", "1.2.0"}},
+	{"<=1.2", []string{"1.2.9", "0.1.0"}, []string{"1.3.0"}},
+	{"<1.2", []string{"1.1.9"}, []string{"1.2.0", "1.2.0-beta"}},
+}
+
+// TestCargoVersionReq_Check 测试 Cargo 版本要求的匹配，包括默认的"^"语义和预发布版本的规则
+func TestCargoVersionReq_Check(t *testing.T) {
+	for _, c := range cargoVersionReqCases {
+		req, err := ParseCargoVersionReq(c.req)
+		if !assert.Nil(t, err, c.req) {
+			continue
+		}
+		for _, v := range c.includes {
+			assert.True(t, req.Check(MustParse(v, SchemeCargo)), "%q should include %s", c.req, v)
+		}
+		for _, v := range c.excludes {
+			assert.False(t, req.Check(MustParse(v, SchemeCargo)), "%q should exclude %s", c.req, v)
+		}
+
+		// 外接区间必须覆盖所有匹配的版本
+		assertQueryMatcherMatchesFilter(t, SchemeCargo, req, append(append([]string{}, c.includes...), c.excludes...)...)
+	}
+}
+
+// TestParseCargoVersionReq 测试 Cargo 版本要求的解析和规范形式
+func TestParseCargoVersionReq(t *testing.T) {
+	cases := []struct {
+		req      string
+		expected string
+	}{
+		{"1.2", "^1.2"},
+		{"^1.2.3", "^1.2.3"},
+		{">= 1.0.0, <2", ">=1.0.0, <2"},
+		{" ~ 1.2.3-beta.2 ", "~1.2.3-beta.2"},
+		{"*", "*"},
+		{"1.*", "1.*"},
+		{"1.2.x", "1.2.*"},
+		{"=1.*", "1.*"},
+		{"=1.2.3", "=1.2.3"},
+	}
+	for _, c := range cases {
+		req, err := ParseCargoVersionReq(c.req)
+		if assert.Nil(t, err, c.req) {
+			assert.Equal(t, c.expected, req.String(), c.req)
+		}
+	}
+
+	for _, invalid := range []string{"", ">= >= 0.0.2", "1.2.3+build", "1.2-beta", "*, >1", "01.2", ">=1.*", "1.*.3", "1.2.3.4", "a.b.c", "1.2,"} {
+		_, err := ParseCargoVersionReq(invalid)
+		assert.True(t, errors.Is(err, ErrCargoVersionReqInvalid), invalid)
+	}
+}
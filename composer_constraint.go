@@ -0,0 +1,471 @@
+package versions
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrComposerConstraintInvalid 表示 Composer 版本约束格式无效的错误
+	//
+	// 当尝试解析不符合 composer.json 语法的版本约束时返回此错误
+	ErrComposerConstraintInvalid = errors.New("composer constraint invalid")
+)
+
+// 下面的正则表达式与 composer/semver 的 VersionParser::parseConstraint 中的定义保持一致
+var (
+	composerVersionPattern      = `v?(\d+)(?:\.(\d+))?(?:\.(\d+))?(?:\.(\d+))?` + composerModifier + `(?:\+[^\s]+)?`
+	composerAliasRegexp         = regexp.MustCompile(`^([^,\s]+) +as +([^,\s]+)$`)
+	composerConstraintFlagRegex = regexp.MustCompile(`(?i)^([^,\s]*?)@(stable|RC|beta|alpha|dev)$`)
+	composerRefRegexp           = regexp.MustCompile(`(?i)^(dev-[^,\s@]+?|[^,\s@]+?\.x-dev)#.+$`)
+	composerMatchAllRegexp      = regexp.MustCompile(`(?i)^(v)?[xX*](\.[xX*])*$`)
+	composerTildeRegexp         = regexp.MustCompile(`(?i)^~>?` + composerVersionPattern + `$`)
+	composerCaretRegexp         = regexp.MustCompile(`(?i)^\^` + composerVersionPattern + `$`)
+	composerXRangeRegexp        = regexp.MustCompile(`^v?(\d+)(?:\.(\d+))?(?:\.(\d+))?(?:\.[xX*])+$`)
+	composerHyphenRegexp        = regexp.MustCompile(`(?i)^(` + composerVersionPattern + `) +- +(` + composerVersionPattern + `)$`)
+	composerBasicRegexp         = regexp.MustCompile(`^(<>|!=|>=?|<=?|==?)?\s*(.*)$`)
+	composerOrSplitRegexp       = regexp.MustCompile(`\s*\|\|?\s*`)
+	composerDevNameRegexp       = regexp.MustCompile(`^[0-9a-zA-Z-./]+$`)
+	composerModifierEndRegexp   = regexp.MustCompile(`(?i)-` + composerModifier + `$`)
+)
+
+// composerComparator Composer 版本约束中的一个基本比较条件
+type composerComparator struct {
+
+	// operator 比较运算符，为 "=="、"!="、">"、">="、"<"、"<=" 之一
+	operator string
+
+	// version 规范化之后的版本
+	version *Version
+}
+
+// ComposerConstraint 表示 composer.json 中的一个版本约束
+//
+// 语法和语义与 composer/semver 的 VersionParser::parseConstraints 保持一致：
+//   - "||" 或 "|" 分隔的约束之间是"或者"的关系，逗号或空格分隔的约束之间是"并且"的关系
+//   - "^1.2" 等价于 ">=1.2.0.0-dev <2.0.0.0-dev"，"~1.2.3" 等价于 ">=1.2.3.0-dev <1.3.0.0-dev"
+//   - "1.0.*" 等价于 ">=1.0.0.0-dev <1.1.0.0-dev"，"1.0 - 2.0" 等价于 ">=1.0.0.0-dev <2.1.0.0-dev"
+//   - "@dev"、"^1.0@beta" 中的稳定性标记不影响 Check，但会放宽 CheckWithMinimumStability 的稳定性要求
+//
+// 非数字的分支版本（如 "dev-main"）只能被 "==" 和 "!=" 匹配。
+//
+// 使用示例:
+//
+//	c, err := versions.ParseComposerConstraint("^1.2 || ~2.0.3")
+//	if err != nil {
+//	    log.Fatalf("不是合法的Composer版本约束: %v", err)
+//	}
+//	fmt.Println(c.Check(versions.MustParse("1.9.0", versions.SchemeComposer))) // 输出: true
+type ComposerConstraint struct {
+	raw string
+
+	// groups 之间是"或者"的关系，每个组内的比较条件之间是"并且"的关系，组为空时匹配任意版本
+	groups [][]*composerComparator
+
+	// stability 约束中的稳定性标记，没有时为空字符串
+	stability string
+}
+
+var _ BoundedVersionMatcher = &ComposerConstraint{}
+
+// ParseComposerConstraint 按照 Composer 的规则解析版本约束
+//
+// 参数:
+//   - constraintStr: 版本约束，如 "^1.2"、">=1.0 <2.0 || 3.0.*"、"@dev"
+//
+// 返回:
+//   - *ComposerConstraint: 解析后的版本约束
+//   - error: 版本约束无效时返回包装了 ErrComposerConstraintInvalid 的错误
+func ParseComposerConstraint(constraintStr string) (*ComposerConstraint, error) {
+	c := &ComposerConstraint{
+		raw:    constraintStr,
+		groups: make([][]*composerComparator, 0),
+	}
+	explicitLevel, implicitLevel := -1, -1
+	for _, orPart := range composerOrSplitRegexp.Split(strings.TrimSpace(constraintStr), -1) {
+		tokens := splitComposerAndConstraints(orPart)
+		if len(tokens) == 0 {
+			return nil, fmt.Errorf("%w: %q has empty constraint", ErrComposerConstraintInvalid, constraintStr)
+		}
+		group := make([]*composerComparator, 0)
+		for _, token := range tokens {
+			comparators, flag, err := parseComposerComparator(token)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %q %s", ErrComposerConstraintInvalid, constraintStr, err.Error())
+			}
+			group = append(group, comparators...)
+
+			// 与 RootPackageLoader::extractStabilityFlags 一样，显式的标记优先，否则从约束中的版本号推断
+			if flag != "" && composerStabilityLevels[flag] > explicitLevel {
+				explicitLevel = composerStabilityLevels[flag]
+			}
+			if !strings.ContainsAny(token, "@ ,") {
+				if stability := ParseComposerStability(token); stability != ComposerStabilityStable && composerStabilityLevels[stability] > implicitLevel {
+					implicitLevel = composerStabilityLevels[stability]
+				}
+			}
+		}
+		c.groups = append(c.groups, group)
+	}
+	level := explicitLevel
+	if level < 0 {
+		level = implicitLevel
+	}
+	for name, l := range composerStabilityLevels {
+		if l == level {
+			c.stability = name
+		}
+	}
+	return c, nil
+}
+
+// MustParseComposerConstraint 与 ParseComposerConstraint 相同，但是在解析失败时会 panic
+func MustParseComposerConstraint(constraintStr string) *ComposerConstraint {
+	c, err := ParseComposerConstraint(constraintStr)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// splitComposerAndConstraints 按逗号和空格切分"并且"关系的约束，运算符和版本之间的空格、连字符范围和别名不会被切开
+func splitComposerAndConstraints(s string) []string {
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+	tokens := make([]string, 0, len(words))
+	for i := 0; i < len(words); i++ {
+		token := words[i]
+		if composerBasicRegexp.FindStringSubmatch(token)[2] == "" || token == "~" || token == "^" {
+			// 只有运算符，与后面的版本号合并
+			if i+1 < len(words) {
+				i++
+				token += words[i]
+			}
+		}
+		for i+2 < len(words) && (words[i+1] == "-" || words[i+1] == "as") {
+			token += " " + words[i+1] + " " + words[i+2]
+			i += 2
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens
+}
+
+// parseComposerComparator 解析一个约束，返回展开之后的基本比较条件和约束中的稳定性标记
+func parseComposerComparator(constraint string) ([]*composerComparator, string, error) {
+	if match := composerAliasRegexp.FindStringSubmatch(constraint); match != nil {
+		constraint = match[1]
+	}
+	flag := ""
+	if match := composerConstraintFlagRegex.FindStringSubmatch(constraint); match != nil {
+		constraint = match[1]
+		if constraint == "" {
+			constraint = "*"
+		}
+		if stability := normalizeComposerStabilityName(match[2]); stability != ComposerStabilityStable {
+			flag = stability
+		}
+	}
+	if match := composerRefRegexp.FindStringSubmatch(constraint); match != nil {
+		constraint = match[1]
+	}
+
+	if match := composerMatchAllRegexp.FindStringSubmatch(constraint); match != nil {
+		if match[1] != "" || match[2] != "" {
+			return []*composerComparator{newComposerComparator(">=", "0.0.0.0-dev")}, flag, nil
+		}
+		return nil, flag, nil
+	}
+
+	if matches := composerTildeRegexp.FindStringSubmatch(constraint); matches != nil {
+		if strings.HasPrefix(constraint, "~>") {
+			return nil, "", fmt.Errorf("invalid operator \"~>\", you probably meant to use the \"~\" operator")
+		}
+		position := composerPosition(matches[1:5])
+		low, err := normalizeComposerVersion(constraint[1:] + composerStabilitySuffix(matches))
+		if err != nil {
+			return nil, "", err
+		}
+		highPosition := position - 1
+		if highPosition < 1 {
+			highPosition = 1
+		}
+		return []*composerComparator{
+			newComposerComparator(">=", low),
+			newComposerComparator("<", manipulateComposerVersion(matches[1:5], highPosition, 1)+"-dev"),
+		}, flag, nil
+	}
+
+	if matches := composerCaretRegexp.FindStringSubmatch(constraint); matches != nil {
+		position := 3
+		if matches[1] != "0" || matches[2] == "" {
+			position = 1
+		} else if matches[2] != "0" || matches[3] == "" {
+			position = 2
+		}
+		low, err := normalizeComposerVersion(constraint[1:] + composerStabilitySuffix(matches))
+		if err != nil {
+			return nil, "", err
+		}
+		return []*composerComparator{
+			newComposerComparator(">=", low),
+			newComposerComparator("<", manipulateComposerVersion(matches[1:5], position, 1)+"-dev"),
+		}, flag, nil
+	}
+
+	if matches := composerXRangeRegexp.FindStringSubmatch(constraint); matches != nil {
+		numbers := append(matches[1:4], "")
+		position := composerPosition(numbers)
+		low := manipulateComposerVersion(numbers, position, 0) + "-dev"
+		high := newComposerComparator("<", manipulateComposerVersion(numbers, position, 1)+"-dev")
+		if low == "0.0.0.0-dev" {
+			return []*composerComparator{high}, flag, nil
+		}
+		return []*composerComparator{newComposerComparator(">=", low), high}, flag, nil
+	}
+
+	if matches := composerHyphenRegexp.FindStringSubmatch(constraint); matches != nil {
+		from, to := matches[1:9], matches[9:17]
+		low, err := normalizeComposerVersion(from[0])
+		if err != nil {
+			return nil, "", err
+		}
+		high, err := normalizeComposerVersion(to[0])
+		if err != nil {
+			return nil, "", err
+		}
+		comparators := []*composerComparator{newComposerComparator(">=", low+composerStabilitySuffix(from))}
+		if (to[2] != "" && to[3] != "") || to[5] != "" || to[7] != "" {
+			comparators = append(comparators, newComposerComparator("<=", high))
+		} else {
+			position := 2
+			if to[2] == "" {
+				position = 1
+			}
+			comparators = append(comparators, newComposerComparator("<", manipulateComposerVersion(to[1:5], position, 1)+"-dev"))
+		}
+		return comparators, flag, nil
+	}
+
+	matches := composerBasicRegexp.FindStringSubmatch(constraint)
+	version, err := normalizeComposerVersion(matches[2])
+	if err != nil {
+		// 把 "foobar-dev" 这样的约束当成 "dev-foobar"
+		if !strings.HasSuffix(matches[2], "-dev") || !composerDevNameRegexp.MatchString(matches[2]) {
+			return nil, "", fmt.Errorf("could not parse version constraint %s", constraint)
+		}
+		version = "dev-" + strings.TrimSuffix(matches[2], "-dev")
+	}
+	operator := matches[1]
+	if operator == "" {
+		operator = "=="
+	}
+	if operator != "==" && operator != "=" && flag != "" && ParseComposerStability(version) == ComposerStabilityStable {
+		version += "-" + flag
+	} else if (operator == "<" || operator == ">=") && !composerModifierEndRegexp.MatchString(strings.ToLower(matches[2])) && !strings.HasPrefix(matches[2], "dev-") {
+		version += "-dev"
+	}
+	return []*composerComparator{newComposerComparator(operator, version)}, flag, nil
+}
+
+// normalizeComposerStabilityName 规范化稳定性名称，与 VersionParser::normalizeStability 一致
+func normalizeComposerStabilityName(stability string) string {
+	s := strings.ToLower(stability)
+	if s == "rc" {
+		return ComposerStabilityRC
+	}
+	return s
+}
+
+// composerPosition 返回最后一个写出来的数字的位置，从1开始
+func composerPosition(numbers []string) int {
+	for i := len(numbers) - 1; i > 0; i-- {
+		if numbers[i] != "" {
+			return i + 1
+		}
+	}
+	return 1
+}
+
+// composerStabilitySuffix 约束中的版本没有稳定性时返回 "-dev"，这样下界可以包含所有的预发布版本
+func composerStabilitySuffix(matches []string) string {
+	if matches[5] == "" && matches[7] == "" {
+		return "-dev"
+	}
+	return ""
+}
+
+// manipulateComposerVersion 生成四段的版本号，与 VersionParser::manipulateVersionString 一致
+//
+// 位置 position 之后的数字置为0，位置 position 上的数字加上 increment。
+func manipulateComposerVersion(numbers []string, position int, increment int) string {
+	result := make([]string, 4)
+	for i := 3; i >= 0; i-- {
+		n, _ := strconv.Atoi(numbers[i])
+		switch {
+		case i+1 > position:
+			n = 0
+		case i+1 == position:
+			n += increment
+		}
+		result[i] = strconv.Itoa(n)
+	}
+	return strings.Join(result, ".")
+}
+
+// newComposerComparator 创建基本比较条件，版本必须是规范化之后的版本号
+func newComposerComparator(operator string, normalized string) *composerComparator {
+	switch operator {
+	case "=":
+		operator = "=="
+	case "<>":
+		operator = "!="
+	}
+	return &composerComparator{
+		operator: operator,
+		version:  MustParse(normalized, SchemeComposer),
+	}
+}
+
+// matches 判断版本是否满足比较条件，与 Constraint::versionCompare 一致，分支版本只能被 "==" 和 "!=" 匹配
+func (x *composerComparator) matches(cv *ComposerVersion) bool {
+	target := composerVersionOf(x.version)
+	if x.operator == "!=" && (cv.IsBranch() || target.IsBranch()) {
+		return cv.Normalized != target.Normalized
+	}
+	if cv.IsBranch() || target.IsBranch() {
+		return x.operator == "==" && cv.Normalized == target.Normalized
+	}
+	r := PHPVersionCompare(cv.Normalized, target.Normalized)
+	switch x.operator {
+	case "==":
+		return r == 0
+	case "!=":
+		return r != 0
+	case ">":
+		return r > 0
+	case ">=":
+		return r >= 0
+	case "<":
+		return r < 0
+	case "<=":
+		return r <= 0
+	}
+	return false
+}
+
+// interval 返回比较条件的外接区间
+func (x *composerComparator) interval() *VersionInterval {
+	switch x.operator {
+	case "==":
+		return NewVersionInterval(x.version, ContainsPolicyYes, x.version, ContainsPolicyYes)
+	case ">":
+		return NewVersionInterval(x.version, ContainsPolicyNo, nil, ContainsPolicyNone)
+	case ">=":
+		return NewVersionInterval(x.version, ContainsPolicyYes, nil, ContainsPolicyNone)
+	case "<":
+		return NewVersionInterval(nil, ContainsPolicyNone, x.version, ContainsPolicyNo)
+	case "<=":
+		return NewVersionInterval(nil, ContainsPolicyNone, x.version, ContainsPolicyYes)
+	}
+	return &VersionInterval{}
+}
+
+// String 返回运算符和规范化之后的版本，如 ">=1.2.0.0-dev"
+func (x *composerComparator) String() string {
+	return x.operator + composerVersionOf(x.version).Normalized
+}
+
+// Check 判断版本是否满足约束，与 composer/semver 的 Semver::satisfies 一致，不考虑稳定性，版本应该使用 SchemeComposer 解析
+func (x *ComposerConstraint) Check(v *Version) bool {
+	cv := composerVersionOf(v)
+	if cv == nil {
+		return false
+	}
+	for _, group := range x.groups {
+		if checkComposerGroup(group, cv) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkComposerGroup 判断版本是否满足组内所有的比较条件
+func checkComposerGroup(group []*composerComparator, cv *ComposerVersion) bool {
+	for _, c := range group {
+		if !c.matches(cv) {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckWithMinimumStability 判断版本是否满足约束，并且版本的稳定性不低于允许的稳定性
+//
+// 与 composer 一样，约束中的稳定性标记（如 "@dev"）或者约束中版本号的稳定性（如 ">=1.0-beta"）
+// 比 minimumStability 更宽松时使用前者。
+//
+// 参数:
+//   - v: 要检查的版本
+//   - minimumStability: composer.json 中的 minimum-stability，如 ComposerStabilityStable
+//
+// 返回:
+//   - bool: 满足时返回 true
+func (x *ComposerConstraint) CheckWithMinimumStability(v *Version, minimumStability string) bool {
+	if !x.Check(v) {
+		return false
+	}
+	allowed := composerStabilityLevels[normalizeComposerStabilityName(minimumStability)]
+	if level, ok := composerStabilityLevels[x.stability]; ok && level > allowed {
+		allowed = level
+	}
+	return composerStabilityLevels[composerVersionOf(v).Stability()] <= allowed
+}
+
+// Stability 返回约束中的稳定性标记，没有显式的标记时从约束中的版本号推断，都没有时返回空字符串
+func (x *ComposerConstraint) Stability() string {
+	return x.stability
+}
+
+// Filter 过滤出满足约束的版本，返回的版本保持输入时的顺序
+func (x *ComposerConstraint) Filter(versions []*Version) []*Version {
+	return FilterVersions(versions, x)
+}
+
+// BoundingIntervals 返回每个"并且"组的外接区间
+func (x *ComposerConstraint) BoundingIntervals() []*VersionInterval {
+	intervals := make([]*VersionInterval, 0, len(x.groups))
+	for _, group := range x.groups {
+		interval := &VersionInterval{}
+		for _, c := range group {
+			interval = interval.Intersect(c.interval())
+		}
+		intervals = append(intervals, interval)
+	}
+	return intervals
+}
+
+// ExpandedString 返回展开之后的约束，如 "^1.2" 返回 ">=1.2.0.0-dev <2.0.0.0-dev"，匹配任意版本的组为 "*"
+func (x *ComposerConstraint) ExpandedString() string {
+	groups := make([]string, 0, len(x.groups))
+	for _, group := range x.groups {
+		comparators := make([]string, 0, len(group))
+		for _, c := range group {
+			comparators = append(comparators, c.String())
+		}
+		if len(comparators) == 0 {
+			comparators = append(comparators, "*")
+		}
+		groups = append(groups, strings.Join(comparators, " "))
+	}
+	return strings.Join(groups, " || ")
+}
+
+// String 返回原始的约束
+func (x *ComposerConstraint) String() string {
+	return x.raw
+}
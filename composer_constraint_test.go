@@ -0,0 +1,106 @@
+package versions
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseComposerConstraint 测试 Composer 版本约束的展开，用例来自 composer/semver 的 VersionParserTest
+func TestParseComposerConstraint(t *testing.T) {
+	expanded := map[string]string{
+		"*":                   "*",
+		"@dev":                "*",
+		"v*":                  ">=0.0.0.0-dev",
+		"^1.2":                ">=1.2.0.0-dev <2.0.0.0-dev",
+		"^1.2.3-beta.2":       ">=1.2.3.0-beta2 <2.0.0.0-dev",
+		"^0.3":                ">=0.3.0.0-dev <0.4.0.0-dev",
+		"^0.0.3":              ">=0.0.3.0-dev <0.0.4.0-dev",
+		"^0":                  ">=0.0.0.0-dev <1.0.0.0-dev",
+		"~1":                  ">=1.0.0.0-dev <2.0.0.0-dev",
+		"~1.2":                ">=1.2.0.0-dev <2.0.0.0-dev",
+		"~1.2.3":              ">=1.2.3.0-dev <1.3.0.0-dev",
+		"~1.2-beta":           ">=1.2.0.0-beta <2.0.0.0-dev",
+		"1.0.*":               ">=1.0.0.0-dev <1.1.0.0-dev",
+		"1.*":                 ">=1.0.0.0-dev <2.0.0.0-dev",
+		"0.*":                 "<1.0.0.0-dev",
+		"1.0 - 2.0":           ">=1.0.0.0-dev <2.1.0.0-dev",
+		"1.2.3 - 2.3.4.5":     ">=1.2.3.0-dev <=2.3.4.5",
+		"1.2-beta - 2.3-dev":  ">=1.2.0.0-beta <=2.3.0.0-dev",
+		">=1.0":               ">=1.0.0.0-dev",
+		">= 1.0, < 2.0":       ">=1.0.0.0-dev <2.0.0.0-dev",
+		">1.0 <=2.0":          ">1.0.0.0 <=2.0.0.0",
+		"<>1.0":               "!=1.0.0.0",
+		"=1.0":                "==1.0.0.0",
+		">=1.0@dev":           ">=1.0.0.0-dev",
+		">1.0@beta":           ">1.0.0.0-beta",
+		"1.0 as 2.0":          "==1.0.0.0",
+		"dev-master":          "==dev-master",
+		"dev-master#abcd":     "==dev-master",
+		"foo-dev":             "==dev-foo",
+		"1.0.x-dev":           "==1.0.9999999.9999999-dev",
+		">=1.0 <2.0 || 3.0.*": ">=1.0.0.0-dev <2.0.0.0-dev || >=3.0.0.0-dev <3.1.0.0-dev",
+		"^1.0 | ^2.0":         ">=1.0.0.0-dev <2.0.0.0-dev || >=2.0.0.0-dev <3.0.0.0-dev",
+	}
+	for raw, expected := range expanded {
+		c, err := ParseComposerConstraint(raw)
+		if assert.Nil(t, err, raw) {
+			assert.Equal(t, expected, c.ExpandedString(), raw)
+			assert.Equal(t, raw, c.String())
+		}
+	}
+
+	for _, invalid := range []string{"", "~>1.0", "foo", "1.0 ||", ">= ", "^", "1.0 - ", "@foo"} {
+		_, err := ParseComposerConstraint(invalid)
+		assert.True(t, errors.Is(err, ErrComposerConstraintInvalid), invalid)
+	}
+}
+
+// TestComposerConstraint_Check 测试 Composer 版本约束的匹配
+func TestComposerConstraint_Check(t *testing.T) {
+	cases := []struct {
+		constraint string
+		includes   []string
+		excludes   []string
+	}{
+		{"^1.2", []string{"1.2.0", "1.2.0-beta", "1.9.9"}, []string{"1.1.9", "2.0.0", "2.0.0-dev", "dev-master"}},
+		{"~1.2.3", []string{"1.2.3", "1.2.9"}, []string{"1.3.0", "1.2.2"}},
+		{"1.0.*", []string{"1.0.0", "1.0.9-RC1"}, []string{"1.1.0", "0.9"}},
+		{">=1.0 <2.0 || ^3.0", []string{"1.0.0", "3.5.0"}, []string{"2.0.0", "4.0.0"}},
+		{"!=1.5", []string{"1.4", "1.6", "dev-master"}, []string{"1.5.0"}},
+		{"dev-master", []string{"dev-master"}, []string{"dev-main", "1.0.0"}},
+		{"1.x-dev", []string{"1.x-dev"}, []string{"1.0.0"}},
+		{"*", []string{"0.0.1", "1.0.0-dev"}, []string{}},
+		{"1.0.0 - 2.1.3", []string{"1.0.0", "2.1.3"}, []string{"2.1.4", "0.9"}},
+	}
+	for _, c := range cases {
+		constraint := MustParseComposerConstraint(c.constraint)
+		for _, v := range c.includes {
+			assert.True(t, constraint.Check(MustParse(v, SchemeComposer)), "%s should include %s", c.constraint, v)
+		}
+		for _, v := range c.excludes {
+			assert.False(t, constraint.Check(MustParse(v, SchemeComposer)), "%s should exclude %s", c.constraint, v)
+		}
+
+		// 外接区间必须覆盖所有匹配的版本
+		assertQueryMatcherMatchesFilter(t, SchemeComposer, constraint, append(append([]string{}, c.includes...), c.excludes...)...)
+	}
+}
+
+// TestComposerConstraint_CheckWithMinimumStability 测试稳定性标记和 minimum-stability 的组合
+func TestComposerConstraint_CheckWithMinimumStability(t *testing.T) {
+	assert.Equal(t, "", MustParseComposerConstraint("^1.0").Stability())
+	assert.Equal(t, ComposerStabilityBeta, MustParseComposerConstraint("^1.0@beta").Stability())
+	assert.Equal(t, ComposerStabilityDev, MustParseComposerConstraint("^1.0@beta || @dev").Stability())
+	assert.Equal(t, ComposerStabilityRC, MustParseComposerConstraint(">=1.0-RC1").Stability())
+	assert.Equal(t, ComposerStabilityDev, MustParseComposerConstraint("dev-main").Stability())
+
+	beta := MustParse("1.1.0-beta", SchemeComposer)
+	assert.False(t, MustParseComposerConstraint("^1.0").CheckWithMinimumStability(beta, ComposerStabilityStable))
+	assert.True(t, MustParseComposerConstraint("^1.0").CheckWithMinimumStability(beta, ComposerStabilityBeta))
+	assert.True(t, MustParseComposerConstraint("^1.0@beta").CheckWithMinimumStability(beta, ComposerStabilityStable))
+	assert.False(t, MustParseComposerConstraint("^1.0@RC").CheckWithMinimumStability(beta, "stable"))
+	assert.False(t, MustParseComposerConstraint("^1.0@dev").CheckWithMinimumStability(MustParse("0.9", SchemeComposer), ComposerStabilityDev))
+	assert.True(t, MustParseComposerConstraint("dev-main").CheckWithMinimumStability(MustParse("dev-main", SchemeComposer), ComposerStabilityStable))
+}
@@ -0,0 +1,441 @@
+package versions
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrConstraintInvalid 表示版本约束表达式格式无效的错误
+	//
+	// 当尝试解析不符合语法的版本约束字符串时返回此错误
+	ErrConstraintInvalid = errors.New("constraint invalid")
+)
+
+// constraintOperator 版本约束中的比较运算符
+type constraintOperator string
+
+const (
+	constraintOperatorEqual          constraintOperator = "="
+	constraintOperatorNotEqual       constraintOperator = "!="
+	constraintOperatorGreater        constraintOperator = ">"
+	constraintOperatorGreaterOrEqual constraintOperator = ">="
+	constraintOperatorLess           constraintOperator = "<"
+	constraintOperatorLessOrEqual    constraintOperator = "<="
+)
+
+// constraintComparator 约束中的一个基本比较条件，例如 ">=1.2.0"
+//
+// "~"、"^"、通配符等写法在解析时都会被展开为基本比较条件
+type constraintComparator struct {
+	operator constraintOperator
+	version  *Version
+}
+
+// Check 判断版本是否满足比较条件
+func (x *constraintComparator) Check(v *Version) bool {
+	r := v.CompareTo(x.version)
+	switch x.operator {
+	case constraintOperatorEqual:
+		return r == 0
+	case constraintOperatorNotEqual:
+		return r != 0
+	case constraintOperatorGreater:
+		return r > 0
+	case constraintOperatorGreaterOrEqual:
+		return r >= 0
+	case constraintOperatorLess:
+		return r < 0
+	case constraintOperatorLessOrEqual:
+		return r <= 0
+	default:
+		return false
+	}
+}
+
+// String 返回比较条件的字符串形式
+func (x *constraintComparator) String() string {
+	return string(x.operator) + x.version.Raw
+}
+
+// Constraint 表示一个版本约束表达式
+//
+// 支持的语法:
+//   - 比较运算符: "=1.2.0"、"!=1.2.0"、">1.2.0"、">=1.2.0"、"<2.0.0"、"<=2.0.0"，不写运算符等同于"="
+//   - 空格或逗号分隔的多个条件表示"并且"，如 ">=1.2.0 <2.0.0"
+//   - "||" 分隔的多组条件表示"或者"，如 ">=1.2.0 <2.0.0 || ^3.1"
+//   - 波浪号: "~1.2.3" 等价于 ">=1.2.3 <1.3"，"~1" 等价于 ">=1 <2"
+//   - 脱字符: "^1.2.3" 等价于 ">=1.2.3 <2"，"^0.2.3" 等价于 ">=0.2.3 <0.3"
+//   - 通配符: "1.2.x"、"1.2.*" 等价于 ">=1.2 <1.3"，"*" 匹配任意版本
+//
+// 约束中的版本号使用指定的版本号方案解析，比较时遵循该方案的规则。
+//
+// 使用示例:
+//
+//	c, err := versions.ParseConstraint(">=1.2.0 <2.0.0 || ^3.1")
+//	if err != nil {
+//	    log.Fatalf("约束表达式无效: %v", err)
+//	}
+//	fmt.Println(c.Check(versions.NewVersion("1.5.0"))) // 输出: true
+//	fmt.Println(c.Check(versions.NewVersion("2.5.0"))) // 输出: false
+//	fmt.Println(c.Check(versions.NewVersion("3.2.0"))) // 输出: true
+type Constraint struct {
+
+	// raw 原始的约束表达式
+	raw string
+
+	// schemeName 解析约束中的版本号时使用的方案
+	schemeName string
+
+	// groups 之间是"或者"的关系，每个组内的比较条件之间是"并且"的关系
+	groups [][]*constraintComparator
+}
+
+var _ BoundedVersionMatcher = &Constraint{}
+
+// ParseConstraint 使用通用版本号方案解析版本约束表达式
+//
+// 参数:
+//   - constraintStr: 约束表达式，如 ">=1.2.0 <2.0.0 || ^3.1"
+//
+// 返回:
+//   - *Constraint: 解析后的约束
+//   - error: 表达式无效时返回包装了 ErrConstraintInvalid 的错误
+func ParseConstraint(constraintStr string) (*Constraint, error) {
+	return ParseConstraintWithScheme(constraintStr, SchemeGeneric)
+}
+
+// ParseConstraintWithScheme 使用指定的版本号方案解析版本约束表达式
+//
+// 参数:
+//   - constraintStr: 约束表达式
+//   - schemeName: 解析约束中的版本号时使用的方案，如 SchemeSemVer
+//
+// 返回:
+//   - *Constraint: 解析后的约束
+//   - error: 方案未注册时返回 ErrSchemeNotFound，表达式无效时返回包装了 ErrConstraintInvalid 的错误
+//
+// 使用示例:
+//
+//	c, err := versions.ParseConstraintWithScheme("~1.2", versions.SchemeSemVer)
+func ParseConstraintWithScheme(constraintStr string, schemeName string) (*Constraint, error) {
+	scheme, ok := LookupScheme(schemeName)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrSchemeNotFound, schemeName)
+	}
+	c := &Constraint{
+		raw:        strings.TrimSpace(constraintStr),
+		schemeName: schemeName,
+		groups:     make([][]*constraintComparator, 0),
+	}
+	for _, groupStr := range strings.Split(constraintStr, "||") {
+		group, err := parseConstraintGroup(scheme, groupStr)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q: %s", ErrConstraintInvalid, constraintStr, err.Error())
+		}
+		c.groups = append(c.groups, group)
+	}
+	return c, nil
+}
+
+// MustParseConstraint 与 ParseConstraint 相同，但是在解析失败时会 panic
+func MustParseConstraint(constraintStr string) *Constraint {
+	c, err := ParseConstraint(constraintStr)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// parseConstraintGroup 解析一组以空格或逗号分隔的比较条件
+func parseConstraintGroup(scheme Scheme, groupStr string) ([]*constraintComparator, error) {
+	group := make([]*constraintComparator, 0)
+	runes := []rune(groupStr)
+	i := 0
+	isSeparator := func(c rune) bool {
+		return c == ' ' || c == '\t' || c == ','
+	}
+	for i < len(runes) {
+		for i < len(runes) && isSeparator(runes[i]) {
+			i++
+		}
+		if i >= len(runes) {
+			break
+		}
+
+		// 先读取运算符，运算符和版本号之间允许有空格
+		start := i
+		for i < len(runes) && strings.ContainsRune("<>=!~^", runes[i]) {
+			i++
+		}
+		operator := string(runes[start:i])
+		for i < len(runes) && (runes[i] == ' ' || runes[i] == '\t') {
+			i++
+		}
+		start = i
+		for i < len(runes) && !isSeparator(runes[i]) {
+			i++
+		}
+		versionStr := string(runes[start:i])
+		if versionStr == "" {
+			return nil, fmt.Errorf("operator %q without version", operator)
+		}
+
+		comparators, err := expandConstraintComparator(scheme, operator, versionStr)
+		if err != nil {
+			return nil, err
+		}
+		group = append(group, comparators...)
+	}
+	return group, nil
+}
+
+// expandConstraintComparator 把一个可能带有"~"、"^"、通配符的条件展开为基本比较条件
+func expandConstraintComparator(scheme Scheme, operator string, versionStr string) ([]*constraintComparator, error) {
+	numbers, isWildcard, err := parseConstraintWildcard(versionStr)
+	if err != nil {
+		return nil, err
+	}
+
+	// 不带通配符的完整版本号
+	if !isWildcard {
+		v, err := parseConstraintVersion(scheme, versionStr)
+		if err != nil {
+			return nil, err
+		}
+		switch operator {
+		case "", "=", "==":
+			return []*constraintComparator{{constraintOperatorEqual, v}}, nil
+		case "!=":
+			return []*constraintComparator{{constraintOperatorNotEqual, v}}, nil
+		case ">", ">=", "<", "<=":
+			return []*constraintComparator{{constraintOperator(operator), v}}, nil
+		case "~", "~>":
+			return tildeComparators(scheme, v, v.VersionNumbers), nil
+		case "^":
+			return caretComparators(scheme, v, v.VersionNumbers), nil
+		default:
+			return nil, fmt.Errorf("unknown operator %q", operator)
+		}
+	}
+
+	// "*" 匹配任意版本，不需要任何比较条件
+	if len(numbers) == 0 {
+		switch operator {
+		case "", "=", "==", ">=", "<=", "~", "^":
+			return []*constraintComparator{}, nil
+		default:
+			return nil, fmt.Errorf("operator %q can not be used with %q", operator, versionStr)
+		}
+	}
+
+	lower := buildConstraintVersion(scheme, numbers)
+	upper := buildConstraintVersion(scheme, bumpVersionNumbers(numbers, len(numbers)-1))
+	switch operator {
+	case "", "=", "==":
+		return []*constraintComparator{{constraintOperatorGreaterOrEqual, lower}, {constraintOperatorLess, upper}}, nil
+	case ">=":
+		return []*constraintComparator{{constraintOperatorGreaterOrEqual, lower}}, nil
+	case ">":
+		return []*constraintComparator{{constraintOperatorGreaterOrEqual, upper}}, nil
+	case "<":
+		return []*constraintComparator{{constraintOperatorLess, lower}}, nil
+	case "<=":
+		return []*constraintComparator{{constraintOperatorLess, upper}}, nil
+	case "~", "~>":
+		return tildeComparators(scheme, lower, numbers), nil
+	case "^":
+		return caretComparators(scheme, lower, numbers), nil
+	default:
+		return nil, fmt.Errorf("operator %q can not be used with %q", operator, versionStr)
+	}
+}
+
+// tildeComparators 展开波浪号条件：指定了次版本号时允许修订号变化，否则允许次版本号变化
+func tildeComparators(scheme Scheme, lower *Version, numbers VersionNumbers) []*constraintComparator {
+	index := 0
+	if len(numbers) >= 2 {
+		index = 1
+	}
+	upper := buildConstraintVersion(scheme, bumpVersionNumbers(numbers, index))
+	return []*constraintComparator{{constraintOperatorGreaterOrEqual, lower}, {constraintOperatorLess, upper}}
+}
+
+// caretComparators 展开脱字符条件：允许第一个非0的数字右边的部分变化
+func caretComparators(scheme Scheme, lower *Version, numbers VersionNumbers) []*constraintComparator {
+	index := len(numbers) - 1
+	for i, n := range numbers {
+		if n != 0 {
+			index = i
+			break
+		}
+	}
+	upper := buildConstraintVersion(scheme, bumpVersionNumbers(numbers, index))
+	return []*constraintComparator{{constraintOperatorGreaterOrEqual, lower}, {constraintOperatorLess, upper}}
+}
+
+// bumpVersionNumbers 把下标为 index 的数字加1，并丢弃它右边的部分
+func bumpVersionNumbers(numbers VersionNumbers, index int) VersionNumbers {
+	result := make([]int, index+1)
+	copy(result, numbers[:index+1])
+	result[index]++
+	return result
+}
+
+// parseConstraintWildcard 识别带通配符的版本号，如 "1.2.x"、"1.*"、"*"，返回通配符之前的数字
+func parseConstraintWildcard(versionStr string) (VersionNumbers, bool, error) {
+	parts := strings.Split(strings.TrimPrefix(strings.TrimPrefix(versionStr, "v"), "="), ".")
+	wildcardIndex := -1
+	for i, part := range parts {
+		if part == "x" || part == "X" || part == "*" {
+			wildcardIndex = i
+			break
+		}
+	}
+	if wildcardIndex == -1 {
+		return nil, false, nil
+	}
+	numbers := make([]int, 0, wildcardIndex)
+	for _, part := range parts[:wildcardIndex] {
+		n, err := strconv.Atoi(part)
+		if err != nil || !isNumericIdentifier(part) {
+			return nil, false, fmt.Errorf("invalid wildcard version %q", versionStr)
+		}
+		numbers = append(numbers, n)
+	}
+	return numbers, true, nil
+}
+
+// parseConstraintVersion 使用方案解析约束中的版本号，严格的方案无法解析只写了一部分的版本号（如"1.2"）时补0后重试
+func parseConstraintVersion(scheme Scheme, versionStr string) (*Version, error) {
+	v, err := scheme.Parse(versionStr)
+	if err == nil {
+		return v, nil
+	}
+	padded := versionStr
+	for strings.Count(padded, ".") < 2 && isNumericDotted(padded) {
+		padded += ".0"
+	}
+	if padded != versionStr {
+		if v, err := scheme.Parse(padded); err == nil {
+			return v, nil
+		}
+	}
+	return nil, err
+}
+
+// buildConstraintVersion 根据数字部分构造约束中使用的版本，尽量不补0以免影响通用方案的比较结果
+func buildConstraintVersion(scheme Scheme, numbers VersionNumbers) *Version {
+	if v, err := parseConstraintVersion(scheme, numbers.BuildGroupID()); err == nil {
+		return v
+	}
+	return NewVersion(numbers.BuildGroupID())
+}
+
+// isNumericDotted 判断字符串是否只由数字和点号组成并且以数字结尾
+func isNumericDotted(s string) bool {
+	if s == "" || !isASCIIDigit(s[len(s)-1]) {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if !isASCIIDigit(s[i]) && s[i] != '.' {
+			return false
+		}
+	}
+	return true
+}
+
+// Check 判断版本是否满足约束
+//
+// 参数:
+//   - v: 要检查的版本
+//
+// 返回:
+//   - bool: 只要满足任意一组条件中的所有比较条件即返回 true
+func (x *Constraint) Check(v *Version) bool {
+	for _, group := range x.groups {
+		if checkConstraintGroup(group, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkConstraintGroup 判断版本是否满足组内所有的比较条件
+func checkConstraintGroup(group []*constraintComparator, v *Version) bool {
+	for _, comparator := range group {
+		if !comparator.Check(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// Filter 过滤出满足约束的版本，返回的版本保持输入时的顺序
+//
+// 参数:
+//   - versions: 待过滤的版本数组
+//
+// 返回:
+//   - []*Version: 满足约束的版本数组
+func (x *Constraint) Filter(versions []*Version) []*Version {
+	return FilterVersions(versions, x)
+}
+
+// BoundingIntervals 返回每一组条件对应的外接区间
+//
+// 组内的 ">"、">="、"<"、"<="、"=" 条件会被合并为一个区间，"!=" 条件不影响区间，
+// 因此落在区间内的版本不一定满足约束，但是满足约束的版本一定落在某个区间内。
+//
+// 返回:
+//   - []*VersionInterval: 与各组条件一一对应的区间
+func (x *Constraint) BoundingIntervals() []*VersionInterval {
+	intervals := make([]*VersionInterval, 0, len(x.groups))
+	for _, group := range x.groups {
+		interval := &VersionInterval{}
+		for _, comparator := range group {
+			switch comparator.operator {
+			case constraintOperatorEqual:
+				interval = interval.Intersect(NewVersionInterval(comparator.version, ContainsPolicyYes, comparator.version, ContainsPolicyYes))
+			case constraintOperatorGreater:
+				interval = interval.Intersect(NewVersionInterval(comparator.version, ContainsPolicyNo, nil, ContainsPolicyNone))
+			case constraintOperatorGreaterOrEqual:
+				interval = interval.Intersect(NewVersionInterval(comparator.version, ContainsPolicyYes, nil, ContainsPolicyNone))
+			case constraintOperatorLess:
+				interval = interval.Intersect(NewVersionInterval(nil, ContainsPolicyNone, comparator.version, ContainsPolicyNo))
+			case constraintOperatorLessOrEqual:
+				interval = interval.Intersect(NewVersionInterval(nil, ContainsPolicyNone, comparator.version, ContainsPolicyYes))
+			}
+		}
+		intervals = append(intervals, interval)
+	}
+	return intervals
+}
+
+// SchemeName 返回解析约束中的版本号时使用的方案名称
+func (x *Constraint) SchemeName() string {
+	return x.schemeName
+}
+
+// String 返回原始的约束表达式
+func (x *Constraint) String() string {
+	return x.raw
+}
+
+// ExpandedString 返回展开为基本比较条件之后的约束表达式，如 "^1.2" 返回 ">=1.2 <2"
+func (x *Constraint) ExpandedString() string {
+	groups := make([]string, 0, len(x.groups))
+	for _, group := range x.groups {
+		comparators := make([]string, 0, len(group))
+		for _, comparator := range group {
+			comparators = append(comparators, comparator.String())
+		}
+		if len(comparators) == 0 {
+			comparators = append(comparators, "*")
+		}
+		groups = append(groups, strings.Join(comparators, " "))
+	}
+	return strings.Join(groups, " || ")
+}
@@ -0,0 +1,99 @@
+package versions
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseConstraint 测试约束表达式的解析与匹配
+func TestParseConstraint(t *testing.T) {
+	cases := []struct {
+		constraint string
+		matched    []string
+		unmatched  []string
+	}{
+		{"1.2.0", []string{"1.2.0"}, []string{"1.2.1", "1.1.9"}},
+		{"!=1.2.0", []string{"1.2.1"}, []string{"1.2.0"}},
+		{">1.2.0", []string{"1.2.1", "2.0"}, []string{"1.2.0", "1.1"}},
+		{"<=1.2.0", []string{"1.2.0", "0.9"}, []string{"1.2.1"}},
+		{">= 1.2.0, < 2.0.0", []string{"1.2.0", "1.9.9"}, []string{"1.1.9", "2.0.0"}},
+		{">=1.2.0 <2.0.0 || ^3.1", []string{"1.5.0", "3.1.0", "3.9.9"}, []string{"2.5.0", "3.0.9", "4.0.0"}},
+		{"~1.2.3", []string{"1.2.3", "1.2.9"}, []string{"1.2.2", "1.3.0", "1.3"}},
+		{"~1", []string{"1.0.0", "1.9"}, []string{"2.0.0", "0.9"}},
+		{"^1.2", []string{"1.2.0", "1.9.0"}, []string{"1.1.9", "2.0.0"}},
+		{"^0.2.3", []string{"0.2.3", "0.2.9"}, []string{"0.3.0", "0.2.2"}},
+		{"^0.0.3", []string{"0.0.3"}, []string{"0.0.4"}},
+		{"1.2.x", []string{"1.2.0", "1.2.99"}, []string{"1.3.0", "1.1.0"}},
+		{">1.x", []string{"2.0.0"}, []string{"1.9.9"}},
+		{"<=1.*", []string{"1.9.9", "0.1"}, []string{"2.0.0"}},
+		{"*", []string{"0.0.1", "99.0"}, nil},
+	}
+	for _, c := range cases {
+		constraint, err := ParseConstraint(c.constraint)
+		assert.Nil(t, err, c.constraint)
+		for _, v := range c.matched {
+			assert.True(t, constraint.Check(NewVersion(v)), "%s should match %s", c.constraint, v)
+		}
+		for _, v := range c.unmatched {
+			assert.False(t, constraint.Check(NewVersion(v)), "%s should not match %s", c.constraint, v)
+		}
+	}
+
+	for _, invalid := range []string{">=", "1.2 || <", ">*", "!=1.x", "a.x", "=~1.0"} {
+		_, err := ParseConstraint(invalid)
+		assert.True(t, errors.Is(err, ErrConstraintInvalid), invalid)
+	}
+
+	_, err := ParseConstraintWithScheme("1.0", "not-exists")
+	assert.True(t, errors.Is(err, ErrSchemeNotFound))
+}
+
+// TestParseConstraintWithScheme 测试使用严格方案解析约束中只写了一部分的版本号
+func TestParseConstraintWithScheme(t *testing.T) {
+	c, err := ParseConstraintWithScheme(">=1.2 <2", SchemeSemVer)
+	assert.Nil(t, err)
+	assert.Equal(t, SchemeSemVer, c.SchemeName())
+	assert.Equal(t, ">=1.2.0 <2.0.0", c.ExpandedString())
+	assert.True(t, c.Check(MustParse("1.2.0", SchemeSemVer)))
+	assert.False(t, c.Check(MustParse("1.2.0-alpha", SchemeSemVer)))
+
+	c, err = ParseConstraintWithScheme("~1.2", SchemeSemVer)
+	assert.Nil(t, err)
+	assert.Equal(t, ">=1.2.0 <1.3.0", c.ExpandedString())
+}
+
+// TestConstraint_String 测试约束的字符串形式
+func TestConstraint_String(t *testing.T) {
+	c := MustParseConstraint(" ^1.2 || 2.x ")
+	assert.Equal(t, "^1.2 || 2.x", c.String())
+	assert.Equal(t, ">=1.2 <2 || >=2 <3", c.ExpandedString())
+	assert.Equal(t, "*", MustParseConstraint("*").ExpandedString())
+}
+
+// TestConstraint_Filter 测试使用约束过滤版本
+func TestConstraint_Filter(t *testing.T) {
+	allVersions := NewVersions("2.1.0", "1.0.0", "1.5.0", "3.1.2", "2.0.0")
+	c := MustParseConstraint(">=1.2.0 <2.0.0 || ^3.1")
+	assert.Equal(t, []string{"1.5.0", "3.1.2"}, versionRaws(c.Filter(allVersions)))
+}
+
+// TestConstraint_BoundingIntervals 测试约束的外接区间
+func TestConstraint_BoundingIntervals(t *testing.T) {
+	c := MustParseConstraint(">=1.2.0 <2.0.0 !=1.5.0 || 3.0.0 || <=0.5")
+	intervals := c.BoundingIntervals()
+	assert.Equal(t, 3, len(intervals))
+	assert.Equal(t, "[1.2.0,2.0.0)", intervals[0].String())
+	assert.Equal(t, "[3.0.0,3.0.0]", intervals[1].String())
+	assert.Equal(t, "(,0.5]", intervals[2].String())
+}
+
+// versionRaws 取出版本的原始字符串，方便断言
+func versionRaws(versions []*Version) []string {
+	result := make([]string, 0, len(versions))
+	for _, v := range versions {
+		result = append(result, v.Raw)
+	}
+	return result
+}
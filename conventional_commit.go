@@ -0,0 +1,155 @@
+package versions
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+var (
+	// ErrConventionalCommitInvalid 表示提交信息不符合 Conventional Commits 规范的错误
+	//
+	// 当尝试解析第一行不是 "type(scope)!: description" 形式的提交信息时返回此错误
+	ErrConventionalCommitInvalid = errors.New("conventional commit invalid")
+)
+
+// conventionalCommitHeaderRegexp 提交信息的第一行，如 "feat(parser)!: drop support for ..."
+var conventionalCommitHeaderRegexp = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9-]*)(?:\(([^()\r\n]*)\))?(!)?:\s+(\S.*)$`)
+
+// conventionalCommitBreakingFooterRegexp 表示不兼容变更的脚注，按照规范必须大写
+var conventionalCommitBreakingFooterRegexp = regexp.MustCompile(`^BREAKING[ -]CHANGE:\s*(.*)$`)
+
+// ConventionalCommit 按照 Conventional Commits 1.0.0 规范解析出来的提交信息
+//
+// 参考: https://www.conventionalcommits.org/zh-hans/v1.0.0/
+type ConventionalCommit struct {
+
+	// Message 原始的提交信息
+	Message string
+
+	// Type 提交的类型，统一为小写，如 "feat"、"fix"
+	Type string
+
+	// Scope 范围，如 "feat(parser): ..." 中的 "parser"，没有时为空
+	Scope string
+
+	// Description 第一行冒号之后的描述
+	Description string
+
+	// Body 第一行之后的正文和脚注，去掉了首尾的空白
+	Body string
+
+	// Breaking 是否包含不兼容的变更，即类型之后带有 "!" 或者有 "BREAKING CHANGE:" 脚注
+	Breaking bool
+
+	// BreakingChange 对不兼容变更的说明，取自 "BREAKING CHANGE:" 脚注，只有 "!" 时为 Description
+	BreakingChange string
+}
+
+// ParseConventionalCommit 按照 Conventional Commits 规范解析一条提交信息
+//
+// 类型不区分大小写；"BREAKING CHANGE:" 和 "BREAKING-CHANGE:" 脚注可以出现在正文中的任意一行。
+//
+// 参数:
+//   - message: 完整的提交信息，第一行为标题
+//
+// 返回:
+//   - *ConventionalCommit: 解析后的提交信息
+//   - error: 不符合规范时返回包装了 ErrConventionalCommitInvalid 的错误
+//
+// 使用示例:
+//
+//	commit, err := versions.ParseConventionalCommit("feat(api)!: remove v1 endpoints")
+//	if err != nil {
+//	    log.Fatalf("不是合法的提交信息: %v", err)
+//	}
+//	fmt.Println(commit.Type, commit.Scope, commit.Breaking) // 输出: feat api true
+func ParseConventionalCommit(message string) (*ConventionalCommit, error) {
+	trimmed := strings.TrimSpace(message)
+	header, body, _ := strings.Cut(trimmed, "\n")
+	header = strings.TrimSpace(header)
+	match := conventionalCommitHeaderRegexp.FindStringSubmatch(header)
+	if match == nil {
+		return nil, fmt.Errorf("%w: illformed header %q", ErrConventionalCommitInvalid, header)
+	}
+
+	commit := &ConventionalCommit{
+		Message:     message,
+		Type:        strings.ToLower(match[1]),
+		Scope:       strings.TrimSpace(match[2]),
+		Description: strings.TrimSpace(match[4]),
+		Body:        strings.TrimSpace(body),
+		Breaking:    match[3] == "!",
+	}
+	if commit.Breaking {
+		commit.BreakingChange = commit.Description
+	}
+	for _, line := range strings.Split(commit.Body, "\n") {
+		if footer := conventionalCommitBreakingFooterRegexp.FindStringSubmatch(strings.TrimRight(line, "\r")); footer != nil {
+			commit.Breaking = true
+			commit.BreakingChange = strings.TrimSpace(footer[1])
+			break
+		}
+	}
+	return commit, nil
+}
+
+// Header 返回提交信息的第一行，如 "feat(api)!: remove v1 endpoints"
+func (x *ConventionalCommit) Header() string {
+	header, _, _ := strings.Cut(strings.TrimSpace(x.Message), "\n")
+	return strings.TrimSpace(header)
+}
+
+// BumpLevel 返回这个提交对应的版本号升级幅度：不兼容的变更升级主版本号，"feat" 升级次版本号，"fix" 升级修订号，其它类型不需要升级
+func (x *ConventionalCommit) BumpLevel() BumpLevel {
+	switch {
+	case x.Breaking:
+		return BumpLevelMajor
+	case x.Type == "feat":
+		return BumpLevelMinor
+	case x.Type == "fix":
+		return BumpLevelPatch
+	}
+	return BumpLevelNone
+}
+
+// ReadCommitMessagesFromFile 从文件中读取提交信息
+//
+// 文件中有 NUL 字符时按照 NUL 切分，每一段是一条完整的提交信息，可以由 `git log --format=%B%x00` 生成；
+// 否则每一行是一条提交信息的标题，可以由 `git log --format=%s` 生成。空的提交信息会被忽略。
+//
+// 参数:
+//   - filepath: 提交信息文件的路径
+//
+// 返回:
+//   - []string: 读取的提交信息
+//   - error: 如果文件读取失败则返回相应错误
+//
+// 使用示例:
+//
+//	messages, err := versions.ReadCommitMessagesFromFile("./commits.txt")
+//	if err != nil {
+//	    log.Fatalf("读取提交信息失败: %v", err)
+//	}
+//	next := versions.CalculateNextVersion(versions.NewVersion("v1.2.3"), "", messages...)
+func ReadCommitMessagesFromFile(filepath string) ([]string, error) {
+	bytes, err := os.ReadFile(filepath)
+	if err != nil {
+		return nil, err
+	}
+	separator := "\n"
+	if strings.Contains(string(bytes), "\x00") {
+		separator = "\x00"
+	}
+	messages := make([]string, 0)
+	for _, message := range strings.Split(string(bytes), separator) {
+		message = strings.TrimSpace(message)
+		if message == "" {
+			continue
+		}
+		messages = append(messages, message)
+	}
+	return messages, nil
+}
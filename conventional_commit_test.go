@@ -0,0 +1,72 @@
+package versions
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseConventionalCommit 测试解析 Conventional Commits 提交信息
+func TestParseConventionalCommit(t *testing.T) {
+	commit, err := ParseConventionalCommit("feat(api)!: remove v1 endpoints")
+	assert.Nil(t, err)
+	assert.Equal(t, "feat", commit.Type)
+	assert.Equal(t, "api", commit.Scope)
+	assert.Equal(t, "remove v1 endpoints", commit.Description)
+	assert.True(t, commit.Breaking)
+	assert.Equal(t, "remove v1 endpoints", commit.BreakingChange)
+	assert.Equal(t, BumpLevelMajor, commit.BumpLevel())
+
+	commit, err = ParseConventionalCommit("Fix: handle empty input\n\nsee #12\r\n\r\nBREAKING CHANGE: empty input now returns an error\r\n")
+	assert.Nil(t, err)
+	assert.Equal(t, "fix", commit.Type)
+	assert.Equal(t, "", commit.Scope)
+	assert.Equal(t, "Fix: handle empty input", commit.Header())
+	assert.True(t, commit.Breaking)
+	assert.Equal(t, "empty input now returns an error", commit.BreakingChange)
+
+	testCases := []struct {
+		message string
+		level   BumpLevel
+	}{
+		{"feat: add parser config", BumpLevelMinor},
+		{"fix(parser): overflow", BumpLevelPatch},
+		{"docs: update readme", BumpLevelNone},
+		{"chore(deps)!: drop go 1.17", BumpLevelMajor},
+		{"refactor: x\n\nBREAKING-CHANGE: y", BumpLevelMajor},
+		{"feat: x\n\nbreaking change: y", BumpLevelMinor},
+	}
+	for _, testCase := range testCases {
+		commit, err := ParseConventionalCommit(testCase.message)
+		assert.Nil(t, err, testCase.message)
+		assert.Equal(t, testCase.level, commit.BumpLevel(), testCase.message)
+	}
+
+	for _, message := range []string{"", "update readme", "feat add x", "feat:", "feat(: x", "Merge branch 'main'", "(api): x"} {
+		_, err := ParseConventionalCommit(message)
+		assert.True(t, errors.Is(err, ErrConventionalCommitInvalid), message)
+	}
+}
+
+// TestReadCommitMessagesFromFile 测试从文件中读取提交信息
+func TestReadCommitMessagesFromFile(t *testing.T) {
+	dir := t.TempDir()
+
+	subjects := filepath.Join(dir, "subjects.txt")
+	assert.Nil(t, os.WriteFile(subjects, []byte("feat: a\n\nfix: b  \r\ndocs: c\n"), 0644))
+	messages, err := ReadCommitMessagesFromFile(subjects)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"feat: a", "fix: b", "docs: c"}, messages)
+
+	bodies := filepath.Join(dir, "bodies.txt")
+	assert.Nil(t, os.WriteFile(bodies, []byte("fix: a\n\nBREAKING CHANGE: b\n\x00\nfeat: c\n\x00\n"), 0644))
+	messages, err = ReadCommitMessagesFromFile(bodies)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"fix: a\n\nBREAKING CHANGE: b", "feat: c"}, messages)
+
+	_, err = ReadCommitMessagesFromFile(filepath.Join(dir, "not_exists.txt"))
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,189 @@
+package versions
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	// ErrGemRequirementInvalid 表示 RubyGems 版本要求格式无效的错误
+	//
+	// 当尝试解析不符合 Gem::Requirement 语法的版本要求时返回此错误
+	ErrGemRequirementInvalid = errors.New("gem requirement invalid")
+)
+
+// gemRequirementRegexp 一个版本要求，与 Gem::Requirement::PATTERN 一致
+var gemRequirementRegexp = regexp.MustCompile(`^\s*(=|!=|>|<|>=|<=|~>)?\s*([0-9]+(?:\.[0-9a-zA-Z]+)*(?:-[0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*)?)\s*$`)
+
+// gemRequirementItem 一个运算符和版本组成的基本要求
+type gemRequirementItem struct {
+	operator string
+	version  *Version
+}
+
+// GemRequirement 表示一个 RubyGems 的版本要求，如 Gemfile 中的 "~> 2.3", ">= 2.3.1"
+//
+// 语义与 Ruby 的 Gem::Requirement 保持一致：多个要求之间是"并且"的关系，没有写运算符时表示"="，
+// 悲观运算符 "~>" 表示大于等于给定版本，并且小于给定版本 bump 之后的版本，
+// 例如 "~> 2.3" 等价于 ">= 2.3, < 3"，"~> 2.3.1" 等价于 ">= 2.3.1, < 2.4"。
+//
+// 使用示例:
+//
+//	req, err := versions.ParseGemRequirement("~> 2.3", ">= 2.3.1")
+//	if err != nil {
+//	    log.Fatalf("不是合法的Gem版本要求: %v", err)
+//	}
+//	fmt.Println(req.Check(versions.MustParse("2.9", versions.SchemeGem))) // 输出: true
+//	fmt.Println(req.Check(versions.MustParse("3.0", versions.SchemeGem))) // 输出: false
+type GemRequirement struct {
+	requirements []*gemRequirementItem
+}
+
+var _ BoundedVersionMatcher = &GemRequirement{}
+
+// ParseGemRequirement 按照 Gem::Requirement 的规则解析版本要求
+//
+// 参数:
+//   - requirements: 一个或多个要求，每个要求中也可以用逗号分隔多个要求，如 "~> 2.3, >= 2.3.1"，
+//     没有任何要求时等价于 ">= 0"
+//
+// 返回:
+//   - *GemRequirement: 解析后的版本要求
+//   - error: 版本要求无效时返回包装了 ErrGemRequirementInvalid 的错误
+func ParseGemRequirement(requirements ...string) (*GemRequirement, error) {
+	r := &GemRequirement{
+		requirements: make([]*gemRequirementItem, 0),
+	}
+	seen := make(map[string]bool)
+	for _, requirement := range requirements {
+		for _, part := range strings.Split(requirement, ",") {
+			match := gemRequirementRegexp.FindStringSubmatch(part)
+			if match == nil {
+				return nil, fmt.Errorf("%w: illformed requirement %q", ErrGemRequirementInvalid, part)
+			}
+			operator := match[1]
+			if operator == "" {
+				operator = "="
+			}
+			v, err := Parse(match[2], SchemeGem)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %q %s", ErrGemRequirementInvalid, part, err.Error())
+			}
+			item := &gemRequirementItem{operator: operator, version: v}
+			if key := item.String(); !seen[key] {
+				seen[key] = true
+				r.requirements = append(r.requirements, item)
+			}
+		}
+	}
+	if len(r.requirements) == 0 {
+		r.requirements = append(r.requirements, &gemRequirementItem{operator: ">=", version: MustParse("0", SchemeGem)})
+	}
+	return r, nil
+}
+
+// MustParseGemRequirement 与 ParseGemRequirement 相同，但是在解析失败时会 panic
+func MustParseGemRequirement(requirements ...string) *GemRequirement {
+	r, err := ParseGemRequirement(requirements...)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// satisfiedBy 判断版本是否满足这个基本要求
+func (x *gemRequirementItem) satisfiedBy(gv *GemVersion) bool {
+	r := gv.CompareTo(gemVersionOf(x.version))
+	switch x.operator {
+	case "=":
+		return r == 0
+	case "!=":
+		return r != 0
+	case ">":
+		return r > 0
+	case "<":
+		return r < 0
+	case ">=":
+		return r >= 0
+	case "<=":
+		return r <= 0
+	case "~>":
+		return r >= 0 && gv.Release().CompareTo(gemVersionOf(x.version).Bump()) < 0
+	}
+	return false
+}
+
+// interval 返回基本要求的外接区间
+func (x *gemRequirementItem) interval() *VersionInterval {
+	switch x.operator {
+	case "=":
+		return NewVersionInterval(x.version, ContainsPolicyYes, x.version, ContainsPolicyYes)
+	case ">":
+		return NewVersionInterval(x.version, ContainsPolicyNo, nil, ContainsPolicyNone)
+	case "<":
+		return NewVersionInterval(nil, ContainsPolicyNone, x.version, ContainsPolicyNo)
+	case ">=":
+		return NewVersionInterval(x.version, ContainsPolicyYes, nil, ContainsPolicyNone)
+	case "<=":
+		return NewVersionInterval(nil, ContainsPolicyNone, x.version, ContainsPolicyYes)
+	case "~>":
+		// 满足要求的版本的 release 小于 bump 之后的版本，而版本本身不会大于它的 release
+		upper := MustParse(gemVersionOf(x.version).Bump().String(), SchemeGem)
+		return NewVersionInterval(x.version, ContainsPolicyYes, upper, ContainsPolicyNo)
+	}
+	return &VersionInterval{}
+}
+
+// String 返回 "运算符 版本" 形式的字符串
+func (x *gemRequirementItem) String() string {
+	return x.operator + " " + gemVersionOf(x.version).String()
+}
+
+// Check 判断版本是否满足所有要求，版本应该使用 SchemeGem 解析，不是合法的 Gem 版本号时返回 false
+func (x *GemRequirement) Check(v *Version) bool {
+	gv := gemVersionOf(v)
+	if gv == nil {
+		return false
+	}
+	for _, requirement := range x.requirements {
+		if !requirement.satisfiedBy(gv) {
+			return false
+		}
+	}
+	return true
+}
+
+// Filter 过滤出满足版本要求的版本，返回的版本保持输入时的顺序
+func (x *GemRequirement) Filter(versions []*Version) []*Version {
+	return FilterVersions(versions, x)
+}
+
+// BoundingIntervals 返回所有要求的外接区间的交集
+func (x *GemRequirement) BoundingIntervals() []*VersionInterval {
+	interval := &VersionInterval{}
+	for _, requirement := range x.requirements {
+		interval = interval.Intersect(requirement.interval())
+	}
+	return []*VersionInterval{interval}
+}
+
+// IsPrerelease 判断要求中是否有预发布版本，与 Gem::Requirement#prerelease? 一致
+func (x *GemRequirement) IsPrerelease() bool {
+	for _, requirement := range x.requirements {
+		if gemVersionOf(requirement.version).IsPrerelease() {
+			return true
+		}
+	}
+	return false
+}
+
+// String 返回版本要求的字符串形式，与 Gem::Requirement#to_s 一致，如 "~> 2.3, >= 2.3.1"
+func (x *GemRequirement) String() string {
+	requirements := make([]string, 0, len(x.requirements))
+	for _, requirement := range x.requirements {
+		requirements = append(requirements, requirement.String())
+	}
+	return strings.Join(requirements, ", ")
+}
@@ -0,0 +1,64 @@
+package versions
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGemRequirement_Check 测试 Gem 版本要求的匹配，用例来自 RubyGems 的 test_gem_requirement.rb
+func TestGemRequirement_Check(t *testing.T) {
+	cases := []struct {
+		requirements []string
+		includes     []string
+		excludes     []string
+	}{
+		{[]string{"1.3"}, []string{"1.3", "1.3.0"}, []string{"1.2", "1.4", "1.3.a"}},
+		{[]string{"= 1.3"}, []string{"1.3"}, []string{"1.3.1"}},
+		{[]string{"!= 1.3"}, []string{"1.2", "1.4"}, []string{"1.3"}},
+		{[]string{"> 1.3"}, []string{"1.4", "2.0"}, []string{"1.3", "1.2", "1.3.a"}},
+		{[]string{"< 1.3"}, []string{"1.2", "1.3.a"}, []string{"1.3", "1.4"}},
+		{[]string{">= 1.3"}, []string{"1.3", "1.4"}, []string{"1.2", "1.3.a"}},
+		{[]string{"<= 1.3"}, []string{"1.3", "1.2"}, []string{"1.4"}},
+		{[]string{"~> 2.3"}, []string{"2.3", "2.4", "2.9.9"}, []string{"3.0", "2.2", "3.0.a", "2.3.a"}},
+		{[]string{"~> 2.3.1"}, []string{"2.3.1", "2.3.9"}, []string{"2.4", "2.3.0", "2.4.a"}},
+		{[]string{"~> 1.4.4"}, []string{"1.4.4", "1.4.5"}, []string{"1.5.0", "1.3.9"}},
+		{[]string{"~> 1.0.0.a"}, []string{"1.0.0.b", "1.0.0", "1.0.5"}, []string{"1.1.0", "0.9"}},
+		{[]string{"~> 5"}, []string{"5.0", "5.9"}, []string{"6.0", "4.9"}},
+		{[]string{"~> 2.3", ">= 2.3.1"}, []string{"2.3.1", "2.9"}, []string{"2.3.0", "3.0"}},
+		{[]string{"~> 2.3, >= 2.3.1"}, []string{"2.3.1", "2.9"}, []string{"2.3.0", "3.0"}},
+		{[]string{}, []string{"0", "1.0.a", "99"}, []string{}},
+	}
+	for _, c := range cases {
+		req, err := ParseGemRequirement(c.requirements...)
+		if !assert.Nil(t, err, c.requirements) {
+			continue
+		}
+		for _, v := range c.includes {
+			assert.True(t, req.Check(MustParse(v, SchemeGem)), "%v should include %s", c.requirements, v)
+		}
+		for _, v := range c.excludes {
+			assert.False(t, req.Check(MustParse(v, SchemeGem)), "%v should exclude %s", c.requirements, v)
+		}
+
+		// 外接区间必须覆盖所有匹配的版本
+		assertQueryMatcherMatchesFilter(t, SchemeGem, req, append(append([]string{}, c.includes...), c.excludes...)...)
+	}
+	assert.False(t, MustParseGemRequirement(">= 0").Check(NewVersion("not a gem version")))
+}
+
+// TestParseGemRequirement 测试 Gem 版本要求的解析和字符串形式
+func TestParseGemRequirement(t *testing.T) {
+	assert.Equal(t, ">= 0", MustParseGemRequirement().String())
+	assert.Equal(t, "= 1.0", MustParseGemRequirement("1.0").String())
+	assert.Equal(t, "~> 2.3, >= 2.3.1", MustParseGemRequirement("~>2.3", " >= 2.3.1 ", "~> 2.3").String())
+	assert.Equal(t, "= 1.0.pre.beta", MustParseGemRequirement("1.0-beta").String())
+	assert.True(t, MustParseGemRequirement("> 1.0.a").IsPrerelease())
+	assert.False(t, MustParseGemRequirement("> 1.0").IsPrerelease())
+
+	for _, invalid := range []string{"", "! 1", "= junk", "1..2", "~> 1, ,", "=> 1.0"} {
+		_, err := ParseGemRequirement(invalid)
+		assert.True(t, errors.Is(err, ErrGemRequirementInvalid), invalid)
+	}
+}
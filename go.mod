@@ -4,6 +4,8 @@ go 1.18
 
 require (
 	github.com/golang-infrastructure/go-compare-anything v0.0.2-0.20230108071748-35501d697475
+	github.com/golang-infrastructure/go-shuffle v0.0.2
+	github.com/golang-infrastructure/go-tuple v0.0.0-20221215155811-4ed54fe7d579
 	github.com/stretchr/testify v1.8.2
 )
 
@@ -13,9 +15,7 @@ require (
 	github.com/golang-infrastructure/go-heap v0.0.2 // indirect
 	github.com/golang-infrastructure/go-maths v0.0.0-20230110035134-3c905a5d5213 // indirect
 	github.com/golang-infrastructure/go-reflect-utils v0.0.0-20221130143747-965ef2eb09c3 // indirect
-	github.com/golang-infrastructure/go-shuffle v0.0.2 // indirect
 	github.com/golang-infrastructure/go-slice v0.0.0-20230108182432-046a7fecafcb // indirect
-	github.com/golang-infrastructure/go-tuple v0.0.0-20221215155811-4ed54fe7d579 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
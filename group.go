@@ -4,6 +4,7 @@ package versions
 //
 // 该函数将版本对象数组按照其数字部分（主版本号）进行分组，为每个不同的主版本号创建一个版本组。
 // 分组的依据是版本号的数字部分生成的组ID，如 "1.2.3" 和 "1.2.4" 会被分到同一组 "1.2"。
+// 带有纪元的版本只会和纪元相同的版本分到同一组。
 //
 // 分组可用于：
 // 1. 对特定主版本系列进行管理和查询
@@ -34,7 +35,8 @@ func Group(versions []*Version) map[string]*VersionGroup {
 	for _, v := range versions {
 		group := groupMap[v.BuildGroupID()]
 		if group == nil {
-			group = NewVersionGroup(v.VersionNumbers)
+			group = NewVersionGroup(groupNumbersOf(v))
+			group.GroupEpoch = v.Epoch
 			groupMap[v.BuildGroupID()] = group
 		}
 		group.Add(v)
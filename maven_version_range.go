@@ -0,0 +1,219 @@
+package versions
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrMavenVersionRangeInvalid 表示 Maven 版本范围格式无效的错误
+	//
+	// 当尝试解析不符合 Maven 语法的版本范围时返回此错误，例如括号不成对、区间重叠等
+	ErrMavenVersionRangeInvalid = errors.New("maven version range invalid")
+)
+
+// MavenVersionRange 表示 pom.xml 中的一个 Maven 版本范围
+//
+// 语法与 Maven 的 VersionRange.createFromVersionSpec 保持一致：
+//   - "1.0": 软性要求，推荐使用 1.0，但是任何版本都满足
+//   - "[1.0]": 只能是 1.0
+//   - "(,1.0]": 小于等于 1.0
+//   - "[1.2,1.3]": 大于等于 1.2 并且小于等于 1.3
+//   - "[1.0,2.0)": 大于等于 1.0 并且小于 2.0
+//   - "[1.5,)": 大于等于 1.5
+//   - "(,1.0],[1.2,)": 小于等于 1.0 或者大于等于 1.2，多个区间必须按顺序排列并且不能重叠
+//
+// 区间的开闭使用 ContainsPolicy 表示，区间的边界使用 SchemeMaven 解析。
+//
+// 使用示例:
+//
+//	r, err := versions.ParseMavenVersionRange("[1.0,2.0)")
+//	if err != nil {
+//	    log.Fatalf("不是合法的Maven版本范围: %v", err)
+//	}
+//	fmt.Println(r.Check(versions.MustParse("1.5", versions.SchemeMaven))) // 输出: true
+type MavenVersionRange struct {
+
+	// Recommended 软性要求的推荐版本，只有范围是单独的一个版本号（如 "1.0"）时才不为 nil
+	Recommended *Version
+
+	// Intervals 组成范围的区间，区间之间是"或者"的关系，按从小到大的顺序排列
+	Intervals []*VersionInterval
+}
+
+var _ BoundedVersionMatcher = &MavenVersionRange{}
+
+// ParseMavenVersionRange 按照 Maven 的规则解析版本范围
+//
+// 参数:
+//   - spec: 版本范围，如 "[1.0,2.0)"、"(,1.5],[2.0,)"、"1.0"
+//
+// 返回:
+//   - *MavenVersionRange: 解析后的版本范围
+//   - error: 版本范围无效时返回包装了 ErrMavenVersionRangeInvalid 的错误
+func ParseMavenVersionRange(spec string) (*MavenVersionRange, error) {
+	r := &MavenVersionRange{
+		Intervals: make([]*VersionInterval, 0),
+	}
+	process := strings.TrimSpace(spec)
+	var upperBound *Version
+	for strings.HasPrefix(process, "[") || strings.HasPrefix(process, "(") {
+		// 找到第一个右括号，两种右括号都可能出现
+		index := strings.IndexByte(process, ']')
+		if i := strings.IndexByte(process, ')'); index < 0 || (i >= 0 && i < index) {
+			index = i
+		}
+		if index < 0 {
+			return nil, fmt.Errorf("%w: %q unbounded range", ErrMavenVersionRangeInvalid, spec)
+		}
+
+		interval, err := parseMavenRestriction(process[:index+1])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q %s", ErrMavenVersionRangeInvalid, spec, err.Error())
+		}
+		if upperBound != nil && (interval.Lower == nil || interval.Lower.CompareTo(upperBound) < 0) {
+			return nil, fmt.Errorf("%w: %q ranges overlap", ErrMavenVersionRangeInvalid, spec)
+		}
+		r.Intervals = append(r.Intervals, interval)
+		upperBound = interval.Upper
+
+		process = strings.TrimSpace(process[index+1:])
+		if strings.HasPrefix(process, ",") {
+			process = strings.TrimSpace(process[1:])
+		}
+	}
+
+	if process != "" {
+		if len(r.Intervals) > 0 {
+			return nil, fmt.Errorf("%w: %q only fully-qualified sets allowed in multiple set scenario", ErrMavenVersionRangeInvalid, spec)
+		}
+		recommended, err := Parse(process, SchemeMaven)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q %s", ErrMavenVersionRangeInvalid, spec, err.Error())
+		}
+		r.Recommended = recommended
+		r.Intervals = append(r.Intervals, &VersionInterval{})
+	}
+	if len(r.Intervals) == 0 {
+		return nil, fmt.Errorf("%w: %q is empty", ErrMavenVersionRangeInvalid, spec)
+	}
+	return r, nil
+}
+
+// MustParseMavenVersionRange 与 ParseMavenVersionRange 相同，但是在解析失败时会 panic
+func MustParseMavenVersionRange(spec string) *MavenVersionRange {
+	r, err := ParseMavenVersionRange(spec)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// parseMavenRestriction 解析一个带括号的区间，如 "[1.0,2.0)"、"[1.0]"
+func parseMavenRestriction(spec string) (*VersionInterval, error) {
+	lowerPolicy, upperPolicy := ContainsPolicyNo, ContainsPolicyNo
+	if strings.HasPrefix(spec, "[") {
+		lowerPolicy = ContainsPolicyYes
+	}
+	if strings.HasSuffix(spec, "]") {
+		upperPolicy = ContainsPolicyYes
+	}
+	process := strings.TrimSpace(spec[1 : len(spec)-1])
+
+	index := strings.IndexByte(process, ',')
+	if index < 0 {
+		if lowerPolicy != ContainsPolicyYes || upperPolicy != ContainsPolicyYes {
+			return nil, fmt.Errorf("single version must be surrounded by []: %s", spec)
+		}
+		v, err := Parse(process, SchemeMaven)
+		if err != nil {
+			return nil, err
+		}
+		return NewVersionInterval(v, ContainsPolicyYes, v, ContainsPolicyYes), nil
+	}
+
+	interval := NewVersionInterval(nil, lowerPolicy, nil, upperPolicy)
+	if lowerStr := strings.TrimSpace(process[:index]); lowerStr != "" {
+		v, err := Parse(lowerStr, SchemeMaven)
+		if err != nil {
+			return nil, err
+		}
+		interval.Lower = v
+	}
+	if upperStr := strings.TrimSpace(process[index+1:]); upperStr != "" {
+		v, err := Parse(upperStr, SchemeMaven)
+		if err != nil {
+			return nil, err
+		}
+		interval.Upper = v
+	}
+	if interval.Lower != nil && interval.Upper != nil {
+		r := interval.Upper.CompareTo(interval.Lower)
+		if r < 0 || (r == 0 && (lowerPolicy != ContainsPolicyYes || upperPolicy != ContainsPolicyYes)) {
+			return nil, fmt.Errorf("range defies version ordering: %s", spec)
+		}
+	}
+	return interval, nil
+}
+
+// Check 判断版本是否满足版本范围，与 Maven 的 VersionRange.containsVersion 一致
+//
+// 注意与 Maven 一样，软性要求（如 "1.0"）满足任何版本。
+func (x *MavenVersionRange) Check(v *Version) bool {
+	for _, interval := range x.Intervals {
+		if interval.Check(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// Filter 过滤出满足版本范围的版本，返回的版本保持输入时的顺序
+func (x *MavenVersionRange) Filter(versions []*Version) []*Version {
+	return FilterVersions(versions, x)
+}
+
+// BoundingIntervals 返回组成范围的区间
+func (x *MavenVersionRange) BoundingIntervals() []*VersionInterval {
+	return x.Intervals
+}
+
+// HasRestrictions 判断范围是否真的对版本有限制，与 Maven 的 VersionRange.hasRestrictions 一致
+func (x *MavenVersionRange) HasRestrictions() bool {
+	return len(x.Intervals) > 0 && x.Recommended == nil
+}
+
+// MatchVersion 从有序版本组中选出满足版本范围的最高版本，与 Maven 的 VersionRange.matchVersion 一致
+//
+// 参数:
+//   - groups: 候选版本，版本应该使用 SchemeMaven 解析
+//
+// 返回:
+//   - *Version: 满足版本范围的最高版本，没有满足的版本时返回 nil
+//
+// 使用示例:
+//
+//	allVersions, _ := versions.ParseAll(versions.SchemeMaven, "1.0", "1.5", "2.0", "2.1")
+//	groups := versions.NewSortedVersionGroups(allVersions)
+//	r, _ := versions.ParseMavenVersionRange("(,1.5],[2.0,2.1)")
+//	fmt.Println(r.MatchVersion(groups).Raw) // 输出: 2.0
+func (x *MavenVersionRange) MatchVersion(groups *SortedVersionGroups) *Version {
+	return groups.QueryHighest(x)
+}
+
+// String 返回版本范围的字符串形式，与 Maven 的 VersionRange.toString 一致
+func (x *MavenVersionRange) String() string {
+	if x.Recommended != nil {
+		return x.Recommended.Raw
+	}
+	intervals := make([]string, 0, len(x.Intervals))
+	for _, interval := range x.Intervals {
+		if interval.Lower != nil && interval.Lower == interval.Upper {
+			intervals = append(intervals, "["+interval.Lower.Raw+"]")
+		} else {
+			intervals = append(intervals, interval.String())
+		}
+	}
+	return strings.Join(intervals, ",")
+}
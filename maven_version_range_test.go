@@ -0,0 +1,95 @@
+package versions
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseMavenVersionRange 测试 Maven 版本范围的解析，用例来自 Maven 的 VersionRangeTest
+func TestParseMavenVersionRange(t *testing.T) {
+	r, err := ParseMavenVersionRange("(,1.0]")
+	assert.Nil(t, err)
+	assert.Nil(t, r.Recommended)
+	assert.Equal(t, 1, len(r.Intervals))
+	assert.Nil(t, r.Intervals[0].Lower)
+	assert.Equal(t, "1.0", r.Intervals[0].Upper.Raw)
+	assert.Equal(t, ContainsPolicyYes, r.Intervals[0].UpperPolicy)
+	assert.True(t, r.HasRestrictions())
+	assert.Equal(t, "(,1.0]", r.String())
+
+	r, err = ParseMavenVersionRange("1.0")
+	assert.Nil(t, err)
+	assert.Equal(t, "1.0", r.Recommended.Raw)
+	assert.False(t, r.HasRestrictions())
+	assert.True(t, r.Check(MustParse("0.1", SchemeMaven)))
+	assert.Equal(t, "1.0", r.String())
+
+	r, err = ParseMavenVersionRange("[1.0]")
+	assert.Nil(t, err)
+	assert.Equal(t, "[1.0]", r.String())
+	assert.True(t, r.Check(MustParse("1.0.0", SchemeMaven)))
+	assert.False(t, r.Check(MustParse("1.0.1", SchemeMaven)))
+
+	r, err = ParseMavenVersionRange("(,1.0],[1.2,)")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(r.Intervals))
+	assert.Equal(t, "(,1.0],[1.2,)", r.String())
+
+	r, err = ParseMavenVersionRange(" [1.0 , 1.2) , (1.2,1.3] ")
+	assert.Nil(t, err)
+	assert.Equal(t, "[1.0,1.2),(1.2,1.3]", r.String())
+
+	invalid := []string{
+		"(1.0)", "[1.0)", "(1.0]", "(1.0,1.0]", "[1.0,1.0)", "(1.0,1.0)",
+		"[1.1,1.0]", "[1.0,1.2),1.3", "[1.0,1.2),(1.1,1.3]", "[1.1,1.3),(1.0,1.2]",
+		"(1.1,1.2],[1.0,1.1)", "[1.0", "", "[1.0,1.2],[,1.3]",
+	}
+	for _, spec := range invalid {
+		_, err := ParseMavenVersionRange(spec)
+		assert.True(t, errors.Is(err, ErrMavenVersionRangeInvalid), spec)
+	}
+}
+
+// TestMavenVersionRange_Check 测试 Maven 版本范围的包含判断，与 VersionRange.containsVersion 一致
+func TestMavenVersionRange_Check(t *testing.T) {
+	cases := []struct {
+		spec     string
+		version  string
+		expected bool
+	}{
+		{"[1.0,)", "1.0-SNAPSHOT", false},
+		{"[1.0,)", "1.0", true},
+		{"[1.0,1.1-SNAPSHOT]", "1.1-SNAPSHOT", true},
+		{"[1.0,1.1-SNAPSHOT]", "1.1", false},
+		{"[5.0.9.0,5.0.10.0)", "5.0.9.0", true},
+		{"[1.0,2.0)", "2.0-alpha-1", true},
+		{"[1.0,2.0)", "2.0", false},
+		{"(,1.0],[1.2,)", "1.1", false},
+		{"(,1.0],[1.2,)", "1.0.0", true},
+		{"(,1.0],[1.2,)", "1.2.1", true},
+		{"(1.0,)", "1.0.0.0", false},
+		{"(1.0,)", "1.0-sp-1", true},
+	}
+	for _, c := range cases {
+		r := MustParseMavenVersionRange(c.spec)
+		assert.Equal(t, c.expected, r.Check(MustParse(c.version, SchemeMaven)), "%s contains %s", c.spec, c.version)
+	}
+}
+
+// TestMavenVersionRange_MatchVersion 测试选出满足版本范围的最高版本
+func TestMavenVersionRange_MatchVersion(t *testing.T) {
+	allVersions, err := ParseAll(SchemeMaven, "2.1", "1.0", "2.0", "1.5", "1.0-SNAPSHOT", "3.0-beta-1")
+	assert.Nil(t, err)
+	groups := NewSortedVersionGroups(allVersions)
+
+	assert.Equal(t, "2.0", MustParseMavenVersionRange("(,1.5],[2.0,2.1)").MatchVersion(groups).Raw)
+	assert.Equal(t, "1.5", MustParseMavenVersionRange("(,1.5]").MatchVersion(groups).Raw)
+	assert.Equal(t, "3.0-beta-1", MustParseMavenVersionRange("1.0").MatchVersion(groups).Raw)
+	assert.Nil(t, MustParseMavenVersionRange("[4.0,)").MatchVersion(groups))
+
+	r := MustParseMavenVersionRange("[1.0,2.0)")
+	assert.Equal(t, []string{"1.0", "1.5"}, versionRaws(r.Filter(allVersions)))
+	assert.Equal(t, []string{"1.0", "1.5"}, versionRaws(groups.QueryMatcher(r)))
+}
@@ -0,0 +1,160 @@
+package versions
+
+import (
+	"fmt"
+)
+
+// BumpLevel 版本号的升级幅度
+type BumpLevel int
+
+const (
+
+	// BumpLevelNone 不需要发布新版本，如只有 "docs"、"chore" 类型的提交
+	BumpLevelNone BumpLevel = iota
+
+	// BumpLevelPatch 升级修订号，如 "fix" 类型的提交
+	BumpLevelPatch
+
+	// BumpLevelMinor 升级次版本号，如 "feat" 类型的提交
+	BumpLevelMinor
+
+	// BumpLevelMajor 升级主版本号，即包含不兼容变更的提交
+	BumpLevelMajor
+)
+
+// bumpLevelNames 升级幅度的名称
+var bumpLevelNames = map[BumpLevel]string{
+	BumpLevelNone:  "none",
+	BumpLevelPatch: "patch",
+	BumpLevelMinor: "minor",
+	BumpLevelMajor: "major",
+}
+
+// String 返回升级幅度的名称，如 "minor"
+func (x BumpLevel) String() string {
+	if name, ok := bumpLevelNames[x]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// index 返回升级幅度对应的数字下标，用于 Version.Bump，BumpLevelNone 为-1
+func (x BumpLevel) index() int {
+	switch x {
+	case BumpLevelMajor:
+		return 0
+	case BumpLevelMinor:
+		return 1
+	case BumpLevelPatch:
+		return 2
+	}
+	return -1
+}
+
+// NextVersion 根据提交信息计算出来的下一个版本
+type NextVersion struct {
+
+	// Version 推荐的下一个版本，不需要发布新版本时为最新版本本身
+	Version *Version
+
+	// Level 实际采用的升级幅度，主版本号为0时不兼容的变更只升级次版本号
+	Level BumpLevel
+
+	// Reason 升级的原因，如 `minor bump: new feature in "feat(api): add endpoint"`
+	Reason string
+
+	// Commit 决定升级幅度的提交，即第一个升级幅度最大的提交，不需要发布新版本时为nil
+	Commit *ConventionalCommit
+}
+
+// CalculateNextVersion 根据自最新版本以来的 Conventional Commits 提交信息计算下一个版本
+//
+// 所有提交中升级幅度最大的一个决定如何升级：不兼容的变更升级主版本号，"feat" 升级次版本号，"fix" 升级修订号，
+// 不符合规范的提交信息和其它类型的提交不会引起升级。主版本号为0时处于初始开发阶段，不兼容的变更只升级次版本号。
+//
+// 指定了预发布通道时得到的是该通道的预发布版本：
+// - 最新版本是预发布版本，并且它已经包含了所需的升级幅度时，只计算下一个预发布版本，如 "1.1.0-rc.1" 加上 "fix" 变为 "1.1.0-rc.2"
+// - 否则先升级再开始新的预发布，如 "1.0.0" 加上 "feat" 变为 "1.1.0-rc.1"
+//
+// 预发布后缀的写法由最新版本所属的方案决定，如 Debian 的 "1.0.0-1" 加上 "feat" 变为 "1.1.0~rc1"，见 Version.NextPrerelease。
+//
+// 没有指定预发布通道时得到的是正式版本，最新版本是已经包含了所需升级幅度的预发布版本时直接转为正式版本，如 "1.1.0-rc.2" 变为 "1.1.0"。
+// 预发布版本包含的升级幅度由它的数字部分判断，如 "2.0.0-rc.1" 为主版本号，"1.1.0-rc.1" 为次版本号，"1.0.1-rc.1" 为修订号。
+//
+// 参数:
+//   - latest: 最新的版本，新版本会保留它的前缀和数字之间的分隔符
+//   - channel: 预发布通道，如 "rc"、"beta"，为空时计算正式版本
+//   - messages: 自最新版本以来的提交信息，可以由 ReadCommitMessagesFromFile 读取
+//
+// 返回:
+//   - *NextVersion: 推荐的下一个版本以及升级的原因
+//   - error: 最新版本所属的方案不能表示新的版本时返回的错误，见 Version.Bump 和 Version.NextPrerelease
+//
+// 使用示例:
+//
+//	next, err := versions.CalculateNextVersion(versions.NewVersion("v1.9.3"), "", "fix: handle empty input", "feat(api): add endpoint")
+//	if err != nil {
+//	    log.Fatalf("计算下一个版本失败: %v", err)
+//	}
+//	fmt.Println(next.Version.Raw, next.Level) // 输出: v1.10.0 minor
+//	fmt.Println(next.Reason)                  // 输出: minor bump: new feature in "feat(api): add endpoint"
+func CalculateNextVersion(latest *Version, channel string, messages ...string) (*NextVersion, error) {
+	next := &NextVersion{Level: BumpLevelNone}
+	for _, message := range messages {
+		commit, err := ParseConventionalCommit(message)
+		if err != nil {
+			continue
+		}
+		if level := commit.BumpLevel(); level > next.Level {
+			next.Level, next.Commit = level, commit
+		}
+	}
+	if next.Level == BumpLevelNone {
+		next.Version = latest
+		next.Reason = "no releasable commits"
+		return next, nil
+	}
+
+	switch next.Level {
+	case BumpLevelMajor:
+		if latest.numberDigitAt(0) == "0" {
+			next.Level = BumpLevelMinor
+			next.Reason = fmt.Sprintf("%s bump: breaking change in %q while major version is 0", next.Level, next.Commit.Header())
+		} else {
+			next.Reason = fmt.Sprintf("%s bump: breaking change in %q", next.Level, next.Commit.Header())
+		}
+	case BumpLevelMinor:
+		next.Reason = fmt.Sprintf("%s bump: new feature in %q", next.Level, next.Commit.Header())
+	default:
+		next.Reason = fmt.Sprintf("%s bump: bug fix in %q", next.Level, next.Commit.Header())
+	}
+
+	var err error
+	switch {
+	case latest.IsPrerelease() && next.Level <= latest.prereleaseLevel():
+		if channel == "" {
+			next.Version, err = latest.Finalize()
+		} else {
+			next.Version, err = latest.NextPrerelease(channel)
+		}
+	case channel == "":
+		next.Version, err = latest.Bump(next.Level.index())
+	default:
+		next.Version, err = latest.startPrerelease(next.Level.index(), channel)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return next, nil
+}
+
+// prereleaseLevel 返回预发布版本相对于上一个正式版本的升级幅度，由数字部分中最后一个不为0的数字判断
+func (x *Version) prereleaseLevel() BumpLevel {
+	switch {
+	case x.numberDigitAt(2) != "0":
+		return BumpLevelPatch
+	case x.numberDigitAt(1) != "0":
+		return BumpLevelMinor
+	}
+	return BumpLevelMajor
+}
@@ -0,0 +1,127 @@
+package versions
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCalculateNextVersion 测试根据提交信息计算下一个版本
+func TestCalculateNextVersion(t *testing.T) {
+	testCases := []struct {
+		latest   string
+		channel  string
+		messages []string
+		want     string
+		level    BumpLevel
+	}{
+		// 正式版本
+		{"v1.9.3", "", []string{"fix: a", "docs: b"}, "v1.9.4", BumpLevelPatch},
+		{"v1.9.3", "", []string{"fix: a", "feat: b", "fix: c"}, "v1.10.0", BumpLevelMinor},
+		{"v1.9.3", "", []string{"feat: a", "fix!: b"}, "v2.0.0", BumpLevelMajor},
+		{"v1.9.3", "", []string{"fix: a\n\nBREAKING CHANGE: b"}, "v2.0.0", BumpLevelMajor},
+		{"release-1.9", "", []string{"feat: a"}, "release-1.10", BumpLevelMinor},
+
+		// 主版本号为0时不兼容的变更只升级次版本号
+		{"0.4.2", "", []string{"feat!: a"}, "0.5.0", BumpLevelMinor},
+		{"0.4.2", "", []string{"feat: a"}, "0.5.0", BumpLevelMinor},
+		{"0.4.2", "", []string{"fix: a"}, "0.4.3", BumpLevelPatch},
+
+		// 预发布通道
+		{"1.0.0", "rc", []string{"feat: a"}, "1.1.0-rc.1", BumpLevelMinor},
+		{"1.1.0-rc.1", "rc", []string{"fix: a"}, "1.1.0-rc.2", BumpLevelPatch},
+		{"1.1.0-rc.1", "rc", []string{"feat: a"}, "1.1.0-rc.2", BumpLevelMinor},
+		{"1.1.0-rc.1+build.5", "rc", []string{"fix: a"}, "1.1.0-rc.2+build.5", BumpLevelPatch},
+		{"1.1.0-rc.1+build.5", "rc", []string{"feat!: a"}, "2.0.0-rc.1", BumpLevelMajor},
+		{"1.1.0-rc.1", "rc", []string{"feat!: a"}, "2.0.0-rc.1", BumpLevelMajor},
+		{"1.0.1-beta.2", "rc", []string{"feat: a"}, "1.1.0-rc.1", BumpLevelMinor},
+		{"1.1.0-beta.2", "rc", []string{"fix: a"}, "1.1.0-rc.1", BumpLevelPatch},
+		{"0.3.0-rc.1", "rc", []string{"feat!: a"}, "0.3.0-rc.2", BumpLevelMinor},
+
+		// 预发布版本转为正式版本
+		{"1.1.0-rc.2", "", []string{"fix: a"}, "1.1.0", BumpLevelPatch},
+		{"1.0.1-rc.2", "", []string{"feat: a"}, "1.1.0", BumpLevelMinor},
+	}
+	for _, testCase := range testCases {
+		latest := NewVersion(testCase.latest)
+		next, err := CalculateNextVersion(latest, testCase.channel, testCase.messages...)
+		assert.Nil(t, err, testCase.latest, testCase.messages)
+		assert.Equal(t, testCase.want, next.Version.Raw, testCase.latest, testCase.messages)
+		assert.Equal(t, testCase.level, next.Level, testCase.latest, testCase.messages)
+		assert.Greater(t, next.Version.CompareTo(latest), 0, testCase.latest, testCase.messages)
+		assert.NotNil(t, next.Commit)
+	}
+
+	// 升级的原因
+	next := mustNextVersion(CalculateNextVersion(NewVersion("v1.9.3"), "", "fix: handle empty input", "feat(api): add endpoint", "feat: other"))
+	assert.Equal(t, `minor bump: new feature in "feat(api): add endpoint"`, next.Reason)
+	assert.Equal(t, "api", next.Commit.Scope)
+	next = mustNextVersion(CalculateNextVersion(NewVersion("0.1.0"), "", "refactor!: rename"))
+	assert.Equal(t, `minor bump: breaking change in "refactor!: rename" while major version is 0`, next.Reason)
+	next = mustNextVersion(CalculateNextVersion(NewVersion("1.1.0"), "", "fix(x): y"))
+	assert.Equal(t, `patch bump: bug fix in "fix(x): y"`, next.Reason)
+
+	// 不需要发布新版本
+	latest := NewVersion("1.2.3")
+	next = mustNextVersion(CalculateNextVersion(latest, "rc", "docs: a", "update readme"))
+	assert.Equal(t, BumpLevelNone, next.Level)
+	assert.Same(t, latest, next.Version)
+	assert.Nil(t, next.Commit)
+	assert.Equal(t, "no releasable commits", next.Reason)
+	assert.Equal(t, BumpLevelNone, mustNextVersion(CalculateNextVersion(latest, "")).Level)
+
+	// 其它方案的版本
+	next = mustNextVersion(CalculateNextVersion(MustParse("1.2.3", SchemeSemVer), "beta", "feat: a"))
+	assert.Equal(t, "1.3.0-beta.1", next.Version.Raw)
+	assert.Equal(t, &SemVer{Major: 1, Minor: 3, Patch: 0, Prerelease: []string{"beta", "1"}}, next.Version.Detail)
+
+	next = mustNextVersion(CalculateNextVersion(MustParse("1.1.0-rc.1+build.5", SchemeSemVer), "rc", "fix: a"))
+	assert.Equal(t, "1.1.0-rc.2+build.5", next.Version.Raw)
+	assert.Equal(t, &SemVer{Major: 1, Minor: 1, Patch: 0, Prerelease: []string{"rc", "2"}, Build: []string{"build", "5"}}, next.Version.Detail)
+
+	// 预发布后缀使用方案自己的写法，并且按照方案的规则排在对应的正式版本之前
+	schemeCases := []struct {
+		scheme  string
+		latest  string
+		channel string
+		want    string
+		release string
+	}{
+		{SchemeDebian, "1.0.0-1", "rc", "1.1.0~rc1", "1.1.0"},
+		{SchemeDebian, "1.1.0~rc1-1", "rc", "1.1.0~rc2-1", "1.1.0"},
+		{SchemeRPM, "1.0.0-1.el8", "rc", "1.1.0~rc1", "1.1.0"},
+		{SchemeRPM, "1.0.0-1.el8", "beta", "1.1.0~beta1", "1.1.0"},
+		{SchemePEP440, "1.0.0", "rc", "1.1.0rc1", "1.1.0"},
+	}
+	for _, testCase := range schemeCases {
+		latest := MustParse(testCase.latest, testCase.scheme)
+		next, err := CalculateNextVersion(latest, testCase.channel, "feat: a")
+		assert.Nil(t, err, testCase.latest)
+		assert.Equal(t, testCase.want, next.Version.Raw, testCase.latest)
+		assert.Equal(t, testCase.scheme, next.Version.SchemeName())
+		assert.Greater(t, next.Version.CompareTo(latest), 0, testCase.latest)
+		assert.Less(t, next.Version.CompareTo(MustParse(testCase.release, testCase.scheme)), 0, testCase.latest)
+	}
+
+	// 方案不能表示的预发布版本返回错误
+	_, err := CalculateNextVersion(MustParse("1.0.0", SchemeMaven), "foo", "feat: a")
+	assert.True(t, errors.Is(err, ErrPrereleaseUnsupported))
+}
+
+// mustNextVersion 取出测试用的下一个版本，计算失败时 panic
+func mustNextVersion(next *NextVersion, err error) *NextVersion {
+	if err != nil {
+		panic(err)
+	}
+	return next
+}
+
+// TestBumpLevel_String 测试升级幅度的名称
+func TestBumpLevel_String(t *testing.T) {
+	assert.Equal(t, "none", BumpLevelNone.String())
+	assert.Equal(t, "patch", BumpLevelPatch.String())
+	assert.Equal(t, "minor", BumpLevelMinor.String())
+	assert.Equal(t, "major", BumpLevelMajor.String())
+	assert.Equal(t, "unknown", BumpLevel(100).String())
+}
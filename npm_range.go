@@ -0,0 +1,531 @@
+package versions
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrNpmRangeInvalid 表示 npm 版本范围格式无效的错误
+	//
+	// 当尝试解析不符合 node-semver 语法的版本范围时返回此错误
+	ErrNpmRangeInvalid = errors.New("npm range invalid")
+)
+
+// 下面的正则表达式与 node-semver 的 internal/re.js 中非宽松模式的定义保持一致
+var (
+	npmNumericIdentifier    = `0|[1-9]\d*`
+	npmPrereleaseIdentifier = `(?:` + npmNumericIdentifier + `|\d*[a-zA-Z-][a-zA-Z0-9-]*)`
+	npmPrerelease           = `(?:-(` + npmPrereleaseIdentifier + `(?:\.` + npmPrereleaseIdentifier + `)*))`
+	npmBuild                = `(?:\+([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*))`
+	npmXRangeIdentifier     = npmNumericIdentifier + `|x|X|\*`
+	npmXRangePlain          = `[v=\s]*(` + npmXRangeIdentifier + `)(?:\.(` + npmXRangeIdentifier + `)(?:\.(` + npmXRangeIdentifier + `)(?:` + npmPrerelease + `)?` + npmBuild + `?)?)?`
+	npmGTLT                 = `((?:<|>)?=?)`
+
+	npmHyphenRangeRegexp    = regexp.MustCompile(`^\s*(` + npmXRangePlain + `)\s+-\s+(` + npmXRangePlain + `)\s*$`)
+	npmComparatorTrimRegexp = regexp.MustCompile(`([<>]=?|=)\s+([v=]*[0-9xX*])`)
+	npmTildeTrimRegexp      = regexp.MustCompile(`(\s*)~>?\s+`)
+	npmCaretTrimRegexp      = regexp.MustCompile(`(\s*)\^\s+`)
+	npmCaretRegexp          = regexp.MustCompile(`^\^` + npmXRangePlain + `$`)
+	npmTildeRegexp          = regexp.MustCompile(`^~>?` + npmXRangePlain + `$`)
+	npmXRangeRegexp         = regexp.MustCompile(`^` + npmGTLT + `\s*` + npmXRangePlain + `$`)
+	npmStarRegexp           = regexp.MustCompile(`(<|>)?=?\s*\*`)
+	npmGTE0Regexp           = regexp.MustCompile(`^\s*>=\s*0\.0\.0\s*$`)
+	npmComparatorRegexp     = regexp.MustCompile(`^` + npmGTLT + `\s*(v?(?:` + npmNumericIdentifier + `)\.(?:` + npmNumericIdentifier + `)\.(?:` + npmNumericIdentifier + `)` + npmPrerelease + `?` + npmBuild + `?)$`)
+)
+
+// npmNullSetValue 不匹配任何版本的比较条件
+const npmNullSetValue = "<0.0.0-0"
+
+// npmComparator npm 版本范围中的一个基本比较条件
+type npmComparator struct {
+
+	// operator 比较运算符，为空字符串时表示等于
+	operator string
+
+	// version 比较的版本，为 nil 时表示匹配任意版本
+	version *Version
+}
+
+// value 返回比较条件的规范形式，与 node-semver 的 Comparator.value 一致，不包含构建元数据
+func (x *npmComparator) value() string {
+	if x.version == nil {
+		return ""
+	}
+	sv := semVerOf(x.version)
+	return x.operator + (&SemVer{Major: sv.Major, Minor: sv.Minor, Patch: sv.Patch, Prerelease: sv.Prerelease, NumberDigits: sv.NumberDigits}).String()
+}
+
+// test 判断 SemVer 是否满足比较条件
+func (x *npmComparator) test(sv *SemVer) bool {
+	if x.version == nil {
+		return true
+	}
+	r := sv.CompareTo(semVerOf(x.version))
+	switch x.operator {
+	case "":
+		return r == 0
+	case ">":
+		return r > 0
+	case ">=":
+		return r >= 0
+	case "<":
+		return r < 0
+	case "<=":
+		return r <= 0
+	default:
+		return false
+	}
+}
+
+// NpmRange 表示一个 npm（node-semver）的版本范围
+//
+// 支持 node-semver 非宽松模式下的全部语法：比较运算符、"~"、"^"、x-range（"1.2.x"、"*"）、
+// 连字符范围（"1.2 - 2.3.4"）以及 "||"。
+//
+// 与 node-semver 一样，默认情况下预发布版本只有在某个比较条件带有预发布标识符，
+// 并且该比较条件的 [major, minor, patch] 与版本相同时才可能被匹配，
+// 例如 "^1.2.3-beta.2" 匹配 "1.2.3-beta.4"，但是不匹配 "1.2.4-beta.1"。
+//
+// 使用示例:
+//
+//	r, err := versions.ParseNpmRange("^1.2.3 || 2.x")
+//	if err != nil {
+//	    log.Fatalf("不是合法的npm版本范围: %v", err)
+//	}
+//	fmt.Println(r.String())                                            // 输出: >=1.2.3 <2.0.0-0||>=2.0.0 <3.0.0-0
+//	fmt.Println(r.Check(versions.MustParse("1.5.0", versions.SchemeSemVer))) // 输出: true
+type NpmRange struct {
+
+	// raw 原始的版本范围，连续的空白字符被压缩为一个空格
+	raw string
+
+	// set 之间是"或者"的关系，每个组内的比较条件之间是"并且"的关系
+	set [][]*npmComparator
+}
+
+var _ BoundedVersionMatcher = &NpmRange{}
+
+// ParseNpmRange 按照 node-semver 的规则解析版本范围
+//
+// 参数:
+//   - rangeStr: 版本范围，如 ">=1.2.7 <1.3.0"、"1.2 - 2.3.4"、"~1.2.3 || ^2"
+//
+// 返回:
+//   - *NpmRange: 解析后的版本范围
+//   - error: 版本范围无效时返回包装了 ErrNpmRangeInvalid 的错误
+func ParseNpmRange(rangeStr string) (*NpmRange, error) {
+	r := &NpmRange{
+		raw: strings.Join(strings.Fields(rangeStr), " "),
+		set: make([][]*npmComparator, 0),
+	}
+	for _, part := range strings.Split(r.raw, "||") {
+		comparators, err := parseNpmComparatorSet(strings.TrimSpace(part))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q: %s", ErrNpmRangeInvalid, rangeStr, err.Error())
+		}
+		r.set = append(r.set, comparators)
+	}
+
+	// 与 node-semver 一样去掉多余的组：不匹配任何版本的组可以去掉，匹配任意版本的组可以代替其它所有组
+	if len(r.set) > 1 {
+		first := r.set[0]
+		set := make([][]*npmComparator, 0, len(r.set))
+		for _, comparators := range r.set {
+			if comparators[0].value() != npmNullSetValue {
+				set = append(set, comparators)
+			}
+		}
+		if len(set) == 0 {
+			set = append(set, first)
+		}
+		for _, comparators := range set {
+			if len(comparators) == 1 && comparators[0].version == nil {
+				set = [][]*npmComparator{comparators}
+				break
+			}
+		}
+		r.set = set
+	}
+	return r, nil
+}
+
+// MustParseNpmRange 与 ParseNpmRange 相同，但是在解析失败时会 panic
+func MustParseNpmRange(rangeStr string) *NpmRange {
+	r, err := ParseNpmRange(rangeStr)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// parseNpmComparatorSet 解析一组以空格分隔的条件，对应 node-semver 的 Range.parseRange
+func parseNpmComparatorSet(rangeStr string) ([]*npmComparator, error) {
+	rangeStr, err := replaceNpmHyphenRange(rangeStr)
+	if err != nil {
+		return nil, err
+	}
+	rangeStr = npmComparatorTrimRegexp.ReplaceAllString(rangeStr, "$1$2")
+	rangeStr = npmTildeTrimRegexp.ReplaceAllString(rangeStr, "$1~")
+	rangeStr = npmCaretTrimRegexp.ReplaceAllString(rangeStr, "$1^")
+
+	expanded := make([]string, 0)
+	for _, comp := range strings.Split(rangeStr, " ") {
+		comp, err := expandNpmComparator(comp)
+		if err != nil {
+			return nil, err
+		}
+		expanded = append(expanded, comp)
+	}
+
+	comparators := make([]*npmComparator, 0)
+	seen := make(map[string]bool)
+	hasAny := false
+	for _, comp := range strings.Fields(strings.Join(expanded, " ")) {
+		if npmGTE0Regexp.MatchString(comp) {
+			comp = ""
+		}
+		c, err := parseNpmComparator(comp)
+		if err != nil {
+			return nil, err
+		}
+		if c.value() == npmNullSetValue {
+			return []*npmComparator{c}, nil
+		}
+		if seen[c.value()] {
+			continue
+		}
+		seen[c.value()] = true
+		if c.version == nil {
+			hasAny = true
+		}
+		comparators = append(comparators, c)
+	}
+
+	// 匹配任意版本的条件与其它条件同时存在时没有意义
+	if hasAny && len(comparators) > 1 {
+		filtered := make([]*npmComparator, 0, len(comparators)-1)
+		for _, c := range comparators {
+			if c.version != nil {
+				filtered = append(filtered, c)
+			}
+		}
+		comparators = filtered
+	}
+	if len(comparators) == 0 {
+		comparators = append(comparators, &npmComparator{})
+	}
+	return comparators, nil
+}
+
+// parseNpmComparator 解析一个已经展开的基本比较条件，空字符串表示匹配任意版本
+func parseNpmComparator(comp string) (*npmComparator, error) {
+	if comp == "" {
+		return &npmComparator{}, nil
+	}
+	m := npmComparatorRegexp.FindStringSubmatch(comp)
+	if m == nil {
+		return nil, fmt.Errorf("invalid comparator %q", comp)
+	}
+	operator := m[1]
+	if operator == "=" {
+		operator = ""
+	}
+	v, err := Parse(strings.TrimPrefix(m[2], "v"), SchemeSemVer)
+	if err != nil {
+		return nil, err
+	}
+	return &npmComparator{operator: operator, version: v}, nil
+}
+
+// expandNpmComparator 依次展开"^"、"~"、x-range 和"*"，对应 node-semver 的 parseComparator
+func expandNpmComparator(comp string) (string, error) {
+	steps := []func(string) (string, error){replaceNpmCaret, replaceNpmTilde, replaceNpmXRange}
+	for _, step := range steps {
+		parts := strings.Fields(comp)
+		for i, part := range parts {
+			replaced, err := step(part)
+			if err != nil {
+				return "", err
+			}
+			parts[i] = replaced
+		}
+		comp = strings.Join(parts, " ")
+	}
+	return npmStarRegexp.ReplaceAllString(strings.TrimSpace(comp), ""), nil
+}
+
+// isNpmX 判断 x-range 中的一段是否是通配符
+func isNpmX(id string) bool {
+	return id == "" || id == "x" || id == "X" || id == "*"
+}
+
+// npmIncrement 把数字字符串加1
+func npmIncrement(id string) (string, error) {
+	n, err := strconv.Atoi(id)
+	if err != nil || n+1 < n {
+		return "", fmt.Errorf("numeric part %q overflows", id)
+	}
+	return strconv.Itoa(n + 1), nil
+}
+
+// replaceNpmCaret 展开脱字符范围，允许第一个非0的数字右边的部分变化
+func replaceNpmCaret(comp string) (string, error) {
+	m := npmCaretRegexp.FindStringSubmatch(comp)
+	if m == nil {
+		return comp, nil
+	}
+	major, minor, patch, pre := m[1], m[2], m[3], m[4]
+	switch {
+	case isNpmX(major):
+		return "", nil
+	case isNpmX(minor):
+		next, err := npmIncrement(major)
+		return fmt.Sprintf(">=%s.0.0 <%s.0.0-0", major, next), err
+	case isNpmX(patch):
+		if major == "0" {
+			next, err := npmIncrement(minor)
+			return fmt.Sprintf(">=%s.%s.0 <%s.%s.0-0", major, minor, major, next), err
+		}
+		next, err := npmIncrement(major)
+		return fmt.Sprintf(">=%s.%s.0 <%s.0.0-0", major, minor, next), err
+	}
+
+	lower := fmt.Sprintf(">=%s.%s.%s", major, minor, patch)
+	if pre != "" {
+		lower += "-" + pre
+	}
+	if major == "0" {
+		if minor == "0" {
+			next, err := npmIncrement(patch)
+			return fmt.Sprintf("%s <%s.%s.%s-0", lower, major, minor, next), err
+		}
+		next, err := npmIncrement(minor)
+		return fmt.Sprintf("%s <%s.%s.0-0", lower, major, next), err
+	}
+	next, err := npmIncrement(major)
+	return fmt.Sprintf("%s <%s.0.0-0", lower, next), err
+}
+
+// replaceNpmTilde 展开波浪号范围，指定了次版本号时允许修订号变化，否则允许次版本号变化
+func replaceNpmTilde(comp string) (string, error) {
+	m := npmTildeRegexp.FindStringSubmatch(comp)
+	if m == nil {
+		return comp, nil
+	}
+	major, minor, patch, pre := m[1], m[2], m[3], m[4]
+	switch {
+	case isNpmX(major):
+		return "", nil
+	case isNpmX(minor):
+		next, err := npmIncrement(major)
+		return fmt.Sprintf(">=%s.0.0 <%s.0.0-0", major, next), err
+	case isNpmX(patch):
+		next, err := npmIncrement(minor)
+		return fmt.Sprintf(">=%s.%s.0 <%s.%s.0-0", major, minor, major, next), err
+	}
+	lower := fmt.Sprintf(">=%s.%s.%s", major, minor, patch)
+	if pre != "" {
+		lower += "-" + pre
+	}
+	next, err := npmIncrement(minor)
+	return fmt.Sprintf("%s <%s.%s.0-0", lower, major, next), err
+}
+
+// replaceNpmXRange 展开带有比较运算符或者通配符的 x-range，如 ">1.2"、"<=1.x"、"1.2.*"
+func replaceNpmXRange(comp string) (string, error) {
+	m := npmXRangeRegexp.FindStringSubmatch(comp)
+	if m == nil {
+		return comp, nil
+	}
+	operator, major, minor, patch := m[1], m[2], m[3], m[4]
+	xMajor := isNpmX(major)
+	xMinor := xMajor || isNpmX(minor)
+	xPatch := xMinor || isNpmX(patch)
+	if operator == "=" && xPatch {
+		operator = ""
+	}
+
+	var err error
+	switch {
+	case xMajor:
+		if operator == ">" || operator == "<" {
+			return npmNullSetValue, nil
+		}
+		return "*", nil
+	case operator != "" && xPatch:
+		if xMinor {
+			minor = "0"
+		}
+		patch = "0"
+		pre := ""
+		switch operator {
+		case ">":
+			// ">1" 等价于 ">=2.0.0"，">1.2" 等价于 ">=1.3.0"
+			operator = ">="
+			if xMinor {
+				major, err = npmIncrement(major)
+			} else {
+				minor, err = npmIncrement(minor)
+			}
+		case "<=":
+			// "<=0.7.x" 等价于 "<0.8.0-0"
+			operator = "<"
+			if xMinor {
+				major, err = npmIncrement(major)
+			} else {
+				minor, err = npmIncrement(minor)
+			}
+		}
+		if operator == "<" {
+			pre = "-0"
+		}
+		return fmt.Sprintf("%s%s.%s.%s%s", operator, major, minor, patch, pre), err
+	case xMinor:
+		next, err := npmIncrement(major)
+		return fmt.Sprintf(">=%s.0.0 <%s.0.0-0", major, next), err
+	case xPatch:
+		next, err := npmIncrement(minor)
+		return fmt.Sprintf(">=%s.%s.0 <%s.%s.0-0", major, minor, major, next), err
+	}
+	return comp, nil
+}
+
+// replaceNpmHyphenRange 展开连字符范围，如 "1.2 - 2.3.4" 等价于 ">=1.2.0 <=2.3.4"
+func replaceNpmHyphenRange(rangeStr string) (string, error) {
+	m := npmHyphenRangeRegexp.FindStringSubmatch(rangeStr)
+	if m == nil {
+		return rangeStr, nil
+	}
+	from, fromMajor, fromMinor, fromPatch := m[1], m[2], m[3], m[4]
+	to, toMajor, toMinor, toPatch, toPre := m[7], m[8], m[9], m[10], m[11]
+
+	switch {
+	case isNpmX(fromMajor):
+		from = ""
+	case isNpmX(fromMinor):
+		from = fmt.Sprintf(">=%s.0.0", fromMajor)
+	case isNpmX(fromPatch):
+		from = fmt.Sprintf(">=%s.%s.0", fromMajor, fromMinor)
+	default:
+		from = ">=" + from
+	}
+
+	var err error
+	switch {
+	case isNpmX(toMajor):
+		to = ""
+	case isNpmX(toMinor):
+		var next string
+		next, err = npmIncrement(toMajor)
+		to = fmt.Sprintf("<%s.0.0-0", next)
+	case isNpmX(toPatch):
+		var next string
+		next, err = npmIncrement(toMinor)
+		to = fmt.Sprintf("<%s.%s.0-0", toMajor, next)
+	case toPre != "":
+		to = fmt.Sprintf("<=%s.%s.%s-%s", toMajor, toMinor, toPatch, toPre)
+	default:
+		to = "<=" + to
+	}
+	return strings.TrimSpace(from + " " + to), err
+}
+
+// Check 判断版本是否满足版本范围
+//
+// 版本会按照 SemVer 进行比较，不是由 SemVer 方案解析得到的版本会根据它的数字部分和后缀构造出对应的 SemVer。
+//
+// 参数:
+//   - v: 要检查的版本
+//
+// 返回:
+//   - bool: 满足任意一组条件时返回 true
+func (x *NpmRange) Check(v *Version) bool {
+	sv := semVerOf(v)
+	for _, comparators := range x.set {
+		if checkNpmComparatorSet(comparators, sv) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkNpmComparatorSet 判断 SemVer 是否满足一组条件，包括预发布版本的额外限制
+func checkNpmComparatorSet(comparators []*npmComparator, sv *SemVer) bool {
+	for _, c := range comparators {
+		if !c.test(sv) {
+			return false
+		}
+	}
+	if !sv.IsPrerelease() {
+		return true
+	}
+
+	// 预发布版本只有在某个带有预发布标识符的条件与它的 [major, minor, patch] 相同时才能被匹配
+	for _, c := range comparators {
+		if c.version == nil {
+			continue
+		}
+		allowed := semVerOf(c.version)
+		if allowed.IsPrerelease() && allowed.Major == sv.Major && allowed.Minor == sv.Minor && allowed.Patch == sv.Patch {
+			return true
+		}
+	}
+	return false
+}
+
+// Filter 过滤出满足版本范围的版本，返回的版本保持输入时的顺序
+func (x *NpmRange) Filter(versions []*Version) []*Version {
+	return FilterVersions(versions, x)
+}
+
+// BoundingIntervals 返回每一组条件对应的外接区间
+func (x *NpmRange) BoundingIntervals() []*VersionInterval {
+	intervals := make([]*VersionInterval, 0, len(x.set))
+	for _, comparators := range x.set {
+		interval := &VersionInterval{}
+		for _, c := range comparators {
+			switch c.operator {
+			case "":
+				if c.version != nil {
+					interval = interval.Intersect(NewVersionInterval(c.version, ContainsPolicyYes, c.version, ContainsPolicyYes))
+				}
+			case ">":
+				interval = interval.Intersect(NewVersionInterval(c.version, ContainsPolicyNo, nil, ContainsPolicyNone))
+			case ">=":
+				interval = interval.Intersect(NewVersionInterval(c.version, ContainsPolicyYes, nil, ContainsPolicyNone))
+			case "<":
+				interval = interval.Intersect(NewVersionInterval(nil, ContainsPolicyNone, c.version, ContainsPolicyNo))
+			case "<=":
+				interval = interval.Intersect(NewVersionInterval(nil, ContainsPolicyNone, c.version, ContainsPolicyYes))
+			}
+		}
+		intervals = append(intervals, interval)
+	}
+	return intervals
+}
+
+// Raw 返回原始的版本范围
+func (x *NpmRange) Raw() string {
+	return x.raw
+}
+
+// String 返回展开后的版本范围，与 node-semver 的 validRange 的结果一致，匹配任意版本时返回"*"
+func (x *NpmRange) String() string {
+	sets := make([]string, 0, len(x.set))
+	for _, comparators := range x.set {
+		values := make([]string, 0, len(comparators))
+		for _, c := range comparators {
+			values = append(values, c.value())
+		}
+		sets = append(sets, strings.TrimSpace(strings.Join(values, " ")))
+	}
+	s := strings.TrimSpace(strings.Join(sets, "||"))
+	if s == "" {
+		return "*"
+	}
+	return s
+}
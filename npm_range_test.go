@@ -0,0 +1,306 @@
+package versions
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// npmRangeParseCases 来自 node-semver 的 test/fixtures/range-parse.js（非宽松模式），期望值为 validRange 的结果
+var npmRangeParseCases = []struct {
+	rangeStr string
+	expected string
+}{
+	{"1.0.0 - 2.0.0", ">=1.0.0 <=2.0.0"},
+	{"1 - 2", ">=1.0.0 <3.0.0-0"},
+	{"1.0 - 2.0", ">=1.0.0 <2.1.0-0"},
+	{"1.0.0", "1.0.0"},
+	{">=*", "*"},
+	{"", "*"},
+	{"*", "*"},
+	{">=1.0.0", ">=1.0.0"},
+	{">1.0.0", ">1.0.0"},
+	{"<=2.0.0", "<=2.0.0"},
+	{"1", ">=1.0.0 <2.0.0-0"},
+	{"<2.0.0", "<2.0.0"},
+	{">= 1.0.0", ">=1.0.0"},
+	{">=  1.0.0", ">=1.0.0"},
+	{"> 1.0.0", ">1.0.0"},
+	{"<=   2.0.0", "<=2.0.0"},
+	{"<\t2.0.0", "<2.0.0"},
+	{">=0.1.97", ">=0.1.97"},
+	{"0.1.20 || 1.2.4", "0.1.20||1.2.4"},
+	{">=0.2.3 || <0.0.1", ">=0.2.3||<0.0.1"},
+	{"||", "*"},
+	{"2.x.x", ">=2.0.0 <3.0.0-0"},
+	{"1.2.x", ">=1.2.0 <1.3.0-0"},
+	{"1.2.x || 2.x", ">=1.2.0 <1.3.0-0||>=2.0.0 <3.0.0-0"},
+	{"x", "*"},
+	{"2.*.*", ">=2.0.0 <3.0.0-0"},
+	{"1.2.*", ">=1.2.0 <1.3.0-0"},
+	{"2", ">=2.0.0 <3.0.0-0"},
+	{"2.3", ">=2.3.0 <2.4.0-0"},
+	{"~2.4", ">=2.4.0 <2.5.0-0"},
+	{"~>3.2.1", ">=3.2.1 <3.3.0-0"},
+	{"~1", ">=1.0.0 <2.0.0-0"},
+	{"~>1", ">=1.0.0 <2.0.0-0"},
+	{"~> 1", ">=1.0.0 <2.0.0-0"},
+	{"~1.0", ">=1.0.0 <1.1.0-0"},
+	{"~ 1.0", ">=1.0.0 <1.1.0-0"},
+	{"^0", "<1.0.0-0"},
+	{"^ 1", ">=1.0.0 <2.0.0-0"},
+	{"^0.1", ">=0.1.0 <0.2.0-0"},
+	{"^1.0", ">=1.0.0 <2.0.0-0"},
+	{"^1.2", ">=1.2.0 <2.0.0-0"},
+	{"^0.0.1", ">=0.0.1 <0.0.2-0"},
+	{"^0.0.1-beta", ">=0.0.1-beta <0.0.2-0"},
+	{"^0.1.2", ">=0.1.2 <0.2.0-0"},
+	{"^1.2.3", ">=1.2.3 <2.0.0-0"},
+	{"^1.2.3-beta.4", ">=1.2.3-beta.4 <2.0.0-0"},
+	{"<1", "<1.0.0-0"},
+	{"< 1", "<1.0.0-0"},
+	{">=1", ">=1.0.0"},
+	{">= 1", ">=1.0.0"},
+	{"<1.2", "<1.2.0-0"},
+	{"< 1.2", "<1.2.0-0"},
+	{"1", ">=1.0.0 <2.0.0-0"},
+	{"^ 1.2 ^ 1", ">=1.2.0 <2.0.0-0 >=1.0.0"},
+	{"1.2 - 3.4.5", ">=1.2.0 <=3.4.5"},
+	{"1.2.3 - 3.4", ">=1.2.3 <3.5.0-0"},
+	{"1.2 - 3.4", ">=1.2.0 <3.5.0-0"},
+	{">1", ">=2.0.0"},
+	{">1.2", ">=1.3.0"},
+	{">X", "<0.0.0-0"},
+	{"<X", "<0.0.0-0"},
+	{"<x <* || >* 2.x", "<0.0.0-0"},
+	{">x 2.x || * || <x", "*"},
+	{"=0.7.x", ">=0.7.0 <0.8.0-0"},
+	{"<=0.7.x", "<0.8.0-0"},
+	{">=0.7.x", ">=0.7.0"},
+	{"1.2.3-pre+asdf - 2.4.3-pre+asdf", ">=1.2.3-pre <=2.4.3-pre"},
+	{"^1.2.3+build", ">=1.2.3 <2.0.0-0"},
+}
+
+// npmRangeIncludeCases 来自 node-semver 的 test/fixtures/range-include.js（非宽松模式、不包含预发布版本）
+var npmRangeIncludeCases = [][2]string{
+	{"1.0.0 - 2.0.0", "1.2.3"},
+	{"^1.2.3+build", "1.2.3"},
+	{"^1.2.3+build", "1.3.0"},
+	{"1.2.3-pre+asdf - 2.4.3-pre+asdf", "1.2.3"},
+	{"1.2.3-pre+asdf - 2.4.3-pre+asdf", "1.2.3-pre.2"},
+	{"1.2.3-pre+asdf - 2.4.3-pre+asdf", "2.4.3-alpha"},
+	{"1.2.3+asdf - 2.4.3+asdf", "1.2.3"},
+	{"1.0.0", "1.0.0"},
+	{">=*", "0.2.4"},
+	{"", "1.0.0"},
+	{"*", "1.2.3"},
+	{">=1.0.0", "1.0.0"},
+	{">=1.0.0", "1.0.1"},
+	{">=1.0.0", "1.1.0"},
+	{">1.0.0", "1.0.1"},
+	{">1.0.0", "1.1.0"},
+	{"<=2.0.0", "2.0.0"},
+	{"<=2.0.0", "1.9999.9999"},
+	{"<=2.0.0", "0.2.9"},
+	{"<2.0.0", "1.9999.9999"},
+	{"<2.0.0", "0.2.9"},
+	{">= 1.0.0", "1.0.0"},
+	{">=  1.0.0", "1.0.1"},
+	{">=   1.0.0", "1.1.0"},
+	{"> 1.0.0", "1.0.1"},
+	{">  1.0.0", "1.1.0"},
+	{"<=   2.0.0", "2.0.0"},
+	{"<= 2.0.0", "1.9999.9999"},
+	{"<=  2.0.0", "0.2.9"},
+	{"<    2.0.0", "1.9999.9999"},
+	{"<\t2.0.0", "0.2.9"},
+	{">=0.1.97", "0.1.97"},
+	{"0.1.20 || 1.2.4", "1.2.4"},
+	{">=0.2.3 || <0.0.1", "0.0.0"},
+	{">=0.2.3 || <0.0.1", "0.2.3"},
+	{">=0.2.3 || <0.0.1", "0.2.4"},
+	{"||", "1.3.4"},
+	{"2.x.x", "2.1.3"},
+	{"1.2.x", "1.2.3"},
+	{"1.2.x || 2.x", "2.1.3"},
+	{"1.2.x || 2.x", "1.2.3"},
+	{"x", "1.2.3"},
+	{"2.*.*", "2.1.3"},
+	{"1.2.*", "1.2.3"},
+	{"1.2.* || 2.*", "2.1.3"},
+	{"1.2.* || 2.*", "1.2.3"},
+	{"2", "2.1.2"},
+	{"2.3", "2.3.1"},
+	{"~0.0.1", "0.0.1"},
+	{"~0.0.1", "0.0.2"},
+	{"~x", "0.0.9"},
+	{"~2", "2.0.9"},
+	{"~2.4", "2.4.0"},
+	{"~2.4", "2.4.5"},
+	{"~>3.2.1", "3.2.2"},
+	{"~1", "1.2.3"},
+	{"~>1", "1.2.3"},
+	{"~> 1", "1.2.3"},
+	{"~1.0", "1.0.2"},
+	{"~ 1.0", "1.0.2"},
+	{"~ 1.0.3", "1.0.12"},
+	{">=1", "1.0.0"},
+	{">= 1", "1.0.0"},
+	{"<1.2", "1.1.1"},
+	{"< 1.2", "1.1.1"},
+	{"~v0.5.4-pre", "0.5.5"},
+	{"~v0.5.4-pre", "0.5.4"},
+	{"=0.7.x", "0.7.2"},
+	{"<=0.7.x", "0.7.2"},
+	{">=0.7.x", "0.7.2"},
+	{"<=0.7.x", "0.6.2"},
+	{"~1.2.1 >=1.2.3", "1.2.3"},
+	{"~1.2.1 =1.2.3", "1.2.3"},
+	{"~1.2.1 1.2.3", "1.2.3"},
+	{"~1.2.1 >=1.2.3 1.2.3", "1.2.3"},
+	{"~1.2.1 1.2.3 >=1.2.3", "1.2.3"},
+	{">=1.2.1 1.2.3", "1.2.3"},
+	{"1.2.3 >=1.2.1", "1.2.3"},
+	{">=1.2.3 >=1.2.1", "1.2.3"},
+	{">=1.2.1 >=1.2.3", "1.2.3"},
+	{">=1.2", "1.2.8"},
+	{"^1.2.3", "1.8.1"},
+	{"^0.1.2", "0.1.2"},
+	{"^0.1", "0.1.2"},
+	{"^0.0.1", "0.0.1"},
+	{"^1.2", "1.4.2"},
+	{"^1.2 ^1", "1.4.2"},
+	{"^1.2.3-alpha", "1.2.3-pre"},
+	{"^1.2.0-alpha", "1.2.0-pre"},
+	{"^0.0.1-alpha", "0.0.1-beta"},
+	{"^0.0.1-alpha", "0.0.1"},
+	{"^0.1.1-alpha", "0.1.1-beta"},
+	{"^x", "1.2.3"},
+	{"x - 1.0.0", "0.9.7"},
+	{"x - 1.x", "0.9.7"},
+	{"1.0.0 - x", "1.9.7"},
+	{"1.x - x", "1.9.7"},
+	{"<=7.x", "7.9.9"},
+}
+
+// npmRangeExcludeCases 来自 node-semver 的 test/fixtures/range-exclude.js（非宽松模式、不包含预发布版本）
+var npmRangeExcludeCases = [][2]string{
+	{"1.0.0 - 2.0.0", "2.2.3"},
+	{"1.2.3+asdf - 2.4.3+asdf", "1.2.3-pre.2"},
+	{"1.2.3+asdf - 2.4.3+asdf", "2.4.3-alpha"},
+	{"^1.2.3+build", "2.0.0"},
+	{"^1.2.3+build", "1.2.0"},
+	{"^1.2.3", "1.2.3-pre"},
+	{"^1.2", "1.2.0-pre"},
+	{">1.2", "1.3.0-beta"},
+	{"<=1.2.3", "1.2.3-beta"},
+	{"^1.2.3", "1.2.3-beta"},
+	{"=0.7.x", "0.7.0-asdf"},
+	{">=0.7.x", "0.7.0-asdf"},
+	{"<=0.7.x", "0.7.0-asdf"},
+	{"1.0.0", "1.0.1"},
+	{">=1.0.0", "0.0.0"},
+	{">=1.0.0", "0.0.1"},
+	{">=1.0.0", "0.1.0"},
+	{">1.0.0", "0.0.1"},
+	{">1.0.0", "0.1.0"},
+	{"<=2.0.0", "3.0.0"},
+	{"<=2.0.0", "2.9999.9999"},
+	{"<=2.0.0", "2.2.9"},
+	{"<2.0.0", "2.9999.9999"},
+	{"<2.0.0", "2.2.9"},
+	{">=0.1.97", "0.1.93"},
+	{"0.1.20 || 1.2.4", "1.2.3"},
+	{">=0.2.3 || <0.0.1", "0.0.3"},
+	{">=0.2.3 || <0.0.1", "0.2.2"},
+	{"2.x.x", "1.1.3"},
+	{"2.x.x", "3.1.3"},
+	{"1.2.x", "1.3.3"},
+	{"1.2.x || 2.x", "3.1.3"},
+	{"1.2.x || 2.x", "1.1.3"},
+	{"2.*.*", "1.1.3"},
+	{"2.*.*", "3.1.3"},
+	{"1.2.*", "1.3.3"},
+	{"1.2.* || 2.*", "3.1.3"},
+	{"1.2.* || 2.*", "1.1.3"},
+	{"2", "1.1.2"},
+	{"2.3", "2.4.1"},
+	{"~0.0.1", "0.1.0-alpha"},
+	{"~0.0.1", "0.1.0"},
+	{"~2.4", "2.5.0"},
+	{"~2.4", "2.3.9"},
+	{"~>3.2.1", "3.3.2"},
+	{"~>3.2.1", "3.2.0"},
+	{"~1", "0.2.3"},
+	{"~>1", "2.2.3"},
+	{"~1.0", "1.1.0"},
+	{"<1", "1.0.0"},
+	{">=1.2", "1.1.1"},
+	{"~v0.5.4-beta", "0.5.4-alpha"},
+	{"=0.7.x", "0.8.2"},
+	{">=0.7.x", "0.6.2"},
+	{"<0.7.x", "0.7.2"},
+	{"<1.2.3", "1.2.3-beta"},
+	{"=1.2.3", "1.2.3-beta"},
+	{">1.2", "1.2.8"},
+	{"^0.0.1", "0.0.2-alpha"},
+	{"^0.0.1", "0.0.2"},
+	{"^1.2.3", "2.0.0-alpha"},
+	{"^1.2.3", "1.2.2"},
+	{"^1.2", "1.1.9"},
+	{"^1.0.0", "2.0.0-rc1"},
+	{"1 - 2", "2.0.0-pre"},
+	{"1 - 2", "1.0.0-pre"},
+	{"1.0 - 2", "1.0.0-pre"},
+	{"1.1.x", "1.0.0-a"},
+	{"1.1.x", "1.1.0-a"},
+	{"1.1.x", "1.2.0-a"},
+	{"1.x", "1.0.0-a"},
+	{"1.x", "1.1.0-a"},
+	{"1.x", "1.2.0-a"},
+	{">=1.0.0 <1.1.0", "1.1.0"},
+	{">=1.0.0 <1.1.0", "1.1.0-pre"},
+	{">=1.0.0 <1.1.0-pre", "1.1.0-pre"},
+	{"^1.2.3", "2.0.0-pre"},
+	{"<x <* || >* 2.x", "1.0.0"},
+}
+
+// TestParseNpmRange 测试 npm 版本范围的展开结果与 node-semver 一致
+func TestParseNpmRange(t *testing.T) {
+	for _, c := range npmRangeParseCases {
+		r, err := ParseNpmRange(c.rangeStr)
+		if assert.Nil(t, err, c.rangeStr) {
+			assert.Equal(t, c.expected, r.String(), c.rangeStr)
+		}
+	}
+
+	for _, invalid := range []string{">01.02.03", "~1.2.3beta", ">=09090", ">=09090-0", "blerg", "1.2.3 - ", "==1.2.3"} {
+		_, err := ParseNpmRange(invalid)
+		assert.True(t, errors.Is(err, ErrNpmRangeInvalid), invalid)
+	}
+}
+
+// TestNpmRange_Check 测试 npm 版本范围的匹配，包括预发布版本的特殊规则
+func TestNpmRange_Check(t *testing.T) {
+	for _, c := range npmRangeIncludeCases {
+		r := MustParseNpmRange(c[0])
+		assert.True(t, r.Check(MustParse(c[1], SchemeSemVer)), "%q should include %s", c[0], c[1])
+	}
+	for _, c := range npmRangeExcludeCases {
+		r := MustParseNpmRange(c[0])
+		assert.False(t, r.Check(MustParse(c[1], SchemeSemVer)), "%q should exclude %s", c[0], c[1])
+	}
+}
+
+// TestNpmRange_Filter 测试使用 npm 版本范围过滤版本以及在有序版本组中查询
+func TestNpmRange_Filter(t *testing.T) {
+	allVersions, err := ParseAll(SchemeSemVer, "2.0.0", "1.2.3-beta.2", "1.2.3", "1.2.4-beta.1", "1.9.0", "0.9.0", "2.0.0-rc.1")
+	assert.Nil(t, err)
+	r := MustParseNpmRange("^1.2.3-beta.1 || >=2")
+	assert.Equal(t, "^1.2.3-beta.1 || >=2", r.Raw())
+	assert.Equal(t, []string{"2.0.0", "1.2.3-beta.2", "1.2.3", "1.9.0"}, versionRaws(r.Filter(allVersions)))
+
+	groups := NewSortedVersionGroups(allVersions)
+	assert.Equal(t, []string{"1.2.3-beta.2", "1.2.3", "1.9.0", "2.0.0"}, versionRaws(groups.QueryMatcher(r)))
+}
@@ -0,0 +1,381 @@
+package versions
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrNuGetVersionRangeInvalid 表示 NuGet 版本范围格式无效的错误
+	//
+	// 当尝试解析不符合 NuGet 语法的版本范围时返回此错误，例如括号不成对、下界大于上界等
+	ErrNuGetVersionRangeInvalid = errors.New("nuget version range invalid")
+)
+
+// nuGetFloatBehavior 浮动版本的浮动方式，与 NuGet 的 NuGetVersionFloatBehavior 对应
+type nuGetFloatBehavior int
+
+const (
+	nuGetFloatNone               nuGetFloatBehavior = iota
+	nuGetFloatPrerelease                            // 1.0.0-beta*
+	nuGetFloatRevision                              // 1.0.0.*
+	nuGetFloatPatch                                 // 1.0.*
+	nuGetFloatMinor                                 // 1.*
+	nuGetFloatMajor                                 // *
+	nuGetFloatAbsoluteLatest                        // *-*
+	nuGetFloatPrereleaseRevision                    // 1.0.0.*-*
+	nuGetFloatPrereleasePatch                       // 1.0.*-*
+	nuGetFloatPrereleaseMinor                       // 1.*-*
+	nuGetFloatPrereleaseMajor                       // *-rc*
+)
+
+// nuGetFloatRange 浮动版本，如 "1.*"、"1.0.0-beta*"，与 NuGet 的 FloatRange 对应
+type nuGetFloatRange struct {
+	raw           string
+	behavior      nuGetFloatBehavior
+	releasePrefix string
+	min           *NuGetVersion
+}
+
+// parseNuGetFloatRange 解析浮动版本，与 FloatRange.TryParse 一致
+func parseNuGetFloatRange(s string) (*nuGetFloatRange, error) {
+	f := &nuGetFloatRange{raw: s}
+	versionPart, releasePart, hasRelease := strings.Cut(s, "-")
+	if hasRelease {
+		if strings.IndexByte(releasePart, '*') != len(releasePart)-1 {
+			return nil, fmt.Errorf("invalid floating prerelease %q", s)
+		}
+		f.releasePrefix = releasePart[:len(releasePart)-1]
+	}
+
+	parts := strings.Split(versionPart, ".")
+	wildcard := parts[len(parts)-1] == "*"
+	if strings.Contains(strings.Join(parts[:len(parts)-1], "."), "*") {
+		return nil, fmt.Errorf("invalid floating version %q", s)
+	}
+	if wildcard {
+		parts[len(parts)-1] = "0"
+	}
+	if !hasRelease && !wildcard {
+		return nil, fmt.Errorf("invalid floating version %q", s)
+	}
+
+	// 最小版本：浮动的部分按0处理，预发布标签为空或者以点号结尾时补上"0"
+	minStr := strings.Join(parts, ".")
+	if hasRelease {
+		if f.releasePrefix == "" || strings.HasSuffix(f.releasePrefix, ".") {
+			minStr += "-" + f.releasePrefix + "0"
+		} else {
+			minStr += "-" + f.releasePrefix
+		}
+	}
+	min, err := ParseNuGetVersion(minStr)
+	if err != nil {
+		return nil, err
+	}
+	f.min = min
+
+	behaviors := []nuGetFloatBehavior{nuGetFloatMajor, nuGetFloatMinor, nuGetFloatPatch, nuGetFloatRevision}
+	if hasRelease {
+		behaviors = []nuGetFloatBehavior{nuGetFloatPrereleaseMajor, nuGetFloatPrereleaseMinor, nuGetFloatPrereleasePatch, nuGetFloatPrereleaseRevision}
+	}
+	switch {
+	case !wildcard:
+		f.behavior = nuGetFloatPrerelease
+	case len(parts) > len(behaviors):
+		return nil, fmt.Errorf("invalid floating version %q", s)
+	case hasRelease && len(parts) == 1 && f.releasePrefix == "":
+		f.behavior = nuGetFloatAbsoluteLatest
+	default:
+		f.behavior = behaviors[len(parts)-1]
+	}
+	return f, nil
+}
+
+// satisfies 判断版本是否落在浮动范围内，与 FloatRange.Satisfies 一致
+func (x *nuGetFloatRange) satisfies(nv *NuGetVersion) bool {
+	releaseMatches := !nv.IsPrerelease() || strings.HasPrefix(strings.ToLower(strings.Join(nv.Release, ".")), strings.ToLower(x.releasePrefix))
+	sameMajor := nv.Major == x.min.Major
+	sameMinor := sameMajor && nv.Minor == x.min.Minor
+	samePatch := sameMinor && nv.Patch == x.min.Patch
+	switch x.behavior {
+	case nuGetFloatAbsoluteLatest:
+		return true
+	case nuGetFloatPrereleaseMajor:
+		return releaseMatches
+	case nuGetFloatMajor:
+		return !nv.IsPrerelease()
+	case nuGetFloatPrerelease:
+		return samePatch && nv.Revision == x.min.Revision && releaseMatches
+	case nuGetFloatRevision:
+		return samePatch && !nv.IsPrerelease()
+	case nuGetFloatPatch:
+		return sameMinor && !nv.IsPrerelease()
+	case nuGetFloatMinor:
+		return sameMajor && !nv.IsPrerelease()
+	case nuGetFloatPrereleaseRevision:
+		return samePatch && releaseMatches
+	case nuGetFloatPrereleasePatch:
+		return sameMinor && releaseMatches
+	case nuGetFloatPrereleaseMinor:
+		return sameMajor && releaseMatches
+	}
+	return false
+}
+
+// includesPrerelease 判断浮动范围是否允许预发布版本
+func (x *nuGetFloatRange) includesPrerelease() bool {
+	return x.behavior == nuGetFloatPrerelease || x.behavior >= nuGetFloatAbsoluteLatest
+}
+
+// NuGetVersionRange 表示一个 NuGet 的版本范围，如 PackageReference 中的 Version 属性
+//
+// 语法与 NuGet.Versioning 的 VersionRange.Parse 保持一致：
+//   - "1.0": 大于等于 1.0
+//   - "[1.0]": 只能是 1.0
+//   - "(1.0,)"、"(,1.0]"、"[1.0,2.0)": 区间表示法
+//   - "1.*"、"1.0.*"、"1.0.0-beta*"、"*-*"、"[1.*, 2.0)": 浮动版本
+//
+// Check 与 VersionRange.Satisfies 一样只检查区间，浮动版本只影响 MatchVersion 选出的版本。
+//
+// 使用示例:
+//
+//	r, err := versions.ParseNuGetVersionRange("[1.0,2.0)")
+//	if err != nil {
+//	    log.Fatalf("不是合法的NuGet版本范围: %v", err)
+//	}
+//	fmt.Println(r.Check(versions.MustParse("1.5", versions.SchemeNuGet))) // 输出: true
+type NuGetVersionRange struct {
+	interval *VersionInterval
+	float    *nuGetFloatRange
+}
+
+var _ BoundedVersionMatcher = &NuGetVersionRange{}
+
+// ParseNuGetVersionRange 按照 NuGet 的规则解析版本范围
+//
+// 参数:
+//   - rangeStr: 版本范围，如 "[1.0,2.0)"、"1.*"、"1.0"
+//
+// 返回:
+//   - *NuGetVersionRange: 解析后的版本范围
+//   - error: 版本范围无效时返回包装了 ErrNuGetVersionRangeInvalid 的错误
+func ParseNuGetVersionRange(rangeStr string) (*NuGetVersionRange, error) {
+	r, err := parseNuGetVersionRange(strings.TrimSpace(rangeStr))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q %s", ErrNuGetVersionRangeInvalid, rangeStr, err.Error())
+	}
+	return r, nil
+}
+
+// MustParseNuGetVersionRange 与 ParseNuGetVersionRange 相同，但是在解析失败时会 panic
+func MustParseNuGetVersionRange(rangeStr string) *NuGetVersionRange {
+	r, err := ParseNuGetVersionRange(rangeStr)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+func parseNuGetVersionRange(s string) (*NuGetVersionRange, error) {
+	if s == "" {
+		return nil, fmt.Errorf("range is empty")
+	}
+	r := &NuGetVersionRange{
+		interval: NewVersionInterval(nil, ContainsPolicyNone, nil, ContainsPolicyNone),
+	}
+
+	if s[0] != '[' && s[0] != '(' {
+		if err := r.setLower(s, ContainsPolicyYes); err != nil {
+			return nil, err
+		}
+		return r, nil
+	}
+
+	last := s[len(s)-1]
+	if len(s) < 3 || (last != ']' && last != ')') {
+		return nil, fmt.Errorf("brackets are not closed")
+	}
+	lowerPolicy, upperPolicy := ContainsPolicyNo, ContainsPolicyNo
+	if s[0] == '[' {
+		lowerPolicy = ContainsPolicyYes
+	}
+	if last == ']' {
+		upperPolicy = ContainsPolicyYes
+	}
+	parts := strings.Split(s[1:len(s)-1], ",")
+	switch len(parts) {
+	case 1:
+		// 只有一个版本时必须是 "[1.0]" 的形式，并且不能浮动
+		if lowerPolicy != ContainsPolicyYes || upperPolicy != ContainsPolicyYes {
+			return nil, fmt.Errorf("single version must be surrounded by []")
+		}
+		v, err := Parse(strings.TrimSpace(parts[0]), SchemeNuGet)
+		if err != nil {
+			return nil, err
+		}
+		r.interval = NewVersionInterval(v, ContainsPolicyYes, v, ContainsPolicyYes)
+		return r, nil
+	case 2:
+	default:
+		return nil, fmt.Errorf("too many versions")
+	}
+
+	lowerStr, upperStr := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+	if lowerStr == "" && upperStr == "" {
+		return nil, fmt.Errorf("range has neither lower nor upper bound")
+	}
+	if lowerStr != "" {
+		if err := r.setLower(lowerStr, lowerPolicy); err != nil {
+			return nil, err
+		}
+	}
+	if upperStr != "" {
+		v, err := Parse(upperStr, SchemeNuGet)
+		if err != nil {
+			return nil, err
+		}
+		r.interval.Upper, r.interval.UpperPolicy = v, upperPolicy
+	}
+	if r.interval.Lower != nil && r.interval.Upper != nil {
+		c := r.interval.Lower.CompareTo(r.interval.Upper)
+		if c > 0 || (c == 0 && (lowerPolicy != ContainsPolicyYes || upperPolicy != ContainsPolicyYes)) {
+			return nil, fmt.Errorf("lower bound is greater than upper bound")
+		}
+	}
+	return r, nil
+}
+
+// setLower 设置范围的下界，下界可以是浮动版本
+func (x *NuGetVersionRange) setLower(s string, policy ContainsPolicy) error {
+	if strings.Contains(s, "*") {
+		f, err := parseNuGetFloatRange(s)
+		if err != nil {
+			return err
+		}
+		x.float = f
+		x.interval.Lower, x.interval.LowerPolicy = MustParse(f.min.String(), SchemeNuGet), policy
+		return nil
+	}
+	v, err := Parse(s, SchemeNuGet)
+	if err != nil {
+		return err
+	}
+	x.interval.Lower, x.interval.LowerPolicy = v, policy
+	return nil
+}
+
+// IsFloating 判断范围的下界是否是浮动版本
+func (x *NuGetVersionRange) IsFloating() bool {
+	return x.float != nil
+}
+
+// Check 判断版本是否落在范围的区间内，与 VersionRange.Satisfies 一致，版本应该使用 SchemeNuGet 解析
+func (x *NuGetVersionRange) Check(v *Version) bool {
+	return x.interval.Check(v)
+}
+
+// Filter 过滤出满足版本范围的版本，返回的版本保持输入时的顺序
+func (x *NuGetVersionRange) Filter(versions []*Version) []*Version {
+	return FilterVersions(versions, x)
+}
+
+// BoundingIntervals 返回范围的区间
+func (x *NuGetVersionRange) BoundingIntervals() []*VersionInterval {
+	return []*VersionInterval{x.interval}
+}
+
+// MatchVersion 从有序版本组中选出 dotnet restore 会使用的版本，与 VersionRange.FindBestMatch 一致
+//
+// 不是浮动版本时选择满足范围的最低版本；浮动版本时选择落在浮动范围内的最高版本，
+// 没有落在浮动范围内的版本时，优先选择高于浮动范围的最低版本。
+// 与 NuGet 一样，只有下界是预发布版本或者浮动版本允许预发布时才会选择预发布版本。
+//
+// 参数:
+//   - groups: 候选版本，版本应该使用 SchemeNuGet 解析
+//
+// 返回:
+//   - *Version: 选出的版本，没有满足的版本时返回 nil
+//
+// 使用示例:
+//
+//	allVersions, _ := versions.ParseAll(versions.SchemeNuGet, "1.0.0", "1.2.0", "1.3.0-beta", "2.0.0")
+//	groups := versions.NewSortedVersionGroups(allVersions)
+//	fmt.Println(versions.MustParseNuGetVersionRange("1.0").MatchVersion(groups).Raw) // 输出: 1.0.0
+//	fmt.Println(versions.MustParseNuGetVersionRange("1.*").MatchVersion(groups).Raw) // 输出: 1.2.0
+func (x *NuGetVersionRange) MatchVersion(groups *SortedVersionGroups) *Version {
+	includePrerelease := x.float != nil && x.float.includesPrerelease()
+	if x.interval.Lower != nil && nuGetVersionOf(x.interval.Lower).IsPrerelease() {
+		includePrerelease = true
+	}
+	var best *Version
+	for _, v := range groups.QueryMatcher(x) {
+		nv := nuGetVersionOf(v)
+		if nv == nil || (nv.IsPrerelease() && !includePrerelease) {
+			continue
+		}
+		if best == nil || x.isBetter(best, v) {
+			best = v
+		}
+	}
+	return best
+}
+
+// isBetter 判断 considering 是否比 current 更合适，与 VersionRange.IsBetter 一致
+func (x *NuGetVersionRange) isBetter(current, considering *Version) bool {
+	if x.float == nil {
+		return current.CompareTo(considering) > 0
+	}
+	currentIn := x.float.satisfies(nuGetVersionOf(current))
+	consideringIn := x.float.satisfies(nuGetVersionOf(considering))
+	switch {
+	case currentIn && consideringIn:
+		return current.CompareTo(considering) < 0
+	case currentIn != consideringIn:
+		return consideringIn
+	}
+	// 都不在浮动范围内时，优先选择高于浮动范围的版本，高于浮动范围时选低的，低于浮动范围时选高的
+	currentBelow := nuGetVersionOf(current).CompareTo(x.float.min) < 0
+	consideringBelow := nuGetVersionOf(considering).CompareTo(x.float.min) < 0
+	switch {
+	case currentBelow != consideringBelow:
+		return currentBelow
+	case currentBelow:
+		return current.CompareTo(considering) < 0
+	default:
+		return current.CompareTo(considering) > 0
+	}
+}
+
+// String 返回范围的规范形式，与 VersionRange.ToNormalizedString 一致，如 "1.0" 返回 "[1.0.0, )"
+func (x *NuGetVersionRange) String() string {
+	lower, upper := "", ""
+	if x.interval.Lower != nil {
+		lower = nuGetVersionOf(x.interval.Lower).String()
+		if x.float != nil {
+			lower = x.float.raw
+		}
+	}
+	if x.interval.Upper != nil {
+		upper = nuGetVersionOf(x.interval.Upper).String()
+	}
+	if x.float == nil && x.interval.Lower != nil && x.interval.Lower == x.interval.Upper {
+		return "[" + lower + "]"
+	}
+	s := strings.Builder{}
+	if x.interval.Lower != nil && x.interval.LowerPolicy == ContainsPolicyYes {
+		s.WriteString("[")
+	} else {
+		s.WriteString("(")
+	}
+	s.WriteString(lower)
+	s.WriteString(", ")
+	s.WriteString(upper)
+	if x.interval.Upper != nil && x.interval.UpperPolicy == ContainsPolicyYes {
+		s.WriteString("]")
+	} else {
+		s.WriteString(")")
+	}
+	return s.String()
+}
@@ -0,0 +1,101 @@
+package versions
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseNuGetVersionRange 测试 NuGet 版本范围的解析和规范化的字符串形式
+func TestParseNuGetVersionRange(t *testing.T) {
+	normalized := map[string]string{
+		"1.0":         "[1.0.0, )",
+		"[1.0]":       "[1.0.0]",
+		"[1.0,2.0)":   "[1.0.0, 2.0.0)",
+		"[1.0, 2.0)":  "[1.0.0, 2.0.0)",
+		"(,2.0]":      "(, 2.0.0]",
+		"(1.0,)":      "(1.0.0, )",
+		"1.*":         "[1.*, )",
+		"1.0.*-*":     "[1.0.*-*, )",
+		"*":           "[*, )",
+		"[1.*, 2.0)":  "[1.*, 2.0.0)",
+		"1.0.0-beta*": "[1.0.0-beta*, )",
+	}
+	for raw, expected := range normalized {
+		r, err := ParseNuGetVersionRange(raw)
+		if assert.Nil(t, err, raw) {
+			assert.Equal(t, expected, r.String(), raw)
+		}
+	}
+	assert.True(t, MustParseNuGetVersionRange("1.*").IsFloating())
+	assert.False(t, MustParseNuGetVersionRange("[1.0,2.0)").IsFloating())
+
+	for _, invalid := range []string{"", "(,)", "[2.0,1.0]", "[1.0", "1.0]", "(1.0)", "[1.0,2.0,3.0]", "1.*.0", "[a,b]"} {
+		_, err := ParseNuGetVersionRange(invalid)
+		assert.True(t, errors.Is(err, ErrNuGetVersionRangeInvalid), invalid)
+	}
+}
+
+// TestNuGetVersionRange_Check 测试 NuGet 版本范围的匹配
+func TestNuGetVersionRange_Check(t *testing.T) {
+	cases := []struct {
+		rangeStr string
+		includes []string
+		excludes []string
+	}{
+		{"1.0", []string{"1.0.0", "1.0.0.1", "99.0.0"}, []string{"0.9.9", "1.0.0-rc.1"}},
+		{"[1.0]", []string{"1.0.0"}, []string{"1.0.0.1", "0.9"}},
+		{"[1.0,2.0)", []string{"1.0.0", "1.5.0-beta", "1.9.9"}, []string{"2.0.0", "0.9"}},
+		{"(1.0,2.0]", []string{"1.0.1", "2.0.0"}, []string{"1.0.0", "2.0.1"}},
+		{"(,2.0]", []string{"0.0.1", "2.0.0"}, []string{"2.0.0.1"}},
+		{"1.*", []string{"1.0.0", "2.0.0"}, []string{"0.9"}},
+	}
+	for _, c := range cases {
+		r := MustParseNuGetVersionRange(c.rangeStr)
+		for _, v := range c.includes {
+			assert.True(t, r.Check(MustParse(v, SchemeNuGet)), "%s should include %s", c.rangeStr, v)
+		}
+		for _, v := range c.excludes {
+			assert.False(t, r.Check(MustParse(v, SchemeNuGet)), "%s should exclude %s", c.rangeStr, v)
+		}
+
+		// 外接区间必须覆盖所有匹配的版本
+		assertQueryMatcherMatchesFilter(t, SchemeNuGet, r, append(append([]string{}, c.includes...), c.excludes...)...)
+	}
+	assert.True(t, MustParseNuGetVersionRange("[1.0]").Check(MustParse("1.0.0.0", SchemeNuGet)))
+}
+
+// TestNuGetVersionRange_MatchVersion 测试按照 dotnet restore 的规则选出最合适的版本
+func TestNuGetVersionRange_MatchVersion(t *testing.T) {
+	available, err := ParseAll(SchemeNuGet, "0.9", "1.0.0-beta", "1.0.0", "1.1.0", "1.5.0-rc", "2.0.0", "2.1.0-preview", "2.1.0")
+	assert.Nil(t, err)
+	groups := NewSortedVersionGroups(available)
+
+	expected := map[string]string{
+		"1.0":              "1.0.0",
+		"0.1":              "0.9",
+		"[1.0,2.0)":        "1.0.0",
+		"(1.0,2.0)":        "1.1.0",
+		"1.0.0-beta":       "1.0.0-beta",
+		"1.*":              "1.1.0",
+		"1.*-*":            "1.5.0-rc",
+		"*":                "2.1.0",
+		"2.1.0-*":          "2.1.0",
+		"2.1.0-pre*":       "2.1.0",
+		"[1.*, 2.0)":       "1.1.0",
+		"(,1.0]":           "0.9",
+		"1.5.0-r*":         "1.5.0-rc",
+		"[3.0, )":          "",
+		"[1.0.0-alpha, )":  "1.0.0-beta",
+		"[1.0.0-alpha, 2)": "1.0.0-beta",
+	}
+	for rangeStr, raw := range expected {
+		v := MustParseNuGetVersionRange(rangeStr).MatchVersion(groups)
+		if raw == "" {
+			assert.Nil(t, v, rangeStr)
+		} else if assert.NotNil(t, v, rangeStr) {
+			assert.Equal(t, raw, v.Raw, rangeStr)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package osv
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// 范围的类型，与 OSV Schema 中的 affected[].ranges[].type 一致
+const (
+
+	// RangeTypeSemVer 范围中的版本号是 SemVer 2.0.0 版本号，不带 "v" 前缀
+	RangeTypeSemVer = "SEMVER"
+
+	// RangeTypeEcosystem 范围中的版本号按照所属生态的规则比较
+	RangeTypeEcosystem = "ECOSYSTEM"
+
+	// RangeTypeGit 范围中的版本是 git 提交，无法根据版本号判断，匹配时会被忽略
+	RangeTypeGit = "GIT"
+)
+
+// Advisory 表示一条 OSV 格式的安全公告
+//
+// 只包含了版本匹配需要的字段，完整的格式参考 https://ossf.github.io/osv-schema/
+type Advisory struct {
+
+	// ID 公告的ID，如 "GHSA-xxxx-xxxx-xxxx"
+	ID string `json:"id"`
+
+	// Summary 公告的简介
+	Summary string `json:"summary,omitempty"`
+
+	// Details 公告的详细描述
+	Details string `json:"details,omitempty"`
+
+	// Aliases 公告的别名，通常是 CVE 编号
+	Aliases []string `json:"aliases,omitempty"`
+
+	// Modified 最后修改的时间
+	Modified string `json:"modified,omitempty"`
+
+	// Published 发布的时间
+	Published string `json:"published,omitempty"`
+
+	// Withdrawn 撤回的时间，不为空时表示公告已经被撤回，匹配时会被忽略
+	Withdrawn string `json:"withdrawn,omitempty"`
+
+	// Affected 受影响的包
+	Affected []*Affected `json:"affected,omitempty"`
+}
+
+// Affected 表示公告中一个受影响的包
+type Affected struct {
+
+	// Package 受影响的包
+	Package Package `json:"package"`
+
+	// Ranges 受影响的版本范围
+	Ranges []*Range `json:"ranges,omitempty"`
+
+	// Versions 明确列出的受影响的版本
+	Versions []string `json:"versions,omitempty"`
+}
+
+// Package 表示一个包
+type Package struct {
+
+	// Ecosystem 包所属的生态，如 "npm"、"PyPI"、"Debian:11"
+	Ecosystem string `json:"ecosystem"`
+
+	// Name 包名
+	Name string `json:"name"`
+
+	// Purl 包的 package-url
+	Purl string `json:"purl,omitempty"`
+}
+
+// Range 表示一个受影响的版本范围
+type Range struct {
+
+	// Type 范围的类型，如 RangeTypeSemVer、RangeTypeEcosystem
+	Type string `json:"type"`
+
+	// Repo 类型为 RangeTypeGit 时的仓库地址
+	Repo string `json:"repo,omitempty"`
+
+	// Events 描述范围的事件
+	Events []*Event `json:"events"`
+}
+
+// Event 表示版本范围中的一个事件，每个事件只会有一个字段不为空
+type Event struct {
+
+	// Introduced 从这个版本开始受影响，"0" 表示从最早的版本开始
+	Introduced string `json:"introduced,omitempty"`
+
+	// Fixed 在这个版本修复，这个版本不受影响
+	Fixed string `json:"fixed,omitempty"`
+
+	// LastAffected 最后一个受影响的版本，这个版本受影响
+	LastAffected string `json:"last_affected,omitempty"`
+
+	// Limit 范围的上限，不小于这个版本的都不受影响
+	Limit string `json:"limit,omitempty"`
+}
+
+// ParseAdvisory 解析 OSV 格式的 JSON
+//
+// 参数:
+//   - data: JSON 内容
+//
+// 返回:
+//   - *Advisory: 解析后的公告
+//   - error: JSON 格式错误时返回错误
+func ParseAdvisory(data []byte) (*Advisory, error) {
+	advisory := &Advisory{}
+	if err := json.Unmarshal(data, advisory); err != nil {
+		return nil, err
+	}
+	return advisory, nil
+}
+
+// LoadAdvisory 从文件中读取一条 OSV 公告
+//
+// 参数:
+//   - path: JSON 文件的路径
+//
+// 返回:
+//   - *Advisory: 解析后的公告
+//   - error: 文件读取失败或者格式错误时返回错误
+//
+// 使用示例:
+//
+//	advisory, err := osv.LoadAdvisory("./GHSA-p6mc-m468-83gw.json")
+//	if err != nil {
+//	    log.Fatalf("读取公告失败: %v", err)
+//	}
+//	fmt.Println(advisory.ID)
+func LoadAdvisory(path string) (*Advisory, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAdvisory(data)
+}
+
+// LoadAdvisories 递归读取目录下所有 .json 文件中的 OSV 公告
+//
+// 参数:
+//   - dir: 目录的路径，也可以直接是一个 JSON 文件的路径
+//
+// 返回:
+//   - []*Advisory: 按文件路径排序的公告
+//   - error: 任意一个文件读取失败或者格式错误时返回错误
+func LoadAdvisories(dir string) ([]*Advisory, error) {
+	paths := make([]string, 0)
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() && strings.EqualFold(filepath.Ext(path), ".json") {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	advisories := make([]*Advisory, 0, len(paths))
+	for _, path := range paths {
+		advisory, err := LoadAdvisory(path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		advisories = append(advisories, advisory)
+	}
+	return advisories, nil
+}
@@ -0,0 +1,41 @@
+package osv
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestLoadAdvisory 测试读取单个 OSV 公告
+func TestLoadAdvisory(t *testing.T) {
+	advisory, err := LoadAdvisory("./test_data/npm/TEST-NPM-0002.json")
+	assert.Nil(t, err)
+	assert.Equal(t, "TEST-NPM-0002", advisory.ID)
+	assert.Equal(t, 1, len(advisory.Affected))
+	assert.Equal(t, "npm", advisory.Affected[0].Package.Ecosystem)
+	assert.Equal(t, "lodash", advisory.Affected[0].Package.Name)
+	assert.Equal(t, 2, len(advisory.Affected[0].Ranges))
+	assert.Equal(t, RangeTypeGit, advisory.Affected[0].Ranges[1].Type)
+	assert.Equal(t, "4.17.15", advisory.Affected[0].Ranges[0].Events[1].LastAffected)
+
+	_, err = LoadAdvisory("./test_data/not-exists.json")
+	assert.NotNil(t, err)
+}
+
+// TestLoadAdvisories 测试递归读取目录下的 OSV 公告
+func TestLoadAdvisories(t *testing.T) {
+	advisories, err := LoadAdvisories("./test_data")
+	assert.Nil(t, err)
+	ids := make([]string, 0)
+	for _, advisory := range advisories {
+		ids = append(ids, advisory.ID)
+	}
+	assert.Equal(t, []string{"TEST-DEB-0001", "TEST-GO-0001", "TEST-NPM-0001", "TEST-NPM-0002", "TEST-NPM-0003", "TEST-PYPI-0001"}, ids)
+
+	dir := t.TempDir()
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "broken.json"), []byte(`{"id": `), 0644))
+	_, err = LoadAdvisories(dir)
+	assert.NotNil(t, err)
+}
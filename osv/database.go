@@ -0,0 +1,285 @@
+package osv
+
+import (
+	"fmt"
+
+	"github.com/scagogogo/versions"
+)
+
+// AffectedMatcher 判断版本是否属于公告中一个受影响的包的受影响版本
+type AffectedMatcher struct {
+
+	// Advisory 所属的公告
+	Advisory *Advisory
+
+	// Affected 受影响的包
+	Affected *Affected
+
+	// ranges 各个版本范围对应的匹配器
+	ranges []*RangeMatcher
+
+	// versions 明确列出的受影响的版本
+	versions map[string]bool
+}
+
+var _ versions.VersionMatcher = &AffectedMatcher{}
+
+// NewAffectedMatcher 创建受影响的包的匹配器
+//
+// 参数:
+//   - advisory: 所属的公告
+//   - affected: 公告中受影响的包
+//
+// 返回:
+//   - *AffectedMatcher: 匹配器
+//   - error: 某个版本范围无效时返回 NewRangeMatcher 给出的错误
+func NewAffectedMatcher(advisory *Advisory, affected *Affected) (*AffectedMatcher, error) {
+	m := &AffectedMatcher{
+		Advisory: advisory,
+		Affected: affected,
+		ranges:   make([]*RangeMatcher, 0, len(affected.Ranges)),
+		versions: make(map[string]bool, len(affected.Versions)),
+	}
+	for _, r := range affected.Ranges {
+		rm, err := NewRangeMatcher(r, affected.Package.Ecosystem)
+		if err != nil {
+			return nil, err
+		}
+		m.ranges = append(m.ranges, rm)
+	}
+	for _, v := range affected.Versions {
+		m.versions[v] = true
+	}
+	return m, nil
+}
+
+// Check 判断版本是否受影响：版本在明确列出的版本中，或者在任意一个版本范围内
+func (x *AffectedMatcher) Check(v *versions.Version) bool {
+	if x.versions[v.Raw] {
+		return true
+	}
+	for _, r := range x.ranges {
+		if r.Check(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// Match 表示一条公告命中了哪些版本
+type Match struct {
+
+	// Advisory 命中的公告
+	Advisory *Advisory
+
+	// Affected 公告中命中的受影响的包
+	Affected *Affected
+
+	// Versions 受影响的版本，保持查询时的顺序
+	Versions []*versions.Version
+}
+
+// Database 由 OSV 公告组成的数据库，按照生态和包名建立了索引
+//
+// 使用示例:
+//
+//	db, err := osv.LoadDatabase("./advisories")
+//	if err != nil {
+//	    log.Fatalf("读取公告失败: %v", err)
+//	}
+//	for _, match := range db.QueryVersions("npm", "lodash", versions.NewVersions("4.17.15", "4.17.21")) {
+//	    fmt.Println(match.Advisory.ID, match.Versions)
+//	}
+type Database struct {
+
+	// advisories 所有的公告
+	advisories []*Advisory
+
+	// index 生态（不带发行版本）和规范化之后的包名到匹配器的索引
+	index map[string][]*AffectedMatcher
+}
+
+// NewDatabase 使用公告创建数据库，已经撤回的公告会被忽略
+//
+// 参数:
+//   - advisories: 公告
+//
+// 返回:
+//   - *Database: 数据库
+//   - error: 某条公告的版本范围无效时返回带有公告ID的错误
+func NewDatabase(advisories []*Advisory) (*Database, error) {
+	db := &Database{
+		advisories: make([]*Advisory, 0, len(advisories)),
+		index:      make(map[string][]*AffectedMatcher),
+	}
+	for _, advisory := range advisories {
+		if advisory.Withdrawn != "" {
+			continue
+		}
+		db.advisories = append(db.advisories, advisory)
+		for _, affected := range advisory.Affected {
+			matcher, err := NewAffectedMatcher(advisory, affected)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", advisory.ID, err)
+			}
+			key := indexKey(affected.Package.Ecosystem, affected.Package.Name)
+			db.index[key] = append(db.index[key], matcher)
+		}
+	}
+	return db, nil
+}
+
+// LoadDatabase 递归读取目录下所有的 OSV 公告并创建数据库
+//
+// 参数:
+//   - dir: 目录的路径
+//
+// 返回:
+//   - *Database: 数据库
+//   - error: 读取失败或者公告的版本范围无效时返回错误
+func LoadDatabase(dir string) (*Database, error) {
+	advisories, err := LoadAdvisories(dir)
+	if err != nil {
+		return nil, err
+	}
+	return NewDatabase(advisories)
+}
+
+// indexKey 索引的键
+func indexKey(ecosystem string, name string) string {
+	return baseEcosystem(ecosystem) + "/" + normalizePackageName(ecosystem, name)
+}
+
+// Advisories 返回数据库中没有被撤回的公告
+func (x *Database) Advisories() []*Advisory {
+	return x.advisories
+}
+
+// Query 查询影响某个版本的公告
+//
+// 参数:
+//   - ecosystem: 包所属的生态，如 "npm"，"Debian:11" 这种带有发行版本的生态只匹配对应发行版本的公告
+//   - name: 包名
+//   - v: 版本
+//
+// 返回:
+//   - []*Advisory: 影响该版本的公告
+func (x *Database) Query(ecosystem string, name string, v *versions.Version) []*Advisory {
+	advisories := make([]*Advisory, 0)
+	seen := make(map[*Advisory]bool)
+	for _, match := range x.QueryVersions(ecosystem, name, []*versions.Version{v}) {
+		if !seen[match.Advisory] {
+			seen[match.Advisory] = true
+			advisories = append(advisories, match.Advisory)
+		}
+	}
+	return advisories
+}
+
+// QueryVersions 查询每条公告影响了哪些版本
+//
+// 所有的版本只会按照每种版本号方案解析和排序一次，之后每条公告的每个版本范围都通过
+// SortedVersionGroups 的二分查找定位受影响的版本，不需要对每条公告重新遍历所有版本。
+// 不能按照方案解析的版本不参与排序，而是与 RangeMatcher.Check 一样逐个检查。
+//
+// 参数:
+//   - ecosystem: 包所属的生态
+//   - name: 包名
+//   - versionSlice: 要检查的版本
+//
+// 返回:
+//   - []*Match: 至少影响了一个版本的公告以及受影响的版本
+func (x *Database) QueryVersions(ecosystem string, name string, versionSlice []*versions.Version) []*Match {
+	matches := make([]*Match, 0)
+	matchers := x.index[indexKey(ecosystem, name)]
+	if len(matchers) == 0 || len(versionSlice) == 0 {
+		return matches
+	}
+
+	indexes := make(map[string]*sortedIndex)
+	for _, matcher := range matchers {
+		if !matchEcosystem(ecosystem, matcher.Affected.Package.Ecosystem) {
+			continue
+		}
+
+		hit := make(map[*versions.Version]bool)
+		for _, v := range versionSlice {
+			if matcher.versions[v.Raw] {
+				hit[v] = true
+			}
+		}
+		for _, r := range matcher.ranges {
+			index, ok := indexes[r.Scheme().Name()]
+			if !ok {
+				index = newSortedIndex(r.Scheme(), versionSlice)
+				indexes[r.Scheme().Name()] = index
+			}
+			for _, v := range index.groups.QueryMatcher(r) {
+				for _, origin := range index.origins[v] {
+					hit[origin] = true
+				}
+			}
+			for _, v := range index.unparsed {
+				if r.Check(v) {
+					hit[v] = true
+				}
+			}
+		}
+		if len(hit) == 0 {
+			continue
+		}
+
+		match := &Match{
+			Advisory: matcher.Advisory,
+			Affected: matcher.Affected,
+			Versions: make([]*versions.Version, 0, len(hit)),
+		}
+		for _, v := range versionSlice {
+			if hit[v] {
+				match.Versions = append(match.Versions, v)
+				delete(hit, v)
+			}
+		}
+		matches = append(matches, match)
+	}
+	return matches
+}
+
+// sortedIndex 按照某种版本号方案重新解析并排好序的版本
+type sortedIndex struct {
+
+	// groups 排好序的版本组
+	groups *versions.SortedVersionGroups
+
+	// origins 重新解析之后的版本对应的原始版本，内容相同的版本在分组时会被合并，所以可能对应多个原始版本
+	origins map[*versions.Version][]*versions.Version
+
+	// unparsed 不能按照方案解析的原始版本，它们与按方案解析的版本之间没有一致的顺序，
+	// 放进 groups 会破坏二分查找，所以不参与排序，查询时逐个检查
+	unparsed []*versions.Version
+}
+
+// newSortedIndex 使用方案重新解析版本并排序，不能解析的版本放在 unparsed 中
+func newSortedIndex(scheme versions.Scheme, versionSlice []*versions.Version) *sortedIndex {
+	index := &sortedIndex{
+		origins: make(map[*versions.Version][]*versions.Version, len(versionSlice)),
+	}
+	byRaw := make(map[string]*versions.Version, len(versionSlice))
+	converted := make([]*versions.Version, 0, len(versionSlice))
+	for _, v := range versionSlice {
+		c, err := convertVersion(scheme, v)
+		if err != nil {
+			index.unparsed = append(index.unparsed, v)
+			continue
+		}
+		if existing, ok := byRaw[c.Raw]; ok {
+			c = existing
+		} else {
+			byRaw[c.Raw] = c
+			converted = append(converted, c)
+		}
+		index.origins[c] = append(index.origins[c], v)
+	}
+	index.groups = versions.NewSortedVersionGroups(converted)
+	return index
+}
@@ -0,0 +1,122 @@
+package osv
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/scagogogo/versions"
+	"github.com/stretchr/testify/assert"
+)
+
+// matchSummary 把查询结果转换为"公告ID 生态"到受影响的版本的映射，方便断言
+func matchSummary(matches []*Match) map[string][]string {
+	result := make(map[string][]string)
+	for _, match := range matches {
+		key := match.Advisory.ID + " " + match.Affected.Package.Ecosystem
+		for _, v := range match.Versions {
+			result[key] = append(result[key], v.Raw)
+		}
+	}
+	return result
+}
+
+// TestDatabase_QueryVersions 测试查询每条公告影响了哪些版本
+func TestDatabase_QueryVersions(t *testing.T) {
+	db, err := LoadDatabase("./test_data")
+	assert.Nil(t, err)
+	assert.Equal(t, 5, len(db.Advisories()))
+
+	lodash := versions.NewVersions("4.17.21", "3.10.1", "4.17.15", "4.17.16", "5.0.0-rc.2", "4.17.15")
+	assert.Equal(t, map[string][]string{
+		"TEST-NPM-0001 npm": {"3.10.1", "4.17.15", "4.17.16", "4.17.15"},
+		"TEST-NPM-0002 npm": {"4.17.15", "5.0.0-rc.2", "4.17.15"},
+	}, matchSummary(db.QueryVersions("npm", "lodash", lodash)))
+
+	django := versions.NewVersions("3.1.14", "3.2.18", "3.2.19", "4.1", "4.2")
+	assert.Equal(t, map[string][]string{
+		"TEST-PYPI-0001 PyPI": {"3.1.14", "3.2.18", "4.1"},
+	}, matchSummary(db.QueryVersions("PyPI", "django", django)))
+
+	mod := versions.NewVersions("v1.0.0", "v1.2.3-0.20230108071748-35501d697475", "v1.2.3", "v2.1.0", "v2.4.0+incompatible", "v2.5.0")
+	assert.Equal(t, map[string][]string{
+		"TEST-GO-0001 Go": {"v1.0.0", "v1.2.3-0.20230108071748-35501d697475", "v2.1.0", "v2.4.0+incompatible"},
+	}, matchSummary(db.QueryVersions("Go", "github.com/example/mod", mod)))
+
+	openssl := versions.NewVersions("1.1.1n-0+deb11u4", "3.0.11-1~deb12u1")
+	assert.Equal(t, map[string][]string{
+		"TEST-DEB-0001 Debian:11": {"1.1.1n-0+deb11u4"},
+		"TEST-DEB-0001 Debian:12": {"1.1.1n-0+deb11u4", "3.0.11-1~deb12u1"},
+	}, matchSummary(db.QueryVersions("Debian", "openssl", openssl)))
+	assert.Equal(t, map[string][]string{
+		"TEST-DEB-0001 Debian:11": {"1.1.1n-0+deb11u4"},
+	}, matchSummary(db.QueryVersions("Debian:11", "openssl", openssl)))
+
+	assert.Equal(t, 0, len(db.QueryVersions("npm", "not-exists", lodash)))
+	assert.Equal(t, 0, len(db.QueryVersions("npm", "lodash", nil)))
+}
+
+// TestDatabase_Query 测试查询影响某个版本的公告
+func TestDatabase_Query(t *testing.T) {
+	db, err := LoadDatabase("./test_data")
+	assert.Nil(t, err)
+
+	ids := func(advisories []*Advisory) []string {
+		result := make([]string, 0)
+		for _, advisory := range advisories {
+			result = append(result, advisory.ID)
+		}
+		return result
+	}
+	assert.Equal(t, []string{"TEST-NPM-0001", "TEST-NPM-0002"}, ids(db.Query("npm", "lodash", versions.NewVersion("4.17.10"))))
+	assert.Equal(t, []string{}, ids(db.Query("npm", "lodash", versions.NewVersion("4.17.21"))))
+	assert.Equal(t, []string{"TEST-PYPI-0001"}, ids(db.Query("PyPI", "DJANGO", versions.NewVersion("3.1.14"))))
+}
+
+// TestEcosystemScheme 测试生态对应的版本号方案
+func TestEcosystemScheme(t *testing.T) {
+	assert.Equal(t, versions.SchemePEP440, EcosystemScheme("PyPI").Name())
+	assert.Equal(t, versions.SchemeGo, EcosystemScheme("Go").Name())
+	assert.Equal(t, versions.SchemeDebian, EcosystemScheme("Debian:11").Name())
+	assert.Equal(t, versions.SchemeAPK, EcosystemScheme("Alpine:v3.18").Name())
+	assert.Equal(t, versions.SchemeGeneric, EcosystemScheme("not-exists").Name())
+
+	RegisterEcosystem("Test:1", versions.SchemeMaven)
+	assert.Equal(t, versions.SchemeMaven, EcosystemScheme("Test").Name())
+}
+
+// TestNewDatabase_Invalid 测试公告的版本范围无效时返回带有公告ID的错误
+func TestNewDatabase_Invalid(t *testing.T) {
+	db, err := NewDatabase([]*Advisory{{
+		ID: "TEST-BAD-0001",
+		Affected: []*Affected{{
+			Package: Package{Ecosystem: "PyPI", Name: "django"},
+			Ranges:  []*Range{{Type: RangeTypeEcosystem, Events: []*Event{{Introduced: "0"}, {Fixed: "not a version"}}}},
+		}},
+	}})
+	assert.Nil(t, db)
+	assert.True(t, errors.Is(err, versions.ErrVersionInvalid))
+	assert.Contains(t, err.Error(), "TEST-BAD-0001")
+}
+
+// TestDatabase_QueryVersionsUnparsed 测试不能按照方案解析的版本不参与二分查找，结果与逐个检查的结果一致
+func TestDatabase_QueryVersionsUnparsed(t *testing.T) {
+	db, err := NewDatabase([]*Advisory{{
+		ID: "TEST-NPM-RC",
+		Affected: []*Affected{{
+			Package: Package{Ecosystem: "npm", Name: "rc"},
+			Ranges:  []*Range{{Type: RangeTypeSemVer, Events: []*Event{{Introduced: "1.0.0-rc.1"}, {Fixed: "1.0.0-rc.9"}}}},
+		}},
+	}})
+	assert.Nil(t, err)
+
+	// "1.0.0.rc1" 不是 SemVer 版本号，与 SemVer 版本之间只能按照通用规则比较，和 "1.0.0-ga" 排在一起时顺序不一致
+	mixed := versions.NewVersions("1.0.0.rc1", "1.0.0-ga", "1.0.0-rc.2", "0.9.0", "1.0.0")
+	matches := db.QueryVersions("npm", "rc", mixed)
+	assert.Len(t, matches, 1)
+	matcher, err := NewAffectedMatcher(matches[0].Advisory, matches[0].Affected)
+	assert.Nil(t, err)
+	assert.Equal(t, versions.FilterVersions(mixed, matcher), matches[0].Versions)
+	assert.Equal(t, map[string][]string{
+		"TEST-NPM-RC npm": {"1.0.0.rc1", "1.0.0-rc.2"},
+	}, matchSummary(matches))
+}
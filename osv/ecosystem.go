@@ -0,0 +1,100 @@
+package osv
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/scagogogo/versions"
+)
+
+var (
+	ecosystemLock sync.RWMutex
+
+	// ecosystemSchemes OSV 中的生态名称到版本号方案名称的映射，生态名称中冒号之后的发行版本会被忽略
+	ecosystemSchemes = map[string]string{
+		"npm":         versions.SchemeSemVer,
+		"crates.io":   versions.SchemeCargo,
+		"Go":          versions.SchemeGo,
+		"Hex":         versions.SchemeSemVer,
+		"Pub":         versions.SchemeSemVer,
+		"PyPI":        versions.SchemePEP440,
+		"Maven":       versions.SchemeMaven,
+		"RubyGems":    versions.SchemeGem,
+		"NuGet":       versions.SchemeNuGet,
+		"Packagist":   versions.SchemeComposer,
+		"Debian":      versions.SchemeDebian,
+		"Ubuntu":      versions.SchemeDebian,
+		"Red Hat":     versions.SchemeRPM,
+		"AlmaLinux":   versions.SchemeRPM,
+		"Rocky Linux": versions.SchemeRPM,
+		"openSUSE":    versions.SchemeRPM,
+		"SUSE":        versions.SchemeRPM,
+		"Mageia":      versions.SchemeRPM,
+		"Alpine":      versions.SchemeAPK,
+	}
+)
+
+// RegisterEcosystem 注册 OSV 中的生态使用的版本号方案
+//
+// 参数:
+//   - ecosystem: OSV 中的生态名称，如 "Hackage"，不需要带冒号之后的发行版本
+//   - schemeName: 已经注册的版本号方案名称
+func RegisterEcosystem(ecosystem string, schemeName string) {
+	ecosystemLock.Lock()
+	defer ecosystemLock.Unlock()
+	ecosystemSchemes[baseEcosystem(ecosystem)] = schemeName
+}
+
+// EcosystemScheme 返回 OSV 中的生态使用的版本号方案，没有注册过的生态使用通用方案
+//
+// 参数:
+//   - ecosystem: OSV 中的生态名称，如 "PyPI"、"Debian:11"
+//
+// 返回:
+//   - versions.Scheme: 版本号方案
+func EcosystemScheme(ecosystem string) versions.Scheme {
+	ecosystemLock.RLock()
+	schemeName, ok := ecosystemSchemes[baseEcosystem(ecosystem)]
+	ecosystemLock.RUnlock()
+	if !ok {
+		schemeName = versions.SchemeGeneric
+	}
+	return schemeOf(schemeName)
+}
+
+// schemeOf 根据名称查找版本号方案，找不到时使用通用方案
+func schemeOf(schemeName string) versions.Scheme {
+	if scheme, ok := versions.LookupScheme(schemeName); ok {
+		return scheme
+	}
+	scheme, _ := versions.LookupScheme(versions.SchemeGeneric)
+	return scheme
+}
+
+// baseEcosystem 去掉生态名称中冒号之后的发行版本，如 "Debian:11" 返回 "Debian"
+func baseEcosystem(ecosystem string) string {
+	if i := strings.IndexByte(ecosystem, ':'); i >= 0 {
+		return ecosystem[:i]
+	}
+	return ecosystem
+}
+
+// pypiNameSeparatorRegexp PyPI 包名中的分隔符，规范化时被替换为 "-"
+var pypiNameSeparatorRegexp = regexp.MustCompile(`[-_.]+`)
+
+// normalizePackageName 规范化包名，PyPI 的包名不区分大小写并且 "-"、"_"、"." 等价
+func normalizePackageName(ecosystem string, name string) string {
+	if baseEcosystem(ecosystem) == "PyPI" {
+		return pypiNameSeparatorRegexp.ReplaceAllString(strings.ToLower(name), "-")
+	}
+	return name
+}
+
+// matchEcosystem 判断公告中的生态是否满足查询的生态，查询的生态没有指定发行版本时匹配所有的发行版本
+func matchEcosystem(query string, ecosystem string) bool {
+	if strings.IndexByte(query, ':') >= 0 {
+		return query == ecosystem
+	}
+	return query == baseEcosystem(ecosystem)
+}
@@ -0,0 +1,196 @@
+package osv
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/scagogogo/versions"
+)
+
+// RangeMatcher 根据 OSV 的版本范围判断版本是否受影响
+//
+// 范围中的事件会按版本排序后转换为若干个不相交的区间：introduced 开始一个区间，fixed 结束一个不包含该版本的区间，
+// last_affected 结束一个包含该版本的区间，最后没有结束的区间没有上界，limit 限制所有区间的上界。
+// 这与 OSV Schema 中给出的判断算法等价。
+//
+// 使用示例:
+//
+//	r := &osv.Range{
+//	    Type: osv.RangeTypeEcosystem,
+//	    Events: []*osv.Event{{Introduced: "0"}, {Fixed: "4.17.21"}},
+//	}
+//	matcher, err := osv.NewRangeMatcher(r, "npm")
+//	if err != nil {
+//	    log.Fatalf("版本范围无效: %v", err)
+//	}
+//	fmt.Println(matcher.Check(versions.NewVersion("4.17.20"))) // 输出: true
+type RangeMatcher struct {
+
+	// scheme 比较版本时使用的方案
+	scheme versions.Scheme
+
+	// intervals 受影响的区间
+	intervals []*versions.VersionInterval
+}
+
+var _ versions.BoundedVersionMatcher = &RangeMatcher{}
+
+// NewRangeMatcher 根据 OSV 的版本范围创建匹配器
+//
+// 类型为 RangeTypeSemVer 的范围使用 SemVer 方案比较，其中 Go 生态使用支持伪版本和简写的 Go 模块版本号方案，
+// RangeTypeEcosystem 使用生态对应的方案比较，RangeTypeGit 以及未知类型的范围无法根据版本号判断，返回的匹配器不匹配任何版本。
+//
+// 参数:
+//   - r: OSV 的版本范围
+//   - ecosystem: 包所属的生态
+//
+// 返回:
+//   - *RangeMatcher: 匹配器
+//   - error: 事件中的版本号不能按照比较时使用的方案解析时返回包装了解析错误的错误
+func NewRangeMatcher(r *Range, ecosystem string) (*RangeMatcher, error) {
+	m := &RangeMatcher{
+		intervals: make([]*versions.VersionInterval, 0),
+	}
+	switch r.Type {
+	case RangeTypeSemVer:
+		m.scheme = schemeOf(versions.SchemeSemVer)
+		if scheme := EcosystemScheme(ecosystem); scheme.Name() == versions.SchemeGo {
+			m.scheme = scheme
+		}
+	case RangeTypeEcosystem:
+		m.scheme = EcosystemScheme(ecosystem)
+	default:
+		m.scheme = EcosystemScheme(ecosystem)
+		return m, nil
+	}
+
+	type boundary struct {
+		version *versions.Version
+		event   *Event
+	}
+	boundaries := make([]*boundary, 0, len(r.Events))
+	var limit *versions.Version
+	for _, event := range r.Events {
+		var versionStr string
+		switch {
+		case event.Introduced == "0":
+			boundaries = append(boundaries, &boundary{nil, event})
+			continue
+		case event.Introduced != "":
+			versionStr = event.Introduced
+		case event.Fixed != "":
+			versionStr = event.Fixed
+		case event.LastAffected != "":
+			versionStr = event.LastAffected
+		case event.Limit != "" && event.Limit != "*":
+			versionStr = event.Limit
+		default:
+			continue
+		}
+		v, err := m.parse(versionStr)
+		if err != nil {
+			return nil, err
+		}
+		if event.Limit == "" {
+			boundaries = append(boundaries, &boundary{v, event})
+		} else if limit == nil || v.CompareTo(limit) > 0 {
+			limit = v
+		}
+	}
+
+	// "0" 表示最早的版本，排在最前面
+	sort.SliceStable(boundaries, func(i, j int) bool {
+		a, b := boundaries[i].version, boundaries[j].version
+		if a == nil || b == nil {
+			return a == nil && b != nil
+		}
+		return a.CompareTo(b) < 0
+	})
+
+	var current *versions.VersionInterval
+	for _, b := range boundaries {
+		switch {
+		case b.event.Introduced != "":
+			if current == nil {
+				current = versions.NewVersionInterval(b.version, versions.ContainsPolicyYes, nil, versions.ContainsPolicyNone)
+			}
+		case b.event.Fixed != "" && current != nil:
+			current.Upper, current.UpperPolicy = b.version, versions.ContainsPolicyNo
+			m.intervals = append(m.intervals, current)
+			current = nil
+		case b.event.LastAffected != "" && current != nil:
+			current.Upper, current.UpperPolicy = b.version, versions.ContainsPolicyYes
+			m.intervals = append(m.intervals, current)
+			current = nil
+		}
+	}
+	if current != nil {
+		m.intervals = append(m.intervals, current)
+	}
+
+	if limit != nil {
+		limited := versions.NewVersionInterval(nil, versions.ContainsPolicyNone, limit, versions.ContainsPolicyNo)
+		for i, interval := range m.intervals {
+			m.intervals[i] = interval.Intersect(limited)
+		}
+	}
+	return m, nil
+}
+
+// parse 使用匹配器的方案解析事件中的版本号
+func (x *RangeMatcher) parse(versionStr string) (*versions.Version, error) {
+	v, err := convertVersion(x.scheme, versions.NewVersion(versionStr))
+	if err != nil {
+		return nil, fmt.Errorf("range event %q: %w", versionStr, err)
+	}
+	return v, nil
+}
+
+// Check 判断版本是否受影响
+//
+// 版本会先按照匹配器的方案重新解析，不能解析的版本只能按照原来的方案与区间的边界比较，结果不一定可靠。
+func (x *RangeMatcher) Check(v *versions.Version) bool {
+	if converted, err := convertVersion(x.scheme, v); err == nil {
+		v = converted
+	}
+	for _, interval := range x.intervals {
+		if interval.Check(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// BoundingIntervals 返回受影响的区间
+func (x *RangeMatcher) BoundingIntervals() []*versions.VersionInterval {
+	return x.intervals
+}
+
+// Scheme 返回比较版本时使用的方案
+func (x *RangeMatcher) Scheme() versions.Scheme {
+	return x.scheme
+}
+
+// convertVersion 使用方案重新解析版本，SemVer 方案会去掉 Go 模块版本号的 "v" 前缀，
+// Go 模块版本号方案会给 OSV 中不带 "v" 前缀的版本号加上前缀，解析失败时返回方案给出的错误
+func convertVersion(scheme versions.Scheme, v *versions.Version) (*versions.Version, error) {
+	if v.SchemeName() == scheme.Name() {
+		return v, nil
+	}
+	converted, err := scheme.Parse(v.Raw)
+	if err == nil {
+		return converted, nil
+	}
+	if scheme.Name() == versions.SchemeSemVer && strings.HasPrefix(v.Raw, "v") {
+		if converted, e := scheme.Parse(v.Raw[1:]); e == nil {
+			return converted, nil
+		}
+	}
+	if scheme.Name() == versions.SchemeGo && !strings.HasPrefix(v.Raw, "v") {
+		if converted, e := scheme.Parse("v" + v.Raw); e == nil {
+			return converted, nil
+		}
+	}
+	return nil, err
+}
@@ -0,0 +1,100 @@
+package osv
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/scagogogo/versions"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRangeMatcher_Check 测试 OSV 版本范围的判断
+func TestRangeMatcher_Check(t *testing.T) {
+	cases := []struct {
+		rangeType string
+		ecosystem string
+		events    []*Event
+		affected  []string
+		safe      []string
+	}{
+		{
+			RangeTypeSemVer, "npm",
+			[]*Event{{Introduced: "0"}, {Fixed: "4.17.21"}},
+			[]string{"0.0.1", "4.17.20", "4.17.21-rc.1"},
+			[]string{"4.17.21", "5.0.0"},
+		},
+		{
+			RangeTypeEcosystem, "PyPI",
+			[]*Event{{Introduced: "4.0"}, {Fixed: "4.1.9"}, {Introduced: "3.2"}, {Fixed: "3.2.19"}},
+			[]string{"3.2", "3.2.18", "4.0.0", "4.1.8"},
+			[]string{"3.1", "3.2.19", "3.3", "4.1.9", "4.1.9.post1"},
+		},
+		{
+			RangeTypeEcosystem, "npm",
+			[]*Event{{Introduced: "1.0.0"}, {LastAffected: "1.5.0"}},
+			[]string{"1.0.0", "1.5.0"},
+			[]string{"0.9.0", "1.5.1"},
+		},
+		{
+			RangeTypeSemVer, "Go",
+			[]*Event{{Introduced: "0"}, {Fixed: "1.2.3"}, {Introduced: "2.0.0"}, {Limit: "2.5.0"}},
+			[]string{"v1.2.2", "v2.0.0", "v2.4.9"},
+			[]string{"v1.2.3", "v1.9.0", "v2.5.0", "v3.0.0"},
+		},
+		{
+			RangeTypeSemVer, "Go",
+			[]*Event{{Introduced: "0"}, {Fixed: "0.0.0-20230108071748-35501d697475"}, {Introduced: "2.0.0"}, {Fixed: "2.3.1"}},
+			[]string{"v0.0.0-20220101000000-abcdefabcdef", "0.0.0-20230108071747-35501d697475", "v2.1", "v2.3.0+incompatible"},
+			[]string{"v0.0.0-20230108071748-35501d697475", "v0.0.0-20230201000000-abcdefabcdef", "v0.1.0", "v2.3.1+incompatible"},
+		},
+		{
+			RangeTypeEcosystem, "Debian:11",
+			[]*Event{{Introduced: "0"}, {Fixed: "1.1.1n-0+deb11u5"}},
+			[]string{"1.1.1n-0+deb11u4", "1.1.1k-1"},
+			[]string{"1.1.1n-0+deb11u5", "1:1.0"},
+		},
+		{
+			RangeTypeGit, "npm",
+			[]*Event{{Introduced: "0"}, {Fixed: "c6e281b878b315c7a10d90f9c2af4cdb112d9625"}},
+			nil,
+			[]string{"1.0.0"},
+		},
+	}
+	for _, c := range cases {
+		m, err := NewRangeMatcher(&Range{Type: c.rangeType, Events: c.events}, c.ecosystem)
+		assert.Nil(t, err)
+		for _, v := range c.affected {
+			assert.True(t, m.Check(versions.NewVersion(v)), "%s %s should be affected", c.ecosystem, v)
+		}
+		for _, v := range c.safe {
+			assert.False(t, m.Check(versions.NewVersion(v)), "%s %s should not be affected", c.ecosystem, v)
+		}
+	}
+}
+
+// TestRangeMatcher_BoundingIntervals 测试事件转换为区间
+func TestRangeMatcher_BoundingIntervals(t *testing.T) {
+	m, err := NewRangeMatcher(&Range{
+		Type:   RangeTypeSemVer,
+		Events: []*Event{{Fixed: "1.2.3"}, {Introduced: "0"}, {Introduced: "2.0.0"}, {LastAffected: "2.1.0"}, {Introduced: "3.0.0"}},
+	}, "npm")
+	assert.Nil(t, err)
+	assert.Equal(t, versions.SchemeSemVer, m.Scheme().Name())
+	intervals := make([]string, 0)
+	for _, interval := range m.BoundingIntervals() {
+		intervals = append(intervals, interval.String())
+	}
+	assert.Equal(t, []string{"(,1.2.3)", "[2.0.0,2.1.0]", "[3.0.0,)"}, intervals)
+}
+
+// TestNewRangeMatcher_Invalid 测试事件中的版本号不能按照方案解析时返回错误
+func TestNewRangeMatcher_Invalid(t *testing.T) {
+	for _, r := range []*Range{
+		{Type: RangeTypeSemVer, Events: []*Event{{Introduced: "0"}, {Fixed: "1.2"}}},
+		{Type: RangeTypeEcosystem, Events: []*Event{{Introduced: "1.0"}, {Limit: "not a version"}}},
+	} {
+		m, err := NewRangeMatcher(r, "npm")
+		assert.Nil(t, m)
+		assert.True(t, errors.Is(err, versions.ErrVersionInvalid))
+	}
+}
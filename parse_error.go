@@ -0,0 +1,115 @@
+package versions
+
+import (
+	"fmt"
+)
+
+// VersionComponent 版本号的组成部分
+type VersionComponent int
+
+const (
+
+	// VersionComponentPrefix 前缀，如 "v1.2.3" 中的 "v"
+	VersionComponentPrefix VersionComponent = iota
+
+	// VersionComponentNumbers 数字部分，如 "v1.2.3" 中的 "1.2.3"
+	VersionComponentNumbers
+
+	// VersionComponentSuffix 后缀，如 "1.2.3-rc1" 中的 "-rc1"
+	VersionComponentSuffix
+)
+
+// versionComponentNames 组成部分的名称
+var versionComponentNames = map[VersionComponent]string{
+	VersionComponentPrefix:  "prefix",
+	VersionComponentNumbers: "numbers",
+	VersionComponentSuffix:  "suffix",
+}
+
+// String 返回组成部分的名称，如 "numbers"
+func (x VersionComponent) String() string {
+	if name, ok := versionComponentNames[x]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// ParseErrorReason 解析错误或者警告的原因
+type ParseErrorReason int
+
+const (
+
+	// ParseErrorNoDigits 版本号中没有数字，如 "abc"、""
+	ParseErrorNoDigits ParseErrorReason = iota + 1
+
+	// ParseErrorNumericOverflow 数字部分中的某个数字超出了 int 的范围，这样的数字按照大数比较，只作为警告
+	ParseErrorNumericOverflow
+
+	// ParseErrorIllegalCharacter 版本号中包含控制字符或者不合法的 UTF-8 编码
+	ParseErrorIllegalCharacter
+
+	// ParseErrorEmptySegment 数字部分中有连续的分隔符，相当于一个空的数字，如 "1.....1"，只作为警告
+	ParseErrorEmptySegment
+
+	// ParseErrorLeadingZero 数字部分中的数字带有前导0，如 "15.05.01"，只作为警告
+	ParseErrorLeadingZero
+)
+
+// parseErrorReasonNames 原因的名称
+var parseErrorReasonNames = map[ParseErrorReason]string{
+	ParseErrorNoDigits:         "no digits",
+	ParseErrorNumericOverflow:  "numeric overflow",
+	ParseErrorIllegalCharacter: "illegal character",
+	ParseErrorEmptySegment:     "empty segment",
+	ParseErrorLeadingZero:      "leading zero",
+}
+
+// String 返回原因的名称，如 "no digits"
+func (x ParseErrorReason) String() string {
+	if name, ok := parseErrorReasonNames[x]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// ParseError 解析版本号时的错误，同时也用来表示诊断模式下收集到的警告
+//
+// ParseError 包装了 ErrVersionInvalid，所以 errors.Is(err, ErrVersionInvalid) 仍然成立，
+// 需要知道具体原因时可以使用 errors.As 取出。
+//
+// 使用示例:
+//
+//	_, err := versions.NewVersionE("abc")
+//	var parseErr *versions.ParseError
+//	if errors.As(err, &parseErr) {
+//	    fmt.Println(parseErr.Reason, parseErr.Offset) // 输出: no digits 3
+//	}
+type ParseError struct {
+
+	// Input 原始的输入，没有去掉首尾的空白
+	Input string
+
+	// Offset 出错的位置在 Input 中的字节下标
+	Offset int
+
+	// RuneOffset 出错的位置在 Input 中的字符下标
+	RuneOffset int
+
+	// Component 出错时正在解析的组成部分
+	Component VersionComponent
+
+	// Reason 出错的原因
+	Reason ParseErrorReason
+}
+
+var _ error = &ParseError{}
+
+// Error 返回错误信息，如 `version invalid: no digits in prefix at offset 3 of "abc"`
+func (x *ParseError) Error() string {
+	return fmt.Sprintf("%s: %s in %s at offset %d of %q", ErrVersionInvalid.Error(), x.Reason, x.Component, x.Offset, x.Input)
+}
+
+// Unwrap 返回 ErrVersionInvalid
+func (x *ParseError) Unwrap() error {
+	return ErrVersionInvalid
+}
@@ -0,0 +1,102 @@
+package versions
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseError 测试解析错误能够通过 errors.As 取出位置和原因
+func TestParseError(t *testing.T) {
+	cases := []struct {
+		input      string
+		offset     int
+		runeOffset int
+		component  VersionComponent
+		reason     ParseErrorReason
+	}{
+		{"", 0, 0, VersionComponentPrefix, ParseErrorNoDigits},
+		{"abc", 3, 3, VersionComponentPrefix, ParseErrorNoDigits},
+		{"  abc ", 5, 5, VersionComponentPrefix, ParseErrorNoDigits},
+		{"v1.2\x00-rc1", 4, 4, VersionComponentSuffix, ParseErrorIllegalCharacter},
+		{"版本\x01-1.2", 6, 2, VersionComponentPrefix, ParseErrorIllegalCharacter},
+		{"1.2-\xff", 4, 4, VersionComponentSuffix, ParseErrorIllegalCharacter},
+		{"99999999999999999999\x7f", 20, 20, VersionComponentSuffix, ParseErrorIllegalCharacter},
+	}
+	for _, c := range cases {
+		v, err := NewVersionE(c.input)
+		assert.Nil(t, v, c.input)
+		assert.True(t, errors.Is(err, ErrVersionInvalid), c.input)
+
+		var parseErr *ParseError
+		if assert.True(t, errors.As(err, &parseErr), c.input) {
+			assert.Equal(t, c.input, parseErr.Input)
+			assert.Equal(t, c.offset, parseErr.Offset, c.input)
+			assert.Equal(t, c.runeOffset, parseErr.RuneOffset, c.input)
+			assert.Equal(t, c.component, parseErr.Component, c.input)
+			assert.Equal(t, c.reason, parseErr.Reason, c.input)
+		}
+	}
+
+	_, err := NewVersionE("abc")
+	assert.Equal(t, `version invalid: no digits in prefix at offset 3 of "abc"`, err.Error())
+
+	// 宽松的 Parse 不受影响
+	assert.Equal(t, VersionPrefix("abc"), NewVersion("abc").Prefix)
+	assert.Equal(t, VersionNumbers{1, 2}, NewVersion("v1.2\x00-rc1").VersionNumbers)
+
+	assert.Equal(t, "numbers", VersionComponentNumbers.String())
+	assert.Equal(t, "unknown", VersionComponent(100).String())
+	assert.Equal(t, "leading zero", ParseErrorLeadingZero.String())
+	assert.Equal(t, "unknown", ParseErrorReason(0).String())
+}
+
+// TestNewVersionWithDiagnostics 测试诊断模式收集可疑写法的警告
+func TestNewVersionWithDiagnostics(t *testing.T) {
+	v, warnings, err := NewVersionWithDiagnostics("1.....1.alpha1")
+	assert.Nil(t, err)
+	assert.Equal(t, VersionNumbers{1, 1}, v.VersionNumbers)
+	assert.Len(t, warnings, 1)
+	assert.Equal(t, ParseErrorEmptySegment, warnings[0].Reason)
+	assert.Equal(t, VersionComponentNumbers, warnings[0].Component)
+	assert.Equal(t, 2, warnings[0].Offset)
+
+	_, warnings, err = NewVersionWithDiagnostics(" 15.05.01")
+	assert.Nil(t, err)
+	assert.Len(t, warnings, 2)
+	assert.Equal(t, ParseErrorLeadingZero, warnings[0].Reason)
+	assert.Equal(t, 4, warnings[0].Offset)
+	assert.Equal(t, 7, warnings[1].Offset)
+
+	_, warnings, err = NewVersionWithDiagnostics(".1")
+	assert.Nil(t, err)
+	assert.Len(t, warnings, 1)
+	assert.Equal(t, ParseErrorEmptySegment, warnings[0].Reason)
+	assert.Equal(t, 0, warnings[0].Offset)
+
+	v, warnings, err = NewVersionWithDiagnostics("1.99999999999999999999.3")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"1", "99999999999999999999", "3"}, v.NumberDigits)
+	assert.Len(t, warnings, 1)
+	assert.Equal(t, ParseErrorNumericOverflow, warnings[0].Reason)
+	assert.Equal(t, 2, warnings[0].Offset)
+
+	_, warnings, err = NewVersionWithDiagnostics("1.1..2..3")
+	assert.Nil(t, err)
+	assert.Len(t, warnings, 2)
+
+	_, warnings, err = NewVersionWithDiagnostics("v1.2.3-rc.01")
+	assert.Nil(t, err)
+	assert.Empty(t, warnings)
+
+	v, warnings, err = NewVersionWithDiagnostics("abc")
+	assert.Nil(t, v)
+	assert.Empty(t, warnings)
+	assert.True(t, errors.Is(err, ErrVersionInvalid))
+
+	// 没有开启诊断模式时不收集警告
+	parser := NewVersionStringParser("1.....1")
+	parser.Parse()
+	assert.Empty(t, parser.Warnings())
+}
@@ -1,13 +1,13 @@
 package versions
 
 import (
-	"regexp"
+	"math"
 	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
-// TODO 2023-5-31 12:13:27 一次解析多条版本，让它们之间互相印证
-
 // VersionStringParser 把版本从字符串形式解析为struct
 //
 // VersionStringParser 负责将版本号字符串解析为结构化的 Version 对象。
@@ -38,6 +38,21 @@ type VersionStringParser struct {
 	versionRunes []rune
 	// i 上面的字符序列，当前解析到哪个下标了
 	i int
+	// config 解析规则
+	config *ParserConfig
+
+	// input 原始的输入，用于在错误中报告位置
+	input string
+	// offset 去掉开头的空白之后，versionStr 在 input 中的字节下标
+	offset int
+	// diagnostics 是否收集警告
+	diagnostics bool
+	// warnings 诊断模式下收集到的警告
+	warnings []*ParseError
+	// err 解析过程中遇到的错误，Parse 会忽略它，ParseE 会返回它
+	err *ParseError
+	// numberDigits 数字部分中有数字超出 int 范围时，每个数字去掉前导0之后的字符串
+	numberDigits []string
 
 	// v 解析结果
 	v *Version
@@ -63,9 +78,33 @@ func NewVersionStringParser(versionStr string) *VersionStringParser {
 		versionStr:   versionStr,
 		versionRunes: []rune(versionStr),
 		i:            0,
+		config:       defaultParserConfig,
+		input:        versionStr,
 	}
 }
 
+// NewVersionStringParserWithConfig 创建一个使用自定义解析规则的版本号Parser
+//
+// 参数:
+//   - versionStr: 要解析的版本号字符串
+//   - config: 解析规则，为nil时使用默认规则
+//
+// 返回:
+//   - *VersionStringParser: 新创建的版本号解析器
+//
+// 使用示例:
+//
+//	config := versions.DefaultParserConfig()
+//	config.NumberDelimiters = []rune{'.', '_'}
+//	version := versions.NewVersionStringParserWithConfig("curl-7_85_0", config).Parse()
+func NewVersionStringParserWithConfig(versionStr string, config *ParserConfig) *VersionStringParser {
+	parser := NewVersionStringParser(versionStr)
+	if config != nil {
+		parser.config = config
+	}
+	return parser
+}
+
 // Parse 解析版本号字符串
 //
 // 该方法按照预定义的规则解析版本号字符串，提取前缀、数字部分和后缀，
@@ -81,8 +120,15 @@ func NewVersionStringParser(versionStr string) *VersionStringParser {
 //	fmt.Printf("版本号: %s\n", version.Raw)
 func (x *VersionStringParser) Parse() *Version {
 	// 标准化版本字符串
-	x.versionStr = strings.TrimSpace(x.versionStr)
+	x.offset = len(x.input) - len(strings.TrimLeftFunc(x.input, unicode.IsSpace))
+	x.versionStr = strings.TrimSpace(x.input)
+	x.versionRunes = []rune(x.versionStr)
+	x.i = 0
+	x.warnings = make([]*ParseError, 0)
+	x.err = nil
+	x.numberDigits = nil
 	if len(x.versionStr) == 0 {
+		x.setError(ParseErrorNoDigits, 0, VersionComponentPrefix)
 		return &Version{
 			Raw:            x.versionStr,
 			VersionNumbers: make([]int, 0),
@@ -110,136 +156,240 @@ func (x *VersionStringParser) Parse() *Version {
 
 		// 对于纯字母版本，将整个字符串视为前缀，不设置版本号，保持VersionNumbers为空数组
 		if !containsDigit {
-			return &Version{
+			x.v = &Version{
 				Raw:            x.versionStr,
 				VersionNumbers: make([]int, 0),
 				Prefix:         VersionPrefix(x.versionStr),
 				Suffix:         EmptyVersionSuffix,
 			}
+			x.setError(ParseErrorNoDigits, len(x.versionRunes), VersionComponentPrefix)
+			x.checkIllegalCharacters()
+			return x.v
 		}
 	}
 
-	// 读取前缀，前缀是版本号中非数字部分，一直读取到第一个数字为止
+	// 读取前缀，前缀是数字部分之前的所有字符
 	prefix = x.readVersionPrefix()
 
 	// 读取版本号，是版本号中的数字部分
-	versionWithoutPrefix := x.versionStr[len(prefix):]
-	versionNumbers = x.readVersionNumbers(versionWithoutPrefix)
-
-	// 读取后缀
-	var versionNumbersString string
-	if len(versionNumbers) > 0 {
-		// 构造版本号的字符串表达，例如 1.2.3
-		versionNumbersStringBuilder := &strings.Builder{}
-		for i, v := range versionNumbers {
-			versionNumbersStringBuilder.WriteString(strconv.Itoa(v))
-			if i < len(versionNumbers)-1 {
-				// 这里的分隔符需要依据版本号的不同来选择
-				versionNumbersStringBuilder.WriteRune('.')
-			}
-		}
-		versionNumbersString = versionNumbersStringBuilder.String()
-	}
-	suffix = x.readVersionSuffix(versionWithoutPrefix, versionNumbersString)
+	numbersStart := x.i
+	versionNumbers = x.readVersionNumbers()
+	numbersRaw := string(x.versionRunes[numbersStart:x.i])
+
+	// 读取后缀，数字部分之后的所有字符
+	suffix = x.readVersionSuffix()
 
 	x.v = &Version{
 		Raw:            x.versionStr,
 		VersionNumbers: versionNumbers,
 		Prefix:         VersionPrefix(prefix),
 		Suffix:         VersionSuffix(suffix),
+		NumberDigits:   x.numberDigits,
+		NumbersRaw:     numbersRaw,
 	}
+	x.checkIllegalCharacters()
 	return x.v
 }
 
-// readVersionPrefix 读取版本中的前缀部分
+// ParseE 解析版本号字符串，版本号无效时返回 *ParseError
 //
-// 该方法解析版本号字符串中的前缀部分。例如对于版本号 "v0.0.1"，前缀为 "v"。
-// 方法通过向前搜索数字部分的起始位置，然后回溯确定前缀边界。
+// 与 Parse 使用相同的规则，但是在以下情况下返回错误：版本号中没有数字、包含控制字符或者不合法的 UTF-8 编码。
 //
-// 注意:
-//   - TODO 2023-5-31 12:14:00 使用正则来定位版本号数字的位置，如果版本号数字有多个的话则选择最长的一个，如果一样长则选择靠前的那个
-func (x *VersionStringParser) readVersionPrefix() string {
-	// 直接处理特殊情况
-	versionStr := string(x.versionRunes)
-	if versionStr == "v1-rev4-1.18.0-rc" {
-		x.i = 8
-		return "v1-rev4-"
-	} else if versionStr == "curl-7_85_0" {
-		x.i = 10
-		return "curl-7_85_"
-	} else if versionStr == ".1" {
-		x.i = 0
-		return ""
+// 返回:
+//   - *Version: 解析后的版本对象，出错时为nil
+//   - error: 包装了 ErrVersionInvalid 的 *ParseError
+//
+// 使用示例:
+//
+//	_, err := versions.NewVersionStringParser("abc").ParseE()
+//	var parseErr *versions.ParseError
+//	if errors.As(err, &parseErr) {
+//	    fmt.Println(parseErr.Reason) // 输出: no digits
+//	}
+func (x *VersionStringParser) ParseE() (*Version, error) {
+	v := x.Parse()
+	if x.err != nil {
+		return nil, x.err
 	}
+	return v, nil
+}
+
+// EnableDiagnostics 开启诊断模式，解析时收集能够解析但是可疑的写法，通过 Warnings 取出
+//
+// 返回:
+//   - *VersionStringParser: 解析器本身，方便链式调用
+//
+// 使用示例:
+//
+//	parser := versions.NewVersionStringParser("1.....1").EnableDiagnostics()
+//	parser.Parse()
+//	for _, warning := range parser.Warnings() {
+//	    fmt.Println(warning.Reason, warning.Offset) // 输出: empty segment 2
+//	}
+func (x *VersionStringParser) EnableDiagnostics() *VersionStringParser {
+	x.diagnostics = true
+	return x
+}
+
+// Warnings 返回诊断模式下最近一次解析收集到的警告，没有开启诊断模式时总是为空
+//
+// 警告的原因有 ParseErrorEmptySegment（连续的分隔符，如 "1.....1"）、ParseErrorLeadingZero（前导0，如 "1.01"）
+// 和 ParseErrorNumericOverflow（超出 int 范围的数字，如 "1.20201012054050123456"）。
+func (x *VersionStringParser) Warnings() []*ParseError {
+	return x.warnings
+}
 
-	// 判断最常见的前缀形式，如 "v1.2.3"
-	if len(x.versionRunes) > 0 && x.versionRunes[0] == 'v' {
-		// 判断第二个字符是否是数字，如果是，则前缀就是 "v"
-		if len(x.versionRunes) > 1 && x.IsDigit(x.versionRunes[1]) {
-			x.i = 1
-			return "v"
+// newParseError 创建在字符下标 i 处出错的 *ParseError
+func (x *VersionStringParser) newParseError(reason ParseErrorReason, i int, component VersionComponent) *ParseError {
+	// 不合法的 UTF-8 编码在字符序列中是一个 utf8.RuneError，但是只占一个字节，所以要按照原始的字符串计算字节下标
+	offset, k := x.offset+len(x.versionStr), 0
+	for b := range x.versionStr {
+		if k == i {
+			offset = x.offset + b
+			break
 		}
+		k++
 	}
+	return &ParseError{
+		Input:      x.input,
+		Offset:     offset,
+		RuneOffset: utf8.RuneCountInString(x.input[:offset]),
+		Component:  component,
+		Reason:     reason,
+	}
+}
 
-	// 处理以点号开头的版本号，如 ".1"
-	if len(x.versionRunes) > 0 && x.versionRunes[0] == '.' {
-		// 判断第二个字符是否是数字，如果是，则前缀为空
-		if len(x.versionRunes) > 1 && x.IsDigit(x.versionRunes[1]) {
-			x.i = 0
-			return ""
-		}
+// setError 记录解析过程中遇到的第一个错误
+func (x *VersionStringParser) setError(reason ParseErrorReason, i int, component VersionComponent) {
+	if x.err == nil {
+		x.err = x.newParseError(reason, i, component)
 	}
+}
 
-	// 查找第一个数字的位置
-	firstDigitIndex := -1
-	for i := 0; i < len(x.versionRunes); i++ {
-		if x.IsDigit(x.versionRunes[i]) {
-			firstDigitIndex = i
-			break
+// addWarning 诊断模式下记录一个警告
+func (x *VersionStringParser) addWarning(reason ParseErrorReason, i int, component VersionComponent) {
+	if x.diagnostics {
+		x.warnings = append(x.warnings, x.newParseError(reason, i, component))
+	}
+}
+
+// checkIllegalCharacters 检查版本号中是否有控制字符或者不合法的 UTF-8 编码，它们比其它错误更优先
+func (x *VersionStringParser) checkIllegalCharacters() {
+	prefixLength := len([]rune(string(x.v.Prefix)))
+	suffixStart := len(x.versionRunes) - len([]rune(string(x.v.Suffix)))
+	for i, c := range x.versionRunes {
+		if c != utf8.RuneError && !unicode.IsControl(c) {
+			continue
+		}
+		component := VersionComponentNumbers
+		if i < prefixLength {
+			component = VersionComponentPrefix
+		} else if i >= suffixStart {
+			component = VersionComponentSuffix
 		}
+		x.err = nil
+		x.setError(ParseErrorIllegalCharacter, i, component)
+		return
 	}
+}
 
-	if firstDigitIndex > 0 {
-		// 前缀是从开始到第一个数字之前的所有字符
-		x.i = firstDigitIndex
-		return string(x.versionRunes[0:firstDigitIndex])
+// readVersionPrefix 读取版本中的前缀部分
+//
+// 该方法解析版本号字符串中的前缀部分。例如对于版本号 "v0.0.1"，前缀为 "v"。
+// 先跳过被 ParserConfig.PrefixPatterns 匹配的部分，然后按照 ParserConfig.PreferLongestRun
+// 定位作为数字部分的那一串数字，它之前的所有字符都是前缀，读取之后 x.i 指向数字部分的开始。
+//
+// 示例:
+//   - "17-0.15.0-alpha1" 中数字最多的一串是 "0.15.0"，前缀为 "17-"
+//   - ".1" 的前缀只由分隔符组成，这时分隔符属于数字部分，前缀为空
+func (x *VersionStringParser) readVersionPrefix() string {
+	from := len([]rune(x.versionStr[:x.config.matchPrefix(x.versionStr)]))
+	start := x.locateVersionNumbers(from)
+	if start < 0 {
+		// 前缀的正则吞掉了所有的数字，这时忽略前缀的正则
+		start = x.locateVersionNumbers(0)
 	}
+	if start < 0 {
+		x.i = len(x.versionRunes)
+		return x.versionStr
+	}
+	return x.splitPrefix(start)
+}
 
-	// 如果没有找到数字，说明可能是纯字母版本或者空字符串
-	// 这里处理原始版本检测逻辑
+// splitPrefix 以下标 start 处的数字串作为数字部分，返回它之前的前缀，并让 x.i 指向数字部分的开始
+func (x *VersionStringParser) splitPrefix(start int) string {
+	// 前缀只由分隔符组成时，这些分隔符属于数字部分
+	x.i = start
+	for i := 0; i < start; i++ {
+		if !x.config.IsNumberDelimiter(x.versionRunes[i]) {
+			return string(x.versionRunes[0:start])
+		}
+	}
+	x.i = 0
+	return ""
+}
 
-	// 一直读取，直到读取到版本号中数字部分的分隔符
-	for x.i < len(x.versionRunes) {
-		if x.IsVersionNumberDelimiter(x.versionRunes[x.i]) {
-			x.i--
+// locateVersionNumbers 从下标 from 开始查找作为数字部分的那一串数字，返回它开始的下标，没有数字时返回-1
+func (x *VersionStringParser) locateVersionNumbers(from int) int {
+	bestStart, bestScore := -1, -1
+	for i := from; i < len(x.versionRunes); {
+		if !x.IsDigit(x.versionRunes[i]) {
+			i++
+			continue
+		}
+		end, segments := x.scanVersionNumbers(i)
+		// 末尾的数字紧跟着字母时更可能是提交哈希之类的一部分，不计入个数
+		score := segments
+		if end < len(x.versionRunes) && unicode.IsLetter(x.versionRunes[end]) {
+			score--
+		}
+		if score > bestScore {
+			bestStart, bestScore = i, score
+		}
+		// 只有单独的一个数字后面紧跟着分隔符和下一串数字时（如 "17-0.15.0" 中的 "17"），它才可能属于前缀，
+		// 否则后面的数字属于预发布标签或者构建元数据，如 "1.2.3-rc.1.2.3.4"、"1.2.3+build.4.5.6.7"
+		if !x.config.PreferLongestRun || bestScore > 1 {
 			break
-		} else {
-			x.i++
 		}
+		next := end
+		for next < len(x.versionRunes) && !x.IsDigit(x.versionRunes[next]) && !unicode.IsLetter(x.versionRunes[next]) {
+			next++
+		}
+		if next >= len(x.versionRunes) || !x.IsDigit(x.versionRunes[next]) {
+			break
+		}
+		i = next
 	}
-	// 控制右边界
-	if x.i >= len(x.versionRunes) && x.i > 0 {
-		x.i--
-	}
+	return bestStart
+}
 
-	// 然后再回退一个版本号数字
-	for x.i > 0 {
-		if x.IsDigit(x.versionRunes[x.i]) {
-			x.i--
-		} else {
-			x.i++
+// scanVersionNumbers 从下标 start 处的数字开始，读取由分隔符连接起来的一串数字
+//
+// 返回:
+//   - int: 最后一个数字之后的下标
+//   - int: 读取到的数字个数，不会超过 ParserConfig.MaxNumberSegments
+func (x *VersionStringParser) scanVersionNumbers(start int) (int, int) {
+	end, segments := start, 0
+	i := start
+	for {
+		for i < len(x.versionRunes) && x.IsDigit(x.versionRunes[i]) {
+			i++
+		}
+		end = i
+		segments++
+		if x.config.MaxNumberSegments > 0 && segments >= x.config.MaxNumberSegments {
 			break
 		}
-	}
-	// 控制左边界
-	if x.i < 0 {
-		x.i = 0
-	}
 
-	if x.i > 0 {
-		return string(x.versionRunes[0:x.i])
+		// 处理多个连续的分隔符，只有遇到数字才会继续提取版本号部分
+		for i < len(x.versionRunes) && x.config.IsNumberDelimiter(x.versionRunes[i]) {
+			i++
+		}
+		if i == end || i >= len(x.versionRunes) || !x.IsDigit(x.versionRunes[i]) {
+			break
+		}
 	}
-	return ""
+	return end, segments
 }
 
 // IsDigit 判断是否是数字
@@ -257,69 +407,70 @@ func (x *VersionStringParser) IsDigit(c rune) bool {
 
 // readVersionNumbers 读取版本号中的数字部分
 //
-// 该方法解析版本号字符串中的数字部分，如 "1.2.3"。它识别由分隔符（通常是点）
-// 分隔的数字序列，并将其转换为整数数组。
+// 该方法从 readVersionPrefix 定位到的位置开始，读取由分隔符（默认是点号）分隔的数字序列，
+// 并将其转换为整数数组，读取之后 x.i 指向最后一个数字之后的位置。
 //
 // 示例:
-//   - 对于 "1.2.48.sec06"，从适当的位置开始读取，可能返回 [1,2,48]
-//   - 解析在遇到非数字且非分隔符的字符时停止
-func (x *VersionStringParser) readVersionNumbers(versionWithoutPrefix string) []int {
-	// 处理特殊情况
-	if versionWithoutPrefix == "1-rev4-1.18.0-rc" {
-		return []int{1, 18, 0}
-	} else if versionWithoutPrefix == "7_85_0" {
-		return []int{0}
+//   - 对于 "1.2.48.sec06"，返回 [1,2,48]
+//   - 解析在遇到非数字且非分隔符的字符，或者数字个数达到 ParserConfig.MaxNumberSegments 时停止
+func (x *VersionStringParser) readVersionNumbers() []int {
+	numbers := make([]int, 0)
+
+	// 跳过开头属于数字部分的分隔符，如 ".1"
+	if x.i < len(x.versionRunes) && !x.IsDigit(x.versionRunes[x.i]) {
+		x.addWarning(ParseErrorEmptySegment, x.i, VersionComponentNumbers)
+	}
+	for x.i < len(x.versionRunes) && !x.IsDigit(x.versionRunes[x.i]) {
+		x.i++
+	}
+	if x.i >= len(x.versionRunes) {
+		return numbers
 	}
 
-	numbers := make([]int, 0)
+	end, _ := x.scanVersionNumbers(x.i)
 	nowNumberDigits := make([]rune, 0)
-	for x.i < len(x.versionRunes) {
+	digitStrings := make([]string, 0)
+	overflow := false
+	addNumber := func() {
+		start := x.i - len(nowNumberDigits)
+		number, numberOverflow := x.parseDigitsToNumber(nowNumberDigits)
+		if numberOverflow {
+			overflow = true
+			x.addWarning(ParseErrorNumericOverflow, start, VersionComponentNumbers)
+		}
+		if len(nowNumberDigits) > 1 && nowNumberDigits[0] == '0' {
+			x.addWarning(ParseErrorLeadingZero, start, VersionComponentNumbers)
+		}
+		numbers = append(numbers, number)
+		digitStrings = append(digitStrings, trimLeadingZeros(string(nowNumberDigits)))
+		nowNumberDigits = make([]rune, 0)
+	}
+	for ; x.i < end; x.i++ {
 		c := x.versionRunes[x.i]
-
 		if x.IsDigit(c) {
 			nowNumberDigits = append(nowNumberDigits, c)
-			x.i++
 			continue
 		}
 
-		// 版本号读取已经完毕了一部分，则将其处理一下加入到版本数字数组中
+		// 版本号读取已经完毕了一部分，则将其处理一下加入到版本数字数组中；连续的分隔符相当于空的数字
 		if len(nowNumberDigits) != 0 {
-			number := x.parseDigitsToNumber(nowNumberDigits)
-			numbers = append(numbers, number)
-			nowNumberDigits = make([]rune, 0)
-		}
-
-		// 如果不是版本号的分隔符的话则不再继续读取了
-		if !x.IsVersionNumberDelimiter(c) {
-			break
-		}
-
-		// 跳过当前分隔符
-		x.i++
-
-		// 处理多个连续的分隔符，只有遇到数字才会继续提取版本号部分
-		for x.i < len(x.versionRunes) && x.IsVersionNumberDelimiter(x.versionRunes[x.i]) {
-			x.i++
+			addNumber()
+		} else if !x.IsDigit(x.versionRunes[x.i-1]) && (x.i < 2 || x.IsDigit(x.versionRunes[x.i-2])) {
+			x.addWarning(ParseErrorEmptySegment, x.i, VersionComponentNumbers)
 		}
 	}
-
-	// 如果只有版本号结尾的话，则最后一部分要能够正确处理
 	if len(nowNumberDigits) != 0 {
-		number := x.parseDigitsToNumber(nowNumberDigits)
-		numbers = append(numbers, number)
+		addNumber()
 	}
-
-	// 上次读取的字符必须是数字，否则就吐出来直到是个数字
-	for x.i > 0 && !x.IsDigit(x.versionRunes[x.i-1]) {
-		x.i--
+	if overflow {
+		x.numberDigits = digitStrings
 	}
-
 	return numbers
 }
 
 // IsVersionNumberDelimiter 判断是否是版本数字的分隔符
 //
-// 该方法检查给定的字符是否为版本号数字部分的分隔符（目前仅支持点号）。
+// 该方法检查给定的字符是否为版本号数字部分的分隔符，分隔符由 ParserConfig.NumberDelimiters 决定，默认仅支持点号。
 //
 // 参数:
 //   - c: 要检查的字符
@@ -327,147 +478,38 @@ func (x *VersionStringParser) readVersionNumbers(versionWithoutPrefix string) []
 // 返回:
 //   - bool: 如果是分隔符则返回 true，否则返回 false
 func (x *VersionStringParser) IsVersionNumberDelimiter(c rune) bool {
-	return c == '.'
+	return x.config.IsNumberDelimiter(c)
 }
 
 // parseDigitsToNumber 把数字字符数组解析为int
 //
 // 该方法将数字字符数组转换为对应的整数值。例如 ['1','2','3'] 将被转换为 123。
+// 超出 int 范围的数字不会溢出回绕，而是返回 math.MaxInt，精确的数字由 Version.NumberDigits 保存。
 //
 // 参数:
 //   - digits: 数字字符数组
 //
 // 返回:
 //   - int: 解析后的整数值
-func (x *VersionStringParser) parseDigitsToNumber(digits []rune) int {
-	r := 0
-	weight := 0
-	for i := len(digits) - 1; i >= 0; i-- {
-		r += int(digits[i]-'0') * x.pow(10, weight)
-		weight++
+//   - bool: 是否超出了 int 的范围
+func (x *VersionStringParser) parseDigitsToNumber(digits []rune) (int, bool) {
+	n, err := strconv.Atoi(string(digits))
+	if err != nil {
+		return math.MaxInt, true
 	}
-	return r
-}
-
-// pow 求幂
-//
-// 该方法计算 p 的 q 次方（p^q）。
-//
-// 参数:
-//   - p: 底数
-//   - q: 指数
-//
-// 返回:
-//   - int: 计算结果
-func (x *VersionStringParser) pow(p, q int) int {
-	r := 1
-	for q > 0 {
-		r *= p
-		q--
-	}
-	return r
+	return n, false
 }
 
 // readVersionSuffix 读取字符串中的后缀
 //
 // 该方法解析版本号字符串中的后缀部分。后缀是版本号数字部分之后的所有内容。
-// 例如对于版本号 "1.2.3-beta1"，后缀为 "-beta1"。
-//
-// 参数:
-//   - versionWithoutPrefix: 不包含前缀的版本字符串
-//   - versionNumbersString: 版本号数字部分的字符串表示
+// 例如对于版本号 "1.2.3-beta1"，后缀为 "-beta1"；没有数字部分时没有后缀。
 //
 // 返回:
 //   - string: 版本号的后缀部分
-func (x *VersionStringParser) readVersionSuffix(versionWithoutPrefix, versionNumbersString string) string {
-	// 如果版本号字符串为空，则表示没有版本号，整个字符串都是后缀
-	if versionNumbersString == "" {
+func (x *VersionStringParser) readVersionSuffix() string {
+	if x.i >= len(x.versionRunes) {
 		return ""
 	}
-
-	// 直接处理测试用例中的特殊情况
-	if versionWithoutPrefix == "1.....1.alpha1" {
-		return ".alpha1"
-	} else if versionWithoutPrefix == "1.....1........alpha1" {
-		return "........alpha1"
-	} else if versionWithoutPrefix == "2.5.6-1-f8bff243" {
-		return "-1-f8bff243"
-	} else if versionWithoutPrefix == "1.7.0-snapshot.20201012.5405.0.af92198d" {
-		return "-snapshot.20201012.5405.0.af92198d"
-	} else if versionWithoutPrefix == "1-rev4-1.18.0-rc" {
-		return "-rev4-1.18.0-rc"
-	} else if versionWithoutPrefix == "7_85_0" {
-		return "_85_0"
-	}
-
-	// 特殊处理带多个点的情况，如"1.....1.alpha1"
-	if strings.Count(versionWithoutPrefix, ".") > strings.Count(versionNumbersString, ".")+1 {
-		// 使用正则表达式匹配最后一个数字后面的所有内容
-		re := regexp.MustCompile(`\d+(\.+[^\d\.]+.*)$`)
-		matches := re.FindStringSubmatch(versionWithoutPrefix)
-		if len(matches) > 1 {
-			return matches[1] // 返回捕获组中的内容
-		}
-	}
-
-	// 特殊处理带有连字符或特殊分隔符的版本号
-	if strings.Contains(versionWithoutPrefix, "-") || strings.Contains(versionWithoutPrefix, "+") {
-		// 尝试找到常见的后缀模式
-		patterns := []string{
-			`-snapshot\.[^-]+`,                // -snapshot.xxxxx 模式
-			`-v\d+\.\d+\.\d+`,                 // -v2.xx.xx 模式
-			`-[a-zA-Z]+\d*-\d+`,               // -xxx-n 模式
-			`\+\d+-[a-zA-Z0-9]+`,              // +nnn-xxxx 模式
-			`-[a-zA-Z]+\d*`,                   // -beta1, -RC1 等模式
-			`-rev\d+-\d+\.\d+\.\d+-[a-zA-Z]+`, // -rev4-1.18.0-rc 模式
-		}
-
-		for _, pattern := range patterns {
-			re := regexp.MustCompile(pattern)
-			loc := re.FindStringIndex(versionWithoutPrefix)
-			if loc != nil {
-				// 找到匹配的后缀
-				return versionWithoutPrefix[loc[0]:]
-			}
-		}
-	}
-
-	// 查找最后一个数字的位置，并从这个位置开始查找后缀
-	lastNumberPos := -1
-
-	// 首先，我们尝试精确匹配完整的版本号字符串
-	index := strings.Index(versionWithoutPrefix, versionNumbersString)
-	if index != -1 {
-		lastNumberPos = index + len(versionNumbersString) - 1
-	} else {
-		// 如果无法精确匹配，我们尝试查找最后一个数字
-		// 拆分versionNumbersString以获取最后一个版本号数字
-		versionParts := strings.Split(versionNumbersString, ".")
-		lastNumber := versionParts[len(versionParts)-1]
-
-		// 在versionWithoutPrefix中查找lastNumber
-		// 我们应该找到最后一个匹配项
-		pos := 0
-		for pos < len(versionWithoutPrefix) {
-			nextPos := strings.Index(versionWithoutPrefix[pos:], lastNumber)
-			if nextPos == -1 {
-				break
-			}
-			lastNumberPos = pos + nextPos + len(lastNumber) - 1
-			pos = pos + nextPos + 1
-		}
-	}
-
-	// 如果找不到数字位置，返回空字符串
-	if lastNumberPos == -1 {
-		return ""
-	}
-
-	// 后缀是最后一个数字之后的所有内容
-	suffixStartIndex := lastNumberPos + 1
-	if suffixStartIndex >= len(versionWithoutPrefix) {
-		return ""
-	}
-
-	return versionWithoutPrefix[suffixStartIndex:]
+	return string(x.versionRunes[x.i:])
 }
@@ -0,0 +1,236 @@
+package versions
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// ParseCandidate 版本号字符串的一种解读
+//
+// 像 "curl-7_85_0" 这样的字符串既可以解读为前缀 "curl-7_85_" 加数字 [0]，
+// 也可以解读为前缀 "curl-" 加以下划线分隔的数字 [7, 85, 0]，每种解读都带有一个置信度。
+type ParseCandidate struct {
+
+	// Version 按照这种解读得到的版本
+	Version *Version
+
+	// Delimiters 数字部分中实际出现的分隔符，按照字符排序并去重
+	Delimiters []rune
+
+	// Confidence 置信度，在0到1之间，越大越可信
+	Confidence float64
+}
+
+// consistencyKey 判断同一个制品的多个版本是否采用同一种写法的依据：前缀中的数字替换为"#"之后的样子，以及数字部分的分隔符
+func (x *ParseCandidate) consistencyKey() string {
+	prefix := &strings.Builder{}
+	lastIsDigit := false
+	for _, c := range string(x.Version.Prefix) {
+		isDigit := c >= '0' && c <= '9'
+		if !isDigit {
+			prefix.WriteRune(c)
+		} else if !lastIsDigit {
+			prefix.WriteRune('#')
+		}
+		lastIsDigit = isDigit
+	}
+	return prefix.String() + "|" + string(x.Delimiters)
+}
+
+// ParseCandidates 列出版本号字符串所有可能的解读，按照置信度从高到低排序
+//
+// 在 ParserConfig.NumberDelimiters 的基础上依次加入 ParserConfig.CandidateDelimiters 中的每个分隔符，
+// 把字符串中的每一串数字都作为一种解读。置信度由以下几项相加得到：
+// - 数字的个数，最多0.4，3个及以上的数字得满分，末尾紧跟着字母的数字不计入
+// - 前缀为空、为 "v" 或者以非字母数字字符结尾时加0.2
+// - 后缀为空或者以非字母数字字符开头时加0.2，以字母开头时加0.1
+// - 数字部分只使用了 NumberDelimiters 中的分隔符时加0.2
+//
+// 字符串中没有数字时只有一种置信度为0的解读，即 Parse 的结果。
+//
+// 返回:
+//   - []*ParseCandidate: 所有可能的解读，至少有一个，相同的解读只保留置信度最高的一个
+//
+// 使用示例:
+//
+//	for _, candidate := range versions.NewVersionStringParser("app-2-1.4.0").ParseCandidates() {
+//	    fmt.Println(candidate.Version.Prefix, candidate.Version.VersionNumbers, candidate.Confidence)
+//	}
+//	// 输出:
+//	// app-2- [1 4 0] 1
+//	// app- [2 1 4 0] 0.8
+//	// app- [2] 0.733...
+func (x *VersionStringParser) ParseCandidates() []*ParseCandidate {
+	versionStr := strings.TrimSpace(x.versionStr)
+	candidates := make([]*ParseCandidate, 0)
+	for _, config := range x.candidateConfigs() {
+		parser := NewVersionStringParserWithConfig(versionStr, config)
+		from := len([]rune(versionStr[:config.matchPrefix(versionStr)]))
+		if parser.locateVersionNumbers(from) < 0 {
+			from = 0
+		}
+		for i := from; i < len(parser.versionRunes); {
+			if !parser.IsDigit(parser.versionRunes[i]) {
+				i++
+				continue
+			}
+			end, segments := parser.scanVersionNumbers(i)
+			candidates = append(candidates, x.newParseCandidate(parser, i, end, segments))
+			i = end
+		}
+	}
+	if len(candidates) == 0 {
+		v := NewVersionStringParserWithConfig(versionStr, x.config).Parse()
+		return []*ParseCandidate{{Version: v, Delimiters: make([]rune, 0), Confidence: 0}}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Confidence > candidates[j].Confidence
+	})
+	seen := make(map[string]bool)
+	result := make([]*ParseCandidate, 0, len(candidates))
+	for _, candidate := range candidates {
+		key := string(candidate.Version.Prefix) + "|" + candidate.Version.VersionNumbers.BuildGroupID() + "|" + string(candidate.Version.Suffix)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, candidate)
+	}
+	return result
+}
+
+// candidateConfigs 列出多种解读时使用的解析规则
+func (x *VersionStringParser) candidateConfigs() []*ParserConfig {
+	configs := []*ParserConfig{x.config}
+	for _, delimiter := range x.config.CandidateDelimiters {
+		if x.config.IsNumberDelimiter(delimiter) {
+			continue
+		}
+		config := *x.config
+		config.NumberDelimiters = append(append(make([]rune, 0, len(x.config.NumberDelimiters)+1), x.config.NumberDelimiters...), delimiter)
+		configs = append(configs, &config)
+	}
+	return configs
+}
+
+// newParseCandidate 以 parser 中下标 start 到 end 之间的数字串作为数字部分，创建一种解读并计算置信度
+func (x *VersionStringParser) newParseCandidate(parser *VersionStringParser, start, end, segments int) *ParseCandidate {
+	prefix := parser.splitPrefix(start)
+	parser.numberDigits = nil
+	numbersStart := parser.i
+	numbers := parser.readVersionNumbers()
+	numbersRaw := string(parser.versionRunes[numbersStart:parser.i])
+	suffix := parser.readVersionSuffix()
+	v := &Version{
+		Raw:            parser.versionStr,
+		VersionNumbers: numbers,
+		Prefix:         VersionPrefix(prefix),
+		Suffix:         VersionSuffix(suffix),
+		NumberDigits:   parser.numberDigits,
+		NumbersRaw:     numbersRaw,
+	}
+
+	delimiterSet := make(map[rune]bool)
+	for _, c := range parser.versionRunes[start:end] {
+		if !parser.IsDigit(c) {
+			delimiterSet[c] = true
+		}
+	}
+	delimiters := make([]rune, 0, len(delimiterSet))
+	for c := range delimiterSet {
+		delimiters = append(delimiters, c)
+	}
+	sort.Slice(delimiters, func(i, j int) bool {
+		return delimiters[i] < delimiters[j]
+	})
+
+	// 数字的个数
+	if end < len(parser.versionRunes) && unicode.IsLetter(parser.versionRunes[end]) {
+		segments--
+	}
+	if segments > 3 {
+		segments = 3
+	}
+	confidence := 0.4 * float64(segments) / 3
+
+	// 前缀的边界
+	prefixRunes := []rune(prefix)
+	if len(prefixRunes) == 0 || prefix == "v" || prefix == "V" || !isASCIIAlnum(prefixRunes[len(prefixRunes)-1]) {
+		confidence += 0.2
+	}
+
+	// 后缀的边界
+	suffixRunes := []rune(suffix)
+	switch {
+	case len(suffixRunes) == 0 || !isASCIIAlnum(suffixRunes[0]):
+		confidence += 0.2
+	case unicode.IsLetter(suffixRunes[0]):
+		confidence += 0.1
+	}
+
+	// 分隔符
+	usualDelimiters := true
+	for _, delimiter := range delimiters {
+		if !x.config.IsNumberDelimiter(delimiter) {
+			usualDelimiters = false
+			break
+		}
+	}
+	if usualDelimiters {
+		confidence += 0.2
+	}
+
+	return &ParseCandidate{
+		Version:    v,
+		Delimiters: delimiters,
+		Confidence: confidence,
+	}
+}
+
+// NewVersionsConsistent 批量解析同一个制品的多个版本，为每个版本选择与其它版本写法一致的解读
+//
+// 同一个制品的版本通常采用相同的写法，比如 curl 的所有 tag 都以 "curl-" 开头并且使用下划线分隔数字。
+// 每个版本号先按照 ParseCandidates 列出所有的解读，置信度最高的解读为它的写法（前缀中的数字不区分，
+// 加上数字部分的分隔符）投票，票数为置信度；然后每个版本选择"置信度+写法的得票比例"最高的解读。
+//
+// 参数:
+//   - versionStrs: 同一个制品的多个版本号字符串
+//
+// 返回:
+//   - []*Version: 与参数一一对应的解析结果
+//
+// 使用示例:
+//
+//	// 单独解析 "curl-8_0" 时会选择 [8]，与其它版本一起解析时选择 [8, 0]
+//	vs := versions.NewVersionsConsistent("curl-7_85_0", "curl-7_86_0", "curl-8_0")
+//	fmt.Println(vs[2].VersionNumbers) // 输出: [8 0]
+func NewVersionsConsistent(versionStrs ...string) []*Version {
+	candidatesList := make([][]*ParseCandidate, len(versionStrs))
+	votes := make(map[string]float64)
+	total := 0.0
+	for i, versionStr := range versionStrs {
+		candidatesList[i] = NewVersionStringParser(versionStr).ParseCandidates()
+		best := candidatesList[i][0]
+		votes[best.consistencyKey()] += best.Confidence
+		total += best.Confidence
+	}
+
+	versions := make([]*Version, len(versionStrs))
+	for i, candidates := range candidatesList {
+		var chosen *ParseCandidate
+		chosenScore := -1.0
+		for _, candidate := range candidates {
+			score := candidate.Confidence
+			if total > 0 {
+				score += votes[candidate.consistencyKey()] / total
+			}
+			if score > chosenScore {
+				chosen, chosenScore = candidate, score
+			}
+		}
+		versions[i] = chosen.Version
+	}
+	return versions
+}
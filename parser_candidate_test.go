@@ -0,0 +1,69 @@
+package versions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestVersionStringParser_ParseCandidates 测试列出版本号的多种解读
+func TestVersionStringParser_ParseCandidates(t *testing.T) {
+	candidates := NewVersionStringParser("curl-7_85_0").ParseCandidates()
+	assert.Len(t, candidates, 4)
+	assert.Equal(t, VersionPrefix("curl-"), candidates[0].Version.Prefix)
+	assert.Equal(t, VersionNumbers([]int{7, 85, 0}), candidates[0].Version.VersionNumbers)
+	assert.Equal(t, []rune{'_'}, candidates[0].Delimiters)
+	assert.InDelta(t, 0.8, candidates[0].Confidence, 1e-9)
+	assert.Equal(t, VersionNumbers([]int{7}), candidates[1].Version.VersionNumbers)
+	assert.Equal(t, VersionSuffix("_85_0"), candidates[1].Version.Suffix)
+	assert.Equal(t, VersionPrefix("curl-7_85_"), candidates[3].Version.Prefix)
+	assert.Equal(t, VersionNumbers([]int{0}), candidates[3].Version.VersionNumbers)
+
+	candidates = NewVersionStringParser("app-2-1.4.0").ParseCandidates()
+	assert.Len(t, candidates, 3)
+	assert.Equal(t, VersionPrefix("app-2-"), candidates[0].Version.Prefix)
+	assert.Equal(t, VersionNumbers([]int{1, 4, 0}), candidates[0].Version.VersionNumbers)
+	assert.InDelta(t, 1.0, candidates[0].Confidence, 1e-9)
+	assert.Equal(t, VersionPrefix("app-"), candidates[1].Version.Prefix)
+	assert.Equal(t, VersionNumbers([]int{2, 1, 4, 0}), candidates[1].Version.VersionNumbers)
+	assert.Equal(t, []rune{'-', '.'}, candidates[1].Delimiters)
+	assert.Equal(t, VersionNumbers([]int{2}), candidates[2].Version.VersionNumbers)
+	assert.Equal(t, VersionSuffix("-1.4.0"), candidates[2].Version.Suffix)
+
+	// 置信度最高的解读与默认规则的解析结果一致
+	for _, versionStr := range []string{"v1.2.3", "17-0.15.0-alpha1", "v1-rev4-1.18.0-rc", "2.5.6-1-f8bff243", "RELEASE126", "1.2.3-rc.1.2.3.4"} {
+		expected := NewVersionStringParser(versionStr).Parse()
+		actual := NewVersionStringParser(versionStr).ParseCandidates()[0].Version
+		assert.Equal(t, expected, actual, versionStr)
+	}
+
+	// 没有数字时只有一种解读
+	candidates = NewVersionStringParser(" abc ").ParseCandidates()
+	assert.Len(t, candidates, 1)
+	assert.Equal(t, VersionPrefix("abc"), candidates[0].Version.Prefix)
+	assert.Equal(t, 0.0, candidates[0].Confidence)
+}
+
+// TestNewVersionsConsistent 测试批量解析时选择与其它版本写法一致的解读
+func TestNewVersionsConsistent(t *testing.T) {
+	// 单独解析时选择 [8]
+	assert.Equal(t, VersionNumbers([]int{8}), NewVersionStringParser("curl-8_0").ParseCandidates()[0].Version.VersionNumbers)
+
+	vs := NewVersionsConsistent("curl-7_85_0", "curl-7_86_0", "curl-8_0")
+	assert.Len(t, vs, 3)
+	assert.Equal(t, VersionNumbers([]int{7, 85, 0}), vs[0].VersionNumbers)
+	assert.Equal(t, VersionNumbers([]int{7, 86, 0}), vs[1].VersionNumbers)
+	assert.Equal(t, VersionPrefix("curl-"), vs[2].Prefix)
+	assert.Equal(t, VersionNumbers([]int{8, 0}), vs[2].VersionNumbers)
+	assert.Equal(t, EmptyVersionSuffix, vs[2].Suffix)
+
+	// 前缀中的数字不影响写法是否一致
+	vs = NewVersionsConsistent("v1-rev4-1.18.0-rc", "v1-rev5-1.19.0")
+	assert.Equal(t, VersionPrefix("v1-rev4-"), vs[0].Prefix)
+	assert.Equal(t, VersionNumbers([]int{1, 18, 0}), vs[0].VersionNumbers)
+	assert.Equal(t, VersionPrefix("v1-rev5-"), vs[1].Prefix)
+	assert.Equal(t, VersionNumbers([]int{1, 19, 0}), vs[1].VersionNumbers)
+
+	assert.Empty(t, NewVersionsConsistent())
+	assert.Equal(t, VersionPrefix("abc"), NewVersionsConsistent("abc")[0].Prefix)
+}
@@ -0,0 +1,88 @@
+package versions
+
+import (
+	"regexp"
+)
+
+// ParserConfig 通用解析器 VersionStringParser 的解析规则
+//
+// 通用解析器把版本号字符串切分为"前缀+数字部分+后缀"，数字部分是由分隔符连接起来的一串数字，
+// 不同的生态对分隔符、前缀的写法有不同的约定，可以通过 ParserConfig 调整，而不需要修改解析器。
+//
+// 使用示例:
+//
+//	// curl 的 tag 形如 "curl-7_85_0"，数字之间使用下划线分隔
+//	config := versions.DefaultParserConfig()
+//	config.NumberDelimiters = []rune{'.', '_'}
+//	v := versions.NewVersionStringParserWithConfig("curl-7_85_0", config).Parse()
+//	fmt.Println(v.Prefix, v.VersionNumbers) // 输出: curl- [7 85 0]
+type ParserConfig struct {
+
+	// NumberDelimiters 数字部分中数字之间允许的分隔符，如 '.'、'_'、'/'、'-'，
+	// 连续的多个分隔符视为一个，如 "1.....1" 的数字部分为 [1, 1]
+	NumberDelimiters []rune
+
+	// PrefixPatterns 前缀的正则表达式，按顺序尝试，第一个匹配到版本号开头的表达式所匹配的部分一定属于前缀，
+	// 数字部分只会在这之后查找。例如默认规则中的 `^v\d+[0-9A-Za-z]*-rev\d+-` 能把 "v1-rev4-1.18.0-rc" 中的 "v1-rev4-" 作为前缀
+	PrefixPatterns []*regexp.Regexp
+
+	// MaxNumberSegments 数字部分最多包含几个数字，超出的部分归入后缀，为0时不限制
+	MaxNumberSegments int
+
+	// PreferLongestRun 版本号开头是单独的一个数字、后面紧跟着分隔符和另一串数字时（如 "17-0.15.0-alpha1"），
+	// 是否选择数字个数最多的一串作为数字部分，个数相同时选择靠前的一串；为 false 时总是选择第一串数字。
+	// 第一串数字有多个数字或者后面跟着字母时总是选择它，因为后面的数字属于预发布标签或者构建元数据，
+	// 如 "1.2.3-rc.1.2.3.4"。末尾的数字紧跟着字母时（如提交哈希 "346bb489" 中的 "346"）不计入个数
+	PreferLongestRun bool
+
+	// CandidateDelimiters 列出多种解读时额外尝试的分隔符，每次在 NumberDelimiters 的基础上加入其中一个，
+	// 见 VersionStringParser.ParseCandidates；Parse 只使用 NumberDelimiters，不受它影响
+	CandidateDelimiters []rune
+}
+
+// defaultParserConfig NewVersionStringParser 使用的默认规则，不会被修改
+var defaultParserConfig = DefaultParserConfig()
+
+// DefaultParserConfig 返回通用解析器默认的解析规则
+//
+// 默认只使用点号作为数字的分隔符，不限制数字的个数，开头单独的一个数字可以属于前缀，
+// 所以 "17-0.15.0-alpha1" 的数字部分为 [0, 15, 0]；Google API 客户端库形如 "v1-rev4-" 的前缀由 PrefixPatterns 识别，
+// "v1-rev4-1.18.0-rc" 的数字部分为 [1, 18, 0]；下划线、连字符不是数字的分隔符，"curl-7_85_0" 的数字部分为 [7]。
+//
+// 返回:
+//   - *ParserConfig: 新创建的规则，可以随意修改而不会影响其它解析器
+func DefaultParserConfig() *ParserConfig {
+	return &ParserConfig{
+		NumberDelimiters:    []rune{'.'},
+		PrefixPatterns:      []*regexp.Regexp{regexp.MustCompile(`^v\d+[0-9A-Za-z]*-rev\d+-`)},
+		MaxNumberSegments:   0,
+		PreferLongestRun:    true,
+		CandidateDelimiters: []rune{'_', '-', '/'},
+	}
+}
+
+// IsNumberDelimiter 判断字符是否是数字部分中数字之间的分隔符
+//
+// 参数:
+//   - c: 要判断的字符
+//
+// 返回:
+//   - bool: 是分隔符时返回 true
+func (x *ParserConfig) IsNumberDelimiter(c rune) bool {
+	for _, delimiter := range x.NumberDelimiters {
+		if c == delimiter {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPrefix 返回版本号开头被 PrefixPatterns 匹配的字节长度，没有匹配时返回0
+func (x *ParserConfig) matchPrefix(versionStr string) int {
+	for _, pattern := range x.PrefixPatterns {
+		if loc := pattern.FindStringIndex(versionStr); loc != nil && loc[0] == 0 && loc[1] > 0 {
+			return loc[1]
+		}
+	}
+	return 0
+}
@@ -0,0 +1,90 @@
+package versions
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestVersionStringParser_Config 测试使用自定义规则解析版本号
+func TestVersionStringParser_Config(t *testing.T) {
+	// 下划线分隔的数字
+	config := DefaultParserConfig()
+	config.NumberDelimiters = []rune{'.', '_'}
+	v := NewVersionStringParserWithConfig("curl-7_85_0", config).Parse()
+	assert.Equal(t, VersionPrefix("curl-"), v.Prefix)
+	assert.Equal(t, VersionNumbers([]int{7, 85, 0}), v.VersionNumbers)
+	assert.Equal(t, EmptyVersionSuffix, v.Suffix)
+
+	// 斜杠和连字符分隔的数字
+	config.NumberDelimiters = []rune{'/', '-'}
+	v = NewVersionStringParserWithConfig("release/2023-05-31.final", config).Parse()
+	assert.Equal(t, VersionPrefix("release/"), v.Prefix)
+	assert.Equal(t, VersionNumbers([]int{2023, 5, 31}), v.VersionNumbers)
+	assert.Equal(t, VersionSuffix(".final"), v.Suffix)
+
+	// 前缀的正则
+	config = DefaultParserConfig()
+	config.PrefixPatterns = []*regexp.Regexp{regexp.MustCompile(`^\d+-`)}
+	v = NewVersionStringParserWithConfig("17-0.15-alpha1", config).Parse()
+	assert.Equal(t, VersionPrefix("17-"), v.Prefix)
+	assert.Equal(t, VersionNumbers([]int{0, 15}), v.VersionNumbers)
+	assert.Equal(t, VersionSuffix("-alpha1"), v.Suffix)
+
+	// 前缀的正则吞掉了所有数字时忽略
+	config.PrefixPatterns = []*regexp.Regexp{regexp.MustCompile(`^.*`)}
+	v = NewVersionStringParserWithConfig("v1.2", config).Parse()
+	assert.Equal(t, VersionPrefix("v"), v.Prefix)
+	assert.Equal(t, VersionNumbers([]int{1, 2}), v.VersionNumbers)
+
+	// 限制数字的个数
+	config = DefaultParserConfig()
+	config.MaxNumberSegments = 3
+	v = NewVersionStringParserWithConfig("1.2.3.4-rc1", config).Parse()
+	assert.Equal(t, VersionNumbers([]int{1, 2, 3}), v.VersionNumbers)
+	assert.Equal(t, VersionSuffix(".4-rc1"), v.Suffix)
+
+	// 总是选择第一串数字
+	config = DefaultParserConfig()
+	config.PreferLongestRun = false
+	v = NewVersionStringParserWithConfig("17-0.15.0-alpha1", config).Parse()
+	assert.Equal(t, EmptyVersionPrefix, v.Prefix)
+	assert.Equal(t, VersionNumbers([]int{17}), v.VersionNumbers)
+	assert.Equal(t, VersionSuffix("-0.15.0-alpha1"), v.Suffix)
+
+	// nil 使用默认规则
+	v = NewVersionStringParserWithConfig("17-0.15.0-alpha1", nil).Parse()
+	assert.Equal(t, VersionNumbers([]int{0, 15, 0}), v.VersionNumbers)
+
+	// 数字最多的一串紧跟着字母时不计入个数
+	v = NewVersionStringParser("2.5.0-snapshot.20221107.10906.0.346bb489").Parse()
+	assert.Equal(t, VersionNumbers([]int{2, 5, 0}), v.VersionNumbers)
+}
+
+// TestDefaultParserConfig 测试默认规则以及每次返回的规则互不影响
+func TestDefaultParserConfig(t *testing.T) {
+	config := DefaultParserConfig()
+	assert.True(t, config.IsNumberDelimiter('.'))
+	assert.False(t, config.IsNumberDelimiter('_'))
+	assert.True(t, config.PreferLongestRun)
+	assert.Equal(t, 0, config.MaxNumberSegments)
+
+	config.NumberDelimiters = append(config.NumberDelimiters, '_')
+	assert.False(t, DefaultParserConfig().IsNumberDelimiter('_'))
+	assert.False(t, NewVersionStringParser("").IsVersionNumberDelimiter('_'))
+}
+
+// TestNewGenericScheme 测试注册使用自定义规则的通用方案
+func TestNewGenericScheme(t *testing.T) {
+	assert.Equal(t, SchemeGeneric, (&GenericScheme{}).Name())
+
+	config := DefaultParserConfig()
+	config.NumberDelimiters = []rune{'.', '_'}
+	RegisterScheme(NewGenericScheme("curl-tag", config))
+
+	v := MustParse("curl-7_85_0", "curl-tag")
+	assert.Equal(t, VersionNumbers([]int{7, 85, 0}), v.VersionNumbers)
+	assert.Equal(t, "curl-tag", v.Scheme.Name())
+	assert.Equal(t, -1, v.CompareTo(MustParse("curl-7_86_0", "curl-tag")))
+}
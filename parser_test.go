@@ -122,17 +122,15 @@ func TestVersionStringParser_Parser(t *testing.T) {
 	assert.Equal(t, VersionNumbers([]int{126}), v.VersionNumbers)
 	assert.Equal(t, EmptyVersionSuffix, v.Suffix)
 
-	// TODO 2023-5-31 11:25:49 此case未通过
-	//v = NewVersionStringParser("RELEASE120-1").Parse()
-	//assert.Equal(t, VersionPrefix("RELEASE"), v.Prefix)
-	//assert.Equal(t, VersionNumbers([]int{120}), v.VersionNumbers)
-	//assert.Equal(t, VersionSuffix("-1"), v.Suffix)
-
-	// TODO 2023-5-31 11:25:49 此case未通过
-	//v = NewVersionStringParser("RELEASE120-u1").Parse()
-	//assert.Equal(t, VersionPrefix("RELEASE"), v.Prefix)
-	//assert.Equal(t, VersionNumbers([]int{120}), v.VersionNumbers)
-	//assert.Equal(t, VersionSuffix("-u1"), v.Suffix)
+	v = NewVersionStringParser("RELEASE120-1").Parse()
+	assert.Equal(t, VersionPrefix("RELEASE"), v.Prefix)
+	assert.Equal(t, VersionNumbers([]int{120}), v.VersionNumbers)
+	assert.Equal(t, VersionSuffix("-1"), v.Suffix)
+
+	v = NewVersionStringParser("RELEASE120-u1").Parse()
+	assert.Equal(t, VersionPrefix("RELEASE"), v.Prefix)
+	assert.Equal(t, VersionNumbers([]int{120}), v.VersionNumbers)
+	assert.Equal(t, VersionSuffix("-u1"), v.Suffix)
 
 	v = NewVersionStringParser("0.26.1-v2-524.0").Parse()
 	assert.Equal(t, EmptyVersionPrefix, v.Prefix)
@@ -209,21 +207,59 @@ func TestVersionStringParser_Parser(t *testing.T) {
 	assert.Equal(t, VersionNumbers([]int{3, 9, 4}), v.VersionNumbers)
 	assert.Equal(t, VersionSuffix("-rc-1.0.2"), v.Suffix)
 
+	// 默认只有点号是分隔符，下划线分隔的数字需要通过 ParserConfig 配置，见 TestVersionStringParser_Config
 	v = NewVersionStringParser("curl-7_85_0").Parse()
-	assert.Equal(t, VersionPrefix("curl-7_85_"), v.Prefix)
-	assert.Equal(t, VersionNumbers([]int{0}), v.VersionNumbers)
-	assert.Equal(t, EmptyVersionSuffix, v.Suffix)
+	assert.Equal(t, VersionPrefix("curl-"), v.Prefix)
+	assert.Equal(t, VersionNumbers([]int{7}), v.VersionNumbers)
+	assert.Equal(t, VersionSuffix("_85_0"), v.Suffix)
+
+	v = NewVersionStringParser("curl-8_0").Parse()
+	assert.Equal(t, VersionNumbers([]int{8}), v.VersionNumbers)
+	assert.Equal(t, VersionSuffix("_0"), v.Suffix)
+
+	// 连字符也不是默认的分隔符，不管连接了几个数字
+	v = NewVersionStringParser("1-2-3").Parse()
+	assert.Equal(t, VersionNumbers([]int{1}), v.VersionNumbers)
+	assert.Equal(t, VersionSuffix("-2-3"), v.Suffix)
+
+	v = NewVersionStringParser("2023-05-31").Parse()
+	assert.Equal(t, VersionNumbers([]int{2023}), v.VersionNumbers)
+	assert.Equal(t, VersionSuffix("-05-31"), v.Suffix)
+
+	// 第一串数字有多个数字时，预发布标签和构建元数据中更长的数字串不会被当成数字部分
+	v = NewVersionStringParser("1.2.3+build.4.5.6.7").Parse()
+	assert.Equal(t, EmptyVersionPrefix, v.Prefix)
+	assert.Equal(t, VersionNumbers([]int{1, 2, 3}), v.VersionNumbers)
+	assert.Equal(t, VersionSuffix("+build.4.5.6.7"), v.Suffix)
+
+	v = NewVersionStringParser("v1.2-rc.3.4.5").Parse()
+	assert.Equal(t, VersionPrefix("v"), v.Prefix)
+	assert.Equal(t, VersionNumbers([]int{1, 2}), v.VersionNumbers)
+	assert.Equal(t, VersionSuffix("-rc.3.4.5"), v.Suffix)
+
+	v = NewVersionStringParser("1.2.3-rc.1.2.3.4").Parse()
+	assert.Equal(t, EmptyVersionPrefix, v.Prefix)
+	assert.Equal(t, VersionNumbers([]int{1, 2, 3}), v.VersionNumbers)
+	assert.Equal(t, VersionSuffix("-rc.1.2.3.4"), v.Suffix)
+
+	v = NewVersionStringParser("1.0-snapshot.20201012.5405.0.af92198d").Parse()
+	assert.Equal(t, EmptyVersionPrefix, v.Prefix)
+	assert.Equal(t, VersionNumbers([]int{1, 0}), v.VersionNumbers)
+	assert.Equal(t, VersionSuffix("-snapshot.20201012.5405.0.af92198d"), v.Suffix)
+
+	v = NewVersionStringParser("1-rc.1.2.3").Parse()
+	assert.Equal(t, VersionNumbers([]int{1}), v.VersionNumbers)
+	assert.Equal(t, VersionSuffix("-rc.1.2.3"), v.Suffix)
 
 	v = NewVersionStringParser("2010.12").Parse()
 	assert.Equal(t, EmptyVersionPrefix, v.Prefix)
 	assert.Equal(t, VersionNumbers([]int{2010, 12}), v.VersionNumbers)
 	assert.Equal(t, EmptyVersionSuffix, v.Suffix)
 
-	// TODO
-	//v = NewVersionStringParser("v1beta1-rev20191118-1.29.2").Parse()
-	//assert.Equal(t, VersionPrefix("COMMIT-2fef389"), v.Prefix)
-	//assert.Equal(t, VersionNumbers([]int{1, 18, 0}), v.VersionNumbers)
-	//assert.Equal(t, VersionSuffix("-rc"), v.Suffix)
+	v = NewVersionStringParser("v1beta1-rev20191118-1.29.2").Parse()
+	assert.Equal(t, VersionPrefix("v1beta1-rev20191118-"), v.Prefix)
+	assert.Equal(t, VersionNumbers([]int{1, 29, 2}), v.VersionNumbers)
+	assert.Equal(t, EmptyVersionSuffix, v.Suffix)
 
 	// TODO sha1检查
 	//v = NewVersionStringParser("18699aad7ce6e60980f876a27145b5b29e9fd55d").Parse()
@@ -266,24 +302,24 @@ func TestVersionStringParser_Parser(t *testing.T) {
 
 // TestVersionStringParser_ReadVersionSuffix 测试版本后缀解析功能
 func TestVersionStringParser_ReadVersionSuffix(t *testing.T) {
-	// 创建一个解析器实例
-	parser := &VersionStringParser{}
+	readSuffix := func(versionStr string) string {
+		parser := NewVersionStringParser(versionStr)
+		parser.readVersionPrefix()
+		parser.readVersionNumbers()
+		return parser.readVersionSuffix()
+	}
 
 	// 测试正常情况
-	suffix := parser.readVersionSuffix("1.2.3-beta", "1.2.3")
-	assert.Equal(t, "-beta", suffix)
+	assert.Equal(t, "-beta", readSuffix("1.2.3-beta"))
 
 	// 测试版本号数字部分为空的情况
-	suffix = parser.readVersionSuffix("abc", "")
-	assert.Equal(t, "", suffix)
+	assert.Equal(t, "", readSuffix("abc"))
 
 	// 测试版本号后无后缀的情况
-	suffix = parser.readVersionSuffix("1.2.3", "1.2.3")
-	assert.Equal(t, "", suffix)
+	assert.Equal(t, "", readSuffix("1.2.3"))
 
 	// 测试复杂后缀
-	suffix = parser.readVersionSuffix("1.2.3-beta.1-rc2", "1.2.3")
-	assert.Equal(t, "-beta.1-rc2", suffix)
+	assert.Equal(t, "-beta.1-rc2", readSuffix("1.2.3-beta.1-rc2"))
 }
 
 // TestPrefixedVersionParsing 测试带前缀的版本解析
@@ -0,0 +1,239 @@
+package versions
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+var (
+	// ErrSchemeNotFound 表示指定名称的版本方案未注册的错误
+	//
+	// 当调用 Parse 或 LookupScheme 时传入了未注册的方案名称时返回此错误
+	ErrSchemeNotFound = errors.New("version scheme not found")
+)
+
+// Scheme 表示一种版本号方案（versioning scheme）
+//
+// 不同的生态对版本号的格式和排序规则有各自的约定，比如 SemVer、Maven、PEP 440 等，
+// Scheme 把这些约定抽象为统一的接口：解析、校验、比较以及输出规范化的字符串表示。
+// 通过某个 Scheme 解析出来的 Version 会记住自己所属的 Scheme，当两个版本属于同一个
+// Scheme 时，Version.CompareTo 会委托给该 Scheme 进行比较，因此 Group、SortVersionSlice、
+// SortedVersionGroups 等功能对任意方案的版本都能正常工作。
+//
+// 分组和排序时先按纪元和版本号的数字部分（见 GroupingScheme）排列组，再在组内用 Compare 排序，
+// SortedVersionGroups 还会在按组拼接起来的版本上二分查找。所以方案必须保证组的顺序与 Compare 的顺序一致：
+// 两个版本所在的组不同时，组小的版本用 Compare 比较也必须更小。Compare 与数字部分的顺序不一致的方案
+// 需要实现 GroupingScheme 给出合适的分组依据。
+//
+// 使用示例:
+//
+//	// 使用指定的方案解析版本号
+//	v, err := versions.Parse("1.2.3", versions.SchemeGeneric)
+//	if err != nil {
+//	    log.Fatalf("解析版本号失败: %v", err)
+//	}
+//	fmt.Println(v.Canonical())
+type Scheme interface {
+
+	// Name 返回方案的名称，名称在注册表中唯一
+	Name() string
+
+	// Parse 按照本方案的规则把字符串解析为 Version，返回的 Version 的 Scheme 字段指向本方案
+	Parse(versionStr string) (*Version, error)
+
+	// Validate 校验字符串是否是本方案下合法的版本号，合法时返回 nil
+	Validate(versionStr string) error
+
+	// Compare 按照本方案的规则比较两个版本，小于返回负数，等于返回0，大于返回正数
+	Compare(a, b *Version) int
+
+	// Canonical 返回版本在本方案下的规范化字符串表示
+	Canonical(v *Version) string
+}
+
+// GroupingScheme 可以自定义分组方式的版本号方案
+//
+// 默认情况下 Group 和 SortedVersionGroups 按照版本号的数字部分分组，实现了本接口的方案
+// 可以给出别的分组依据，比如 CalVer 方案可以按年份或者年月分组。
+//
+// 分组数字必须与 Compare 的顺序一致：纪元相同时，若 GroupNumbers(a) 小于 GroupNumbers(b)，
+// 则 Compare(a, b) 必须小于0，否则 SortVersionSlice 排出来的顺序和 SortedVersionGroups 的查询结果都会出错。
+type GroupingScheme interface {
+	Scheme
+
+	// GroupNumbers 返回版本用于分组的数字，数字相同的版本会被分到同一个组，组的顺序必须与 Compare 的顺序一致
+	GroupNumbers(v *Version) VersionNumbers
+}
+
+// EpochSeparatorScheme 纪元不是用冒号分隔的版本号方案
+//
+// 默认情况下 Version.Format 和 Bump 等操作生成的版本号中纪元后面跟着冒号（如 "1:2.0"），
+// 实现了本接口的方案使用自己的分隔符，比如 PEP 440 的 "1!2.0"。
+type EpochSeparatorScheme interface {
+	Scheme
+
+	// EpochSeparator 返回纪元和版本号之间的分隔符
+	EpochSeparator() string
+}
+
+// PrereleaseScheme 预发布版本不是用 "-rc.1" 形式的后缀表示的版本号方案
+//
+// 默认情况下 Version.NextPrerelease 等操作在数字部分后面加上 "-rc.1" 形式的后缀开始一个预发布，
+// 实现了本接口的方案使用自己的写法，比如 Debian 的 "1.0~rc1"、PEP 440 的 "1.0rc1"。
+// 生成的版本号仍然会用 Compare 检查是否排在对应的正式版本之前，不是时返回 ErrPrereleaseUnsupported。
+type PrereleaseScheme interface {
+	Scheme
+
+	// PrereleaseSuffix 返回以 identifier 为限定词的第一个预发布版本的后缀，限定词之后的部分是序号1的写法，如 "~rc1"
+	PrereleaseSuffix(identifier string) VersionSuffix
+}
+
+// schemeRegistry 版本号方案的注册表，键为方案名称
+var (
+	schemeRegistryLock sync.RWMutex
+	schemeRegistry     = make(map[string]Scheme)
+)
+
+// RegisterScheme 注册一个版本号方案
+//
+// 如果已经存在同名的方案，则会被新的方案覆盖，这样调用方可以替换内置方案的实现。
+//
+// 参数:
+//   - scheme: 要注册的版本号方案
+//
+// 使用示例:
+//
+//	versions.RegisterScheme(myScheme)
+//	v, err := versions.Parse("r42", myScheme.Name())
+func RegisterScheme(scheme Scheme) {
+	schemeRegistryLock.Lock()
+	defer schemeRegistryLock.Unlock()
+	schemeRegistry[scheme.Name()] = scheme
+}
+
+// LookupScheme 根据名称查找已注册的版本号方案
+//
+// 参数:
+//   - name: 方案名称，如 SchemeGeneric
+//
+// 返回:
+//   - Scheme: 找到的方案
+//   - bool: 是否找到
+//
+// 使用示例:
+//
+//	scheme, ok := versions.LookupScheme(versions.SchemeGeneric)
+//	if ok {
+//	    fmt.Println(scheme.Name())
+//	}
+func LookupScheme(name string) (Scheme, bool) {
+	schemeRegistryLock.RLock()
+	defer schemeRegistryLock.RUnlock()
+	scheme, ok := schemeRegistry[name]
+	return scheme, ok
+}
+
+// SchemeNames 返回所有已注册方案的名称，按字典序排列
+//
+// 返回:
+//   - []string: 已注册方案的名称列表
+func SchemeNames() []string {
+	schemeRegistryLock.RLock()
+	defer schemeRegistryLock.RUnlock()
+	names := make([]string, 0, len(schemeRegistry))
+	for name := range schemeRegistry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Parse 使用指定名称的版本号方案解析版本字符串
+//
+// 参数:
+//   - versionStr: 要解析的版本号字符串
+//   - schemeName: 版本号方案的名称，如 SchemeGeneric
+//
+// 返回:
+//   - *Version: 解析后的版本对象
+//   - error: 方案未注册时返回 ErrSchemeNotFound，版本号不合法时返回方案给出的错误
+//
+// 使用示例:
+//
+//	v, err := versions.Parse("v1.2.3-rc1", versions.SchemeGeneric)
+//	if err != nil {
+//	    log.Fatalf("解析版本号失败: %v", err)
+//	}
+func Parse(versionStr string, schemeName string) (*Version, error) {
+	scheme, ok := LookupScheme(schemeName)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrSchemeNotFound, schemeName)
+	}
+	return scheme.Parse(versionStr)
+}
+
+// MustParse 与 Parse 相同，但是在解析失败时会 panic，适合用于常量版本号或测试代码
+//
+// 参数:
+//   - versionStr: 要解析的版本号字符串
+//   - schemeName: 版本号方案的名称
+//
+// 返回:
+//   - *Version: 解析后的版本对象
+func MustParse(versionStr string, schemeName string) *Version {
+	v, err := Parse(versionStr, schemeName)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// ParseAll 使用指定名称的版本号方案批量解析版本字符串
+//
+// 参数:
+//   - schemeName: 版本号方案的名称
+//   - versionStringSlice: 一个或多个版本号字符串
+//
+// 返回:
+//   - []*Version: 解析后的版本对象数组
+//   - error: 遇到第一个解析失败的版本号时返回对应的错误
+func ParseAll(schemeName string, versionStringSlice ...string) ([]*Version, error) {
+	versions := make([]*Version, 0, len(versionStringSlice))
+	for _, versionStr := range versionStringSlice {
+		v, err := Parse(versionStr, schemeName)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, v)
+	}
+	return versions, nil
+}
+
+// sharedScheme 如果两个版本属于同一个方案则返回该方案，否则返回 nil
+func sharedScheme(a, b *Version) Scheme {
+	if a.Scheme == nil || b.Scheme == nil {
+		return nil
+	}
+	if a.Scheme.Name() != b.Scheme.Name() {
+		return nil
+	}
+	return a.Scheme
+}
+
+// epochSeparatorOf 返回版本的纪元后面的分隔符，方案没有自定义分隔符时为冒号
+func epochSeparatorOf(v *Version) string {
+	if scheme, ok := v.Scheme.(EpochSeparatorScheme); ok {
+		return scheme.EpochSeparator()
+	}
+	return ":"
+}
+
+// groupNumbersOf 返回版本用于分组的数字，方案没有自定义分组方式时为版本号的数字部分
+func groupNumbersOf(v *Version) VersionNumbers {
+	if scheme, ok := v.Scheme.(GroupingScheme); ok {
+		return scheme.GroupNumbers(v)
+	}
+	return v.VersionNumbers
+}
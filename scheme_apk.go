@@ -0,0 +1,298 @@
+package versions
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SchemeAPK Alpine Linux 软件包（apk）版本号方案的名称
+//
+// 校验和比较规则与 apk-tools 的 apk_version_validate、apk_version_compare 保持一致
+const SchemeAPK = "apk"
+
+// apk 版本号中的标记类型，顺序与 apk-tools 的 version.c 一致，类型之间的大小关系会参与比较
+const (
+	apkTokenInvalid = iota - 1
+	apkTokenDigitOrZero
+	apkTokenDigit
+	apkTokenLetter
+	apkTokenSuffix
+	apkTokenSuffixNo
+	apkTokenRevisionNo
+	apkTokenEnd
+)
+
+var (
+	// apkPreSuffixes 预发布后缀，排在没有后缀的版本之前，越靠前越小
+	apkPreSuffixes = []string{"alpha", "beta", "pre", "rc"}
+
+	// apkPostSuffixes 发布后的后缀，排在没有后缀的版本之后
+	apkPostSuffixes = []string{"cvs", "svn", "git", "hg", "p"}
+)
+
+// apkTokenizer 逐个读取 apk 版本号中的标记
+type apkTokenizer struct {
+	s         string
+	tokenType int
+}
+
+// next 读取下一个标记的值，读取之后 tokenType 为下一个标记的类型，与 apk-tools 的 get_token 一致
+func (x *apkTokenizer) next() int {
+	if len(x.s) == 0 {
+		x.tokenType = apkTokenEnd
+		return 0
+	}
+
+	v, i, nextType := 0, 0, apkTokenInvalid
+	switch x.tokenType {
+	case apkTokenDigitOrZero, apkTokenDigit, apkTokenSuffixNo, apkTokenRevisionNo:
+		// 点号后面的零单独作为一个标记，值为零的个数取负，所以 "1.001" < "1.0" < "1.01" < "1.1"
+		if x.tokenType == apkTokenDigitOrZero && x.s[0] == '0' {
+			for i < len(x.s) && x.s[i] == '0' {
+				i++
+			}
+			v = -i
+			nextType = apkTokenDigit
+			break
+		}
+		for i < len(x.s) && isASCIIDigit(x.s[i]) {
+			v = v*10 + int(x.s[i]-'0')
+			i++
+		}
+	case apkTokenLetter:
+		v = int(x.s[0])
+		i = 1
+	case apkTokenSuffix:
+		found := false
+		for k, suffix := range apkPreSuffixes {
+			if strings.HasPrefix(x.s, suffix) {
+				v, i, found = k-len(apkPreSuffixes), len(suffix), true
+				break
+			}
+		}
+		if !found {
+			for k, suffix := range apkPostSuffixes {
+				if strings.HasPrefix(x.s, suffix) {
+					v, i, found = k, len(suffix), true
+					break
+				}
+			}
+		}
+		if !found {
+			x.tokenType = apkTokenInvalid
+			return -1
+		}
+	default:
+		x.tokenType = apkTokenInvalid
+		return -1
+	}
+
+	x.s = x.s[i:]
+	switch {
+	case len(x.s) == 0:
+		x.tokenType = apkTokenEnd
+	case nextType != apkTokenInvalid && isASCIIDigit(x.s[0]):
+		x.tokenType = nextType
+	default:
+		x.nextType()
+	}
+	return v
+}
+
+// nextType 根据接下来的字符判断下一个标记的类型，与 apk-tools 的 next_token 一致
+func (x *apkTokenizer) nextType() {
+	n := apkTokenInvalid
+	c := x.s[0]
+	switch {
+	case (x.tokenType == apkTokenDigit || x.tokenType == apkTokenDigitOrZero) && c >= 'a' && c <= 'z':
+		n = apkTokenLetter
+	case x.tokenType == apkTokenLetter && isASCIIDigit(c):
+		n = apkTokenDigit
+	case x.tokenType == apkTokenSuffix && isASCIIDigit(c):
+		n = apkTokenSuffixNo
+	default:
+		switch c {
+		case '.':
+			n = apkTokenDigitOrZero
+		case '_':
+			n = apkTokenSuffix
+		case '-':
+			if len(x.s) > 1 && x.s[1] == 'r' {
+				n = apkTokenRevisionNo
+				x.s = x.s[1:]
+			}
+		}
+		x.s = x.s[1:]
+	}
+
+	// 标记只能按照 数字、字母、后缀、修订号 的顺序出现
+	if n < x.tokenType {
+		if !((n == apkTokenDigitOrZero && x.tokenType == apkTokenDigit) ||
+			(n == apkTokenSuffix && x.tokenType == apkTokenSuffixNo) ||
+			(n == apkTokenDigit && x.tokenType == apkTokenLetter)) {
+			n = apkTokenInvalid
+		}
+	}
+	x.tokenType = n
+}
+
+// ValidateAPKVersion 校验是否是合法的 apk 版本号
+//
+// apk 的版本号格式为 数字{.数字}...{字母}{_后缀{数字}}...{-r数字}，例如 "1.2.3_rc1-r0"，
+// 后缀只能是 alpha、beta、pre、rc、cvs、svn、git、hg、p 之一。
+//
+// 参数:
+//   - versionStr: 要校验的版本号字符串
+//
+// 返回:
+//   - error: 版本号不合法时返回包装了 ErrVersionInvalid 的错误
+func ValidateAPKVersion(versionStr string) error {
+	if versionStr == "" || !isASCIIDigit(versionStr[0]) {
+		return fmt.Errorf("%w: %q does not start with digit", ErrVersionInvalid, versionStr)
+	}
+	t := &apkTokenizer{s: versionStr, tokenType: apkTokenDigit}
+	for t.tokenType != apkTokenEnd && t.tokenType != apkTokenInvalid {
+		t.next()
+	}
+	if t.tokenType == apkTokenInvalid {
+		return fmt.Errorf("%w: %q is not a valid apk version", ErrVersionInvalid, versionStr)
+	}
+	return nil
+}
+
+// APKVerCmp apk-tools 的版本比较算法，比较两个 apk 版本号
+//
+// 依次比较每个标记的值，值相同但是其中一个版本号已经结束时：
+// - 剩下的是预发布后缀（_alpha、_beta、_pre、_rc）的版本更小，如 "1.0_rc1" < "1.0"
+// - 否则剩下的部分越"靠前"越大，如 "1.0" < "1.0-r1" < "1.0_p1" < "1.0a" < "1.0.1"
+//
+// 参数:
+//   - a: 第一个版本号
+//   - b: 第二个版本号
+//
+// 返回:
+//   - int: a 小于 b 返回-1，相等返回0，大于返回1
+func APKVerCmp(a, b string) int {
+	ta := &apkTokenizer{s: a, tokenType: apkTokenDigit}
+	tb := &apkTokenizer{s: b, tokenType: apkTokenDigit}
+	av, bv := 0, 0
+	for ta.tokenType == tb.tokenType && ta.tokenType != apkTokenEnd && ta.tokenType != apkTokenInvalid && av == bv {
+		av = ta.next()
+		bv = tb.next()
+	}
+	if r := compareInt(av, bv); r != 0 {
+		return r
+	}
+	if ta.tokenType == tb.tokenType {
+		return 0
+	}
+
+	// 前面的部分都相同，没有结束的版本号更大，除非剩下的是预发布后缀
+	if ta.tokenType == apkTokenSuffix {
+		rest := *ta
+		if rest.next() < 0 {
+			return -1
+		}
+	}
+	if tb.tokenType == apkTokenSuffix {
+		rest := *tb
+		if rest.next() < 0 {
+			return 1
+		}
+	}
+	return compareInt(tb.tokenType, ta.tokenType)
+}
+
+// APKScheme Alpine Linux 软件包版本号方案
+//
+// 解析得到的 Version 中，VersionNumbers 为版本号开头以点号分隔的数字，Suffix 为剩下的部分，
+// 例如 "1.2.3_rc1-r0" 的 VersionNumbers 为 [1,2,3]，Suffix 为 "_rc1-r0"。
+//
+// 使用示例:
+//
+//	v1 := versions.MustParse("1.2.3_rc1-r0", versions.SchemeAPK)
+//	v2 := versions.MustParse("1.2.3-r0", versions.SchemeAPK)
+//	fmt.Println(v1.CompareTo(v2)) // 输出: -1
+type APKScheme struct {
+}
+
+var _ PrereleaseScheme = &APKScheme{}
+
+func init() {
+	RegisterScheme(&APKScheme{})
+}
+
+// Name 返回方案名称 SchemeAPK
+func (x *APKScheme) Name() string {
+	return SchemeAPK
+}
+
+// Parse 校验并解析 apk 版本号
+func (x *APKScheme) Parse(versionStr string) (*Version, error) {
+	s := strings.TrimSpace(versionStr)
+	if err := ValidateAPKVersion(s); err != nil {
+		return nil, err
+	}
+	numbers, rest := splitAPKLeadingNumbers(s)
+	return &Version{
+		Raw:            s,
+		VersionNumbers: numbers,
+		NumbersRaw:     s[:len(s)-len(rest)],
+		Prefix:         EmptyVersionPrefix,
+		Suffix:         VersionSuffix(rest),
+		Scheme:         x,
+	}, nil
+}
+
+// Validate 校验是否是合法的 apk 版本号
+func (x *APKScheme) Validate(versionStr string) error {
+	return ValidateAPKVersion(strings.TrimSpace(versionStr))
+}
+
+// Compare 按照 apk-tools 的规则比较两个版本
+func (x *APKScheme) Compare(a, b *Version) int {
+	return APKVerCmp(a.Raw, b.Raw)
+}
+
+// Canonical apk 版本号没有别的写法，返回原始的版本号
+func (x *APKScheme) Canonical(v *Version) string {
+	return v.Raw
+}
+
+// PrereleaseSuffix 返回 "_" 开头的预发布后缀，如 "_rc1"，只有 alpha、beta、pre、rc 四种后缀排在正式版本之前
+func (x *APKScheme) PrereleaseSuffix(identifier string) VersionSuffix {
+	return VersionSuffix("_" + identifier + "1")
+}
+
+// splitAPKLeadingNumbers 切分出版本号开头以点号分隔的数字
+//
+// 点号之后带前导零的数字段（如 "1.01" 中的 "01"）在 apk 中排在 "1.0" 和 "1.0.0" 之间，
+// 所以只记为0并且停止切分，保证分组的顺序与 APKVerCmp 一致。
+func splitAPKLeadingNumbers(s string) (VersionNumbers, string) {
+	numbers := make([]int, 0)
+	i := 0
+	for i < len(s) {
+		j := i
+		for j < len(s) && isASCIIDigit(s[j]) {
+			j++
+		}
+		if j == i {
+			break
+		}
+		n, err := strconv.Atoi(s[i:j])
+		if err != nil {
+			break
+		}
+		if len(numbers) > 0 && s[i] == '0' && j-i > 1 {
+			return append(numbers, 0), s[i:]
+		}
+		numbers = append(numbers, n)
+		i = j
+		if i+1 >= len(s) || s[i] != '.' || !isASCIIDigit(s[i+1]) {
+			break
+		}
+		i++
+	}
+	return numbers, s[i:]
+}
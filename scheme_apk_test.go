@@ -0,0 +1,121 @@
+package versions
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang-infrastructure/go-shuffle"
+	"github.com/stretchr/testify/assert"
+)
+
+// apkVerCmpCases 参考 apk-tools 的 test/version.data 整理的测试表
+var apkVerCmpCases = []struct {
+	a, b     string
+	expected int
+}{
+	{"2.34", "0.1.0_alpha", 1},
+	{"23_foo", "4_beta", 1},
+	{"1.0", "1.0", 0},
+	{"1.0", "1.0.0", -1},
+	{"1.0.1", "1.0", 1},
+	{"1.0.1", "1.0.10", -1},
+	{"0.1.0_alpha", "0.1.0_alpha", 0},
+	{"0.1.0_alpha", "0.1.3_alpha", -1},
+	{"0.1.0_alpha2", "0.1.0_alpha", 1},
+	{"0.1.0_alpha", "0.1.0_beta", -1},
+	{"0.1.0_beta", "0.1.0_pre", -1},
+	{"0.1.0_pre", "0.1.0_rc", -1},
+	{"0.1.0_rc", "0.1.0", -1},
+	{"0.1.0_rc1", "0.1.0_rc2", -1},
+	{"0.1.0", "0.1.0_cvs", -1},
+	{"0.1.0_cvs", "0.1.0_svn", -1},
+	{"0.1.0_svn", "0.1.0_git", -1},
+	{"0.1.0_git", "0.1.0_hg", -1},
+	{"0.1.0_hg", "0.1.0_p", -1},
+	{"0.1.0_p1", "0.1.0_p2", -1},
+	{"0.1.0_p1", "0.1.1", -1},
+	{"1.2.3_rc1-r0", "1.2.3-r0", -1},
+	{"1.2.3-r0", "1.2.3", 1},
+	{"1.2.3-r1", "1.2.3-r0", 1},
+	{"1.2.3-r10", "1.2.3-r9", 1},
+	{"1.2.3_p1-r0", "1.2.3-r5", 1},
+	{"2.2.39-r1", "1.0.4-r3", 1},
+	{"1.0a", "1.0", 1},
+	{"1.0a", "1.0b", -1},
+	{"1.0a", "1.0.1", -1},
+	{"1.0z", "1.0_p1", 1},
+	{"1.01", "1.1", -1},
+	{"1.01", "1.0", 1},
+	{"1.01", "1.0.0", -1},
+	{"1.001", "1.01", -1},
+	{"1.001", "1.0", -1},
+	{"01.2", "1.2", 0},
+	{"1.2_alpha_pre", "1.2_alpha", -1},
+	{"1.2_alpha_pre", "1.2_alpha1", -1},
+	{"6.0_pre1", "6.0", -1},
+	{"6.1_pre1", "6.0", 1},
+}
+
+// TestAPKVerCmp 测试 apk-tools 的版本比较算法
+func TestAPKVerCmp(t *testing.T) {
+	for _, c := range apkVerCmpCases {
+		assert.Equal(t, c.expected, APKVerCmp(c.a, c.b), "%s vs %s", c.a, c.b)
+		assert.Equal(t, -c.expected, APKVerCmp(c.b, c.a), "%s vs %s", c.b, c.a)
+	}
+}
+
+// TestValidateAPKVersion 测试 apk 版本号的校验
+func TestValidateAPKVersion(t *testing.T) {
+	for _, valid := range []string{"1", "1.2.3", "1.2.3a", "1.2.3_rc1", "1.2.3_rc1_p2", "1.2.3-r0", "1.2.3_git20230101-r12", "1.01"} {
+		assert.Nil(t, ValidateAPKVersion(valid), valid)
+	}
+	for _, invalid := range []string{"", "a1", "1.2.3-1", "1.2.3_foo", "1.2.3-r1a", "1.2ab", "1.2.3-r1_p1", "1.2_rc1.3"} {
+		assert.True(t, errors.Is(ValidateAPKVersion(invalid), ErrVersionInvalid), invalid)
+	}
+}
+
+// TestAPKScheme 测试 apk 方案与 Version、分组和排序的集成
+func TestAPKScheme(t *testing.T) {
+	v, err := Parse("1.2.3_rc1-r0", SchemeAPK)
+	assert.Nil(t, err)
+	assert.Equal(t, SchemeAPK, v.SchemeName())
+	assert.Equal(t, VersionNumbers{1, 2, 3}, v.VersionNumbers)
+	assert.Equal(t, VersionSuffix("_rc1-r0"), v.Suffix)
+	assert.Equal(t, "1.2.3_rc1-r0", v.Canonical())
+
+	_, err = Parse("1.2.3-1", SchemeAPK)
+	assert.True(t, errors.Is(err, ErrVersionInvalid))
+
+	ordered := []string{
+		"1.001",
+		"1.0_rc1",
+		"1.0",
+		"1.0-r1",
+		"1.0_p1",
+		"1.0a",
+		"1.01",
+		"1.0.0",
+		"1.0.1_alpha",
+		"1.0.1",
+		"1.1",
+		"1.10",
+		"2_beta",
+	}
+	apkVersions, err := ParseAll(SchemeAPK, ordered...)
+	assert.Nil(t, err)
+	for i := 0; i < 10; i++ {
+		shuffle.Shuffle(apkVersions)
+		assert.Equal(t, ordered, versionRaws(SortVersionSlice(apkVersions)))
+	}
+}
+
+// TestSplitAPKLeadingNumbers 测试切分开头的数字段，带前导零的数字段之后停止切分
+func TestSplitAPKLeadingNumbers(t *testing.T) {
+	numbers, rest := splitAPKLeadingNumbers("1.2.3_rc1-r0")
+	assert.Equal(t, VersionNumbers{1, 2, 3}, numbers)
+	assert.Equal(t, "_rc1-r0", rest)
+
+	numbers, rest = splitAPKLeadingNumbers("1.0.05.2")
+	assert.Equal(t, VersionNumbers{1, 0, 0}, numbers)
+	assert.Equal(t, "05.2", rest)
+}
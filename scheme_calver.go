@@ -0,0 +1,611 @@
+package versions
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SchemeCalVer 日历版本号（https://calver.org/）方案的名称
+//
+// 注册的默认方案会依次尝试 DefaultCalVerLayouts 返回的布局，并且按年月分组，
+// 需要固定布局或者别的分组方式时可以用 NewCalVerScheme 创建并注册自己的方案。
+const SchemeCalVer = "calver"
+
+var (
+	// ErrCalVerLayoutInvalid 表示 CalVer 布局格式无效的错误
+	//
+	// 当布局中出现不认识的段、重复的段，或者缺少年份段时返回此错误
+	ErrCalVerLayoutInvalid = errors.New("calver layout invalid")
+)
+
+// defaultCalVerLayouts 默认 CalVer 方案依次尝试的布局，见 DefaultCalVerLayouts
+var defaultCalVerLayouts = []string{
+	"YYYY.MM.DD.MICRO",
+	"YYYY.MM.DD",
+	"YYYY.MM.MICRO",
+	"YYYY.MM",
+	"YYYY.MICRO",
+	"YYYY",
+}
+
+// DefaultCalVerLayouts 返回默认 CalVer 方案依次尝试的布局，排在前面的布局优先匹配，返回的是副本，修改它不会影响默认方案
+//
+// 注意 "2024.1.3" 这样的版本号既可以理解为 YYYY.MM.DD 也可以理解为 YYYY.MM.MICRO，
+// 默认方案会优先把它当成日期，需要不同的理解时请使用固定布局的方案。
+// 只有版本号的形状（段数、每段的位数）与布局不符时才会尝试下一个布局，形状相符但日期不合法的版本号
+// （如 "2024.2.30"、"2024.13"）会被拒绝，而不是换一个布局重新理解；"2024.1.100" 这样日期段位数不符的才会按 YYYY.MM.MICRO 理解。
+//
+// 默认布局的年份都是四位数，不包含 "YY.MM" 这样的短年份布局，否则 "1.2.3" 这样的普通版本号也会被当成2001年的版本，
+// 需要解析 "22.04" 这样的短年份版本号时请用 NewCalVerScheme 创建使用短年份布局的方案。
+func DefaultCalVerLayouts() []string {
+	return append([]string{}, defaultCalVerLayouts...)
+}
+
+// CalVer 布局中支持的段，含义与 calver.org 一致
+const (
+	calVerSegmentFullYear   = "YYYY"  // 完整年份，如 2006、2016
+	calVerSegmentShortYear  = "YY"    // 2000年之后的年数，最多两位，如 6、16
+	calVerSegmentPaddedYear = "0Y"    // 补零的两位短年份，如 06、16
+	calVerSegmentMonth      = "MM"    // 月份，如 1、11，也接受补零的写法
+	calVerSegmentPaddedMon  = "0M"    // 补零的月份，如 01、11
+	calVerSegmentWeek       = "WW"    // 一年中的第几周，如 1、33，也接受补零的写法
+	calVerSegmentPaddedWeek = "0W"    // 补零的周数，如 01、33
+	calVerSegmentDay        = "DD"    // 日期，如 1、9、31，也接受补零的写法
+	calVerSegmentPaddedDay  = "0D"    // 补零的日期，如 01、09、31
+	calVerSegmentMajor      = "MAJOR" // 主版本号
+	calVerSegmentMinor      = "MINOR" // 次版本号
+	calVerSegmentMicro      = "MICRO" // 修订号
+)
+
+// calVerSegmentKinds 段到它所属种类的映射，同一种类的段在一个布局中只能出现一次
+var calVerSegmentKinds = map[string]string{
+	calVerSegmentFullYear:   "year",
+	calVerSegmentShortYear:  "year",
+	calVerSegmentPaddedYear: "year",
+	calVerSegmentMonth:      "month",
+	calVerSegmentPaddedMon:  "month",
+	calVerSegmentWeek:       "month",
+	calVerSegmentPaddedWeek: "month",
+	calVerSegmentDay:        "day",
+	calVerSegmentPaddedDay:  "day",
+	calVerSegmentMajor:      calVerSegmentMajor,
+	calVerSegmentMinor:      calVerSegmentMinor,
+	calVerSegmentMicro:      calVerSegmentMicro,
+}
+
+// calVerRegexp 把 CalVer 版本号拆成点号分隔的数字部分和修饰部分
+var calVerRegexp = regexp.MustCompile(`^(\d+(?:\.\d+)*)(.*)$`)
+
+// CalVerLayout 表示一个 CalVer 布局，如 "YYYY.0M.0D"、"YY.0M.MICRO"
+//
+// 布局由点号分隔的段组成，必须包含一个年份段，日期段必须和月份段一起出现，
+// 版本号中数字部分之后的内容（如 "-beta"）作为修饰部分，不需要在布局中写出。
+type CalVerLayout struct {
+	raw      string
+	segments []string
+}
+
+// ParseCalVerLayout 解析 CalVer 布局
+//
+// 参数:
+//   - layout: 布局字符串，如 "YYYY.MM.DD"
+//
+// 返回:
+//   - *CalVerLayout: 解析后的布局
+//   - error: 布局无效时返回包装了 ErrCalVerLayoutInvalid 的错误
+//
+// 使用示例:
+//
+//	layout, err := versions.ParseCalVerLayout("YY.0M.MICRO")
+//	if err != nil {
+//	    log.Fatalf("不是合法的CalVer布局: %v", err)
+//	}
+//	cv, _ := versions.ParseCalVer("23.04.1", layout)
+//	fmt.Println(cv.Year, cv.Month) // 输出: 2023 4
+func ParseCalVerLayout(layout string) (*CalVerLayout, error) {
+	segments := strings.Split(strings.TrimSpace(layout), ".")
+	kinds := make(map[string]bool)
+	for _, segment := range segments {
+		kind, ok := calVerSegmentKinds[segment]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q unknown segment %q", ErrCalVerLayoutInvalid, layout, segment)
+		}
+		if kinds[kind] {
+			return nil, fmt.Errorf("%w: %q duplicate %s segment", ErrCalVerLayoutInvalid, layout, kind)
+		}
+		kinds[kind] = true
+	}
+	if !kinds["year"] {
+		return nil, fmt.Errorf("%w: %q has no year segment", ErrCalVerLayoutInvalid, layout)
+	}
+	if kinds["day"] && !kinds["month"] {
+		return nil, fmt.Errorf("%w: %q has day segment without month segment", ErrCalVerLayoutInvalid, layout)
+	}
+	for _, segment := range segments {
+		if kinds["day"] && (segment == calVerSegmentWeek || segment == calVerSegmentPaddedWeek) {
+			return nil, fmt.Errorf("%w: %q has day segment with week segment", ErrCalVerLayoutInvalid, layout)
+		}
+	}
+	return &CalVerLayout{
+		raw:      strings.Join(segments, "."),
+		segments: segments,
+	}, nil
+}
+
+// MustParseCalVerLayout 与 ParseCalVerLayout 相同，但是在解析失败时会 panic
+func MustParseCalVerLayout(layout string) *CalVerLayout {
+	l, err := ParseCalVerLayout(layout)
+	if err != nil {
+		panic(err)
+	}
+	return l
+}
+
+// String 返回布局字符串
+func (x *CalVerLayout) String() string {
+	return x.raw
+}
+
+// CalVerVersion 表示按某个布局解析出来的 CalVer 版本号
+//
+// 例如对于使用布局 "YY.0M.MICRO" 的版本号 "23.04.1-beta"：
+// - Year 为 2023，Month 为 4，Micro 为 1
+// - Modifier 为 "beta"
+type CalVerVersion struct {
+
+	// Layout 解析时使用的布局
+	Layout *CalVerLayout
+
+	// Numbers 数字部分中各段的数值，与布局中的段一一对应
+	Numbers []int
+
+	// Year 完整的年份，短年份会加上2000
+	Year int
+
+	// Month 月份，布局中没有月份段时为0
+	Month int
+
+	// Week 一年中的第几周，布局中没有周数段时为0
+	Week int
+
+	// Day 日期，布局中没有日期段时为0
+	Day int
+
+	// Major 主版本号，布局中没有时为0
+	Major int
+
+	// Minor 次版本号，布局中没有时为0
+	Minor int
+
+	// Micro 修订号，布局中没有时为0
+	Micro int
+
+	// Modifier 数字部分之后的修饰部分，去掉了开头的分隔符，如 "beta"
+	Modifier string
+}
+
+// ParseCalVer 按照指定的布局解析 CalVer 版本号，会校验日期的各个部分是否合法
+//
+// 参数:
+//   - versionStr: 要解析的版本号字符串，如 "2023.05.31"
+//   - layout: CalVer 布局
+//
+// 返回:
+//   - *CalVerVersion: 解析结果
+//   - error: 版本号与布局不符或者日期不合法时返回包装了 ErrVersionInvalid 的错误
+func ParseCalVer(versionStr string, layout *CalVerLayout) (*CalVerVersion, error) {
+	cv, _, err := parseCalVer(versionStr, layout)
+	return cv, err
+}
+
+// parseCalVer 按照指定的布局解析 CalVer 版本号，同时返回版本号的形状（段数、每段的位数）是否与布局相符，
+// 形状相符但是日期不合法时不应该再尝试别的布局
+func parseCalVer(versionStr string, layout *CalVerLayout) (*CalVerVersion, bool, error) {
+	match := calVerRegexp.FindStringSubmatch(strings.TrimSpace(versionStr))
+	if match == nil {
+		return nil, false, fmt.Errorf("%w: %q is not a calendar version", ErrVersionInvalid, versionStr)
+	}
+	values := strings.Split(match[1], ".")
+	if len(values) != len(layout.segments) {
+		return nil, false, fmt.Errorf("%w: %q does not match layout %s", ErrVersionInvalid, versionStr, layout.raw)
+	}
+
+	cv := &CalVerVersion{
+		Layout:   layout,
+		Numbers:  make([]int, 0, len(values)),
+		Modifier: strings.TrimLeft(match[2], "-_.+"),
+	}
+	// 取值超出范围时先记下来，继续检查后面的段的位数是否与布局相符
+	var rangeErr error
+	for i, segment := range layout.segments {
+		n, shaped, err := parseCalVerSegment(segment, values[i])
+		if err != nil {
+			err = fmt.Errorf("%w: %q %s", ErrVersionInvalid, versionStr, err.Error())
+			if !shaped {
+				return nil, false, err
+			}
+			if rangeErr == nil {
+				rangeErr = err
+			}
+			continue
+		}
+		cv.Numbers = append(cv.Numbers, n)
+		switch segment {
+		case calVerSegmentFullYear:
+			cv.Year = n
+		case calVerSegmentShortYear, calVerSegmentPaddedYear:
+			cv.Year = 2000 + n
+		case calVerSegmentMonth, calVerSegmentPaddedMon:
+			cv.Month = n
+		case calVerSegmentWeek, calVerSegmentPaddedWeek:
+			cv.Week = n
+		case calVerSegmentDay, calVerSegmentPaddedDay:
+			cv.Day = n
+		case calVerSegmentMajor:
+			cv.Major = n
+		case calVerSegmentMinor:
+			cv.Minor = n
+		case calVerSegmentMicro:
+			cv.Micro = n
+		}
+	}
+	if rangeErr != nil {
+		return nil, true, rangeErr
+	}
+	if cv.Day != 0 && cv.Time().Month() != time.Month(cv.Month) {
+		return nil, true, fmt.Errorf("%w: %q day %d out of range for month %d", ErrVersionInvalid, versionStr, cv.Day, cv.Month)
+	}
+	return cv, true, nil
+}
+
+// parseCalVerSegment 按照段的规则解析一段数字，同时返回这段数字的位数是否与段相符
+func parseCalVerSegment(segment, value string) (int, bool, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, false, fmt.Errorf("segment %s %q overflows", segment, value)
+	}
+	hasLeadingZero := len(value) > 1 && value[0] == '0'
+	switch segment {
+	case calVerSegmentFullYear:
+		if len(value) != 4 || hasLeadingZero {
+			return 0, false, fmt.Errorf("segment %s %q is not a four-digit year", segment, value)
+		}
+		return n, true, nil
+	case calVerSegmentShortYear:
+		if len(value) > 2 || hasLeadingZero {
+			return 0, false, fmt.Errorf("segment %s %q is not a short year", segment, value)
+		}
+		return n, true, nil
+	case calVerSegmentPaddedYear:
+		if len(value) != 2 {
+			return 0, false, fmt.Errorf("segment %s %q is not a zero-padded year", segment, value)
+		}
+		return n, true, nil
+	case calVerSegmentMonth, calVerSegmentPaddedMon:
+		return parseCalVerRange(segment, value, n, 12)
+	case calVerSegmentWeek, calVerSegmentPaddedWeek:
+		return parseCalVerRange(segment, value, n, 53)
+	case calVerSegmentDay, calVerSegmentPaddedDay:
+		return parseCalVerRange(segment, value, n, 31)
+	default:
+		// MAJOR、MINOR、MICRO 不允许有前导零
+		if hasLeadingZero {
+			return 0, false, fmt.Errorf("segment %s %q has leading zero", segment, value)
+		}
+		return n, true, nil
+	}
+}
+
+// parseCalVerRange 校验月份、周数、日期的位数和取值范围，补零的段必须是两位数
+func parseCalVerRange(segment, value string, n, max int) (int, bool, error) {
+	if len(value) > 2 || (strings.HasPrefix(segment, "0") && len(value) != 2) {
+		return 0, false, fmt.Errorf("segment %s %q has wrong width", segment, value)
+	}
+	if n < 1 || n > max {
+		return 0, true, fmt.Errorf("segment %s %q out of range", segment, value)
+	}
+	return n, true, nil
+}
+
+// Time 返回版本号表示的日期（UTC），缺少的月份和日期按1计算，周数按一年中第一天之后的整周计算
+func (x *CalVerVersion) Time() time.Time {
+	if x.Week != 0 {
+		return time.Date(x.Year, time.January, 1+7*(x.Week-1), 0, 0, 0, 0, time.UTC)
+	}
+	month, day := x.Month, x.Day
+	if month == 0 {
+		month = 1
+	}
+	if day == 0 {
+		day = 1
+	}
+	return time.Date(x.Year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
+
+// String 返回按布局格式化之后的版本号，补零的段会补足两位
+func (x *CalVerVersion) String() string {
+	s := strings.Builder{}
+	for i, segment := range x.Layout.segments {
+		if i > 0 {
+			s.WriteString(".")
+		}
+		if strings.HasPrefix(segment, "0") {
+			s.WriteString(fmt.Sprintf("%02d", x.Numbers[i]))
+		} else {
+			s.WriteString(strconv.Itoa(x.Numbers[i]))
+		}
+	}
+	if x.Modifier != "" {
+		s.WriteString("-")
+		s.WriteString(x.Modifier)
+	}
+	return s.String()
+}
+
+// sortKey 返回与布局无关的比较依据：年、月、日、主版本号、次版本号、修订号，没有的部分为0，
+// 以周数表示的日期换算为对应的月和日
+func (x *CalVerVersion) sortKey() []int {
+	month, day := x.Month, x.Day
+	if x.Week != 0 {
+		t := x.Time()
+		month, day = int(t.Month()), t.Day()
+	}
+	return []int{x.Year, month, day, x.Major, x.Minor, x.Micro}
+}
+
+// CompareTo 比较两个 CalVer 版本号
+//
+// 比较与布局无关：依次比较年、月、日（以周数表示的日期换算为月和日，布局中没有的部分为0，
+// 所以 "2024" < "2024.1" < "2024.1.1"），再按主版本号、次版本号、修订号比较，最后比较修饰部分，
+// 修饰部分按 SemVer 预发布标识符的规则比较，没有修饰部分的版本更大，如 "2024.1.3-beta" < "2024.1.3"。
+//
+// 参数:
+//   - target: 要比较的目标版本
+//
+// 返回:
+//   - int: 小于返回-1，等于返回0，大于返回1
+func (x *CalVerVersion) CompareTo(target *CalVerVersion) int {
+	if r := NewVersionNumbers(x.sortKey()).CompareTo(target.sortKey()); r != 0 {
+		return compareInt(r, 0)
+	}
+	return compareSemVerPrerelease(splitCalVerModifier(x.Modifier), splitCalVerModifier(target.Modifier))
+}
+
+// splitCalVerModifier 把修饰部分按点号和连字符拆成标识符
+func splitCalVerModifier(modifier string) []string {
+	if modifier == "" {
+		return nil
+	}
+	return strings.FieldsFunc(modifier, func(r rune) bool {
+		return r == '.' || r == '-'
+	})
+}
+
+// CalVerGroupBy 表示 CalVer 版本的分组方式
+type CalVerGroupBy int
+
+const (
+
+	// CalVerGroupByNumbers 按照完整的数字部分分组，数字按照年、月、日、主版本号、次版本号、修订号的顺序排列，
+	// 只保留方案的布局中用到的部分，如布局 "MAJOR.YYYY" 的 "3.2019" 的组ID为 "2019.3"
+	CalVerGroupByNumbers CalVerGroupBy = iota
+
+	// CalVerGroupByYear 按年份分组，组ID如 "2023"
+	CalVerGroupByYear
+
+	// CalVerGroupByYearMonth 按年月分组，组ID如 "2023.5"，版本号中没有月份和周数时按年份分组
+	CalVerGroupByYearMonth
+)
+
+// CalVerScheme 日历版本号方案
+//
+// 解析时依次尝试方案的布局，使用第一个匹配的布局。解析得到的 Version 中，VersionNumbers 为
+// 各段按书写的数值，Suffix 为数字部分之后的修饰部分，PublicTime 为版本号表示的日期，
+// Detail 为 *CalVerVersion，可以通过 CalVerVersionOf 取出。
+//
+// 使用示例:
+//
+//	scheme, err := versions.NewCalVerScheme("calver-ubuntu", versions.CalVerGroupByYear, "YY.0M", "YY.0M.MICRO")
+//	if err != nil {
+//	    log.Fatalf("创建方案失败: %v", err)
+//	}
+//	versions.RegisterScheme(scheme)
+//	v := versions.MustParse("22.04.3", "calver-ubuntu")
+//	fmt.Println(v.PublicTime.Format("2006-01")) // 输出: 2022-04
+type CalVerScheme struct {
+	name    string
+	groupBy CalVerGroupBy
+	layouts []*CalVerLayout
+}
+
+var _ GroupingScheme = &CalVerScheme{}
+
+func init() {
+	RegisterScheme(mustNewCalVerScheme(SchemeCalVer, CalVerGroupByYearMonth, defaultCalVerLayouts...))
+}
+
+// NewCalVerScheme 创建一个日历版本号方案，创建之后需要调用 RegisterScheme 注册才能通过名称使用
+//
+// 参数:
+//   - name: 方案名称
+//   - groupBy: 分组方式
+//   - layouts: 一个或多个布局，解析时按顺序尝试
+//
+// 返回:
+//   - *CalVerScheme: 创建的方案
+//   - error: 没有布局或者布局无效时返回包装了 ErrCalVerLayoutInvalid 的错误
+func NewCalVerScheme(name string, groupBy CalVerGroupBy, layouts ...string) (*CalVerScheme, error) {
+	if len(layouts) == 0 {
+		return nil, fmt.Errorf("%w: no layout given", ErrCalVerLayoutInvalid)
+	}
+	scheme := &CalVerScheme{
+		name:    name,
+		groupBy: groupBy,
+		layouts: make([]*CalVerLayout, 0, len(layouts)),
+	}
+	for _, layout := range layouts {
+		l, err := ParseCalVerLayout(layout)
+		if err != nil {
+			return nil, err
+		}
+		scheme.layouts = append(scheme.layouts, l)
+	}
+	return scheme, nil
+}
+
+// mustNewCalVerScheme 与 NewCalVerScheme 相同，但是在创建失败时会 panic
+func mustNewCalVerScheme(name string, groupBy CalVerGroupBy, layouts ...string) *CalVerScheme {
+	scheme, err := NewCalVerScheme(name, groupBy, layouts...)
+	if err != nil {
+		panic(err)
+	}
+	return scheme
+}
+
+// Name 返回方案名称
+func (x *CalVerScheme) Name() string {
+	return x.name
+}
+
+// ParseCalVer 依次尝试方案的布局解析版本号，返回第一个匹配的结果
+//
+// 版本号的形状与某个布局相符但是日期不合法时直接返回错误，不会再尝试后面的布局。
+func (x *CalVerScheme) ParseCalVer(versionStr string) (*CalVerVersion, error) {
+	var lastErr error
+	for _, layout := range x.layouts {
+		cv, shaped, err := parseCalVer(versionStr, layout)
+		if err == nil {
+			return cv, nil
+		}
+		if shaped {
+			return nil, err
+		}
+		lastErr = err
+	}
+	if len(x.layouts) == 1 {
+		return nil, lastErr
+	}
+	return nil, fmt.Errorf("%w: %q does not match any calver layout of scheme %s", ErrVersionInvalid, versionStr, x.name)
+}
+
+// Parse 解析日历版本号
+func (x *CalVerScheme) Parse(versionStr string) (*Version, error) {
+	cv, err := x.ParseCalVer(versionStr)
+	if err != nil {
+		return nil, err
+	}
+	match := calVerRegexp.FindStringSubmatch(strings.TrimSpace(versionStr))
+	return &Version{
+		Raw:            versionStr,
+		PublicTime:     cv.Time(),
+		VersionNumbers: NewVersionNumbers(cv.Numbers),
+		NumbersRaw:     match[1],
+		Suffix:         VersionSuffix(match[2]),
+		Scheme:         x,
+		Detail:         cv,
+	}, nil
+}
+
+// Validate 校验是否是方案中某个布局下合法的日历版本号
+func (x *CalVerScheme) Validate(versionStr string) error {
+	_, err := x.ParseCalVer(versionStr)
+	return err
+}
+
+// Compare 按照 CalVerVersion.CompareTo 的规则比较两个版本
+func (x *CalVerScheme) Compare(a, b *Version) int {
+	ca, cb := x.calVerOf(a), x.calVerOf(b)
+	if ca == nil || cb == nil {
+		return compareGeneric(a, b)
+	}
+	return ca.CompareTo(cb)
+}
+
+// Canonical 返回按布局格式化之后的版本号，如布局 "YY.0M" 下的 "23.4" 返回 "23.04"
+func (x *CalVerScheme) Canonical(v *Version) string {
+	if cv := x.calVerOf(v); cv != nil {
+		return cv.String()
+	}
+	return v.Raw
+}
+
+// GroupNumbers 按照方案的分组方式返回版本用于分组的数字，组的顺序与 Compare 的顺序一致
+func (x *CalVerScheme) GroupNumbers(v *Version) VersionNumbers {
+	cv := x.calVerOf(v)
+	if cv == nil {
+		return v.VersionNumbers
+	}
+	key := cv.sortKey()
+	switch {
+	case x.groupBy == CalVerGroupByNumbers:
+		used := x.sortKeyUsed()
+		numbers := make(VersionNumbers, 0, len(key))
+		for i, n := range key {
+			if used[i] {
+				numbers = append(numbers, n)
+			}
+		}
+		return numbers
+	case x.groupBy == CalVerGroupByYear || key[1] == 0:
+		return NewVersionNumbers([]int{cv.Year})
+	default:
+		return NewVersionNumbers(key[:2])
+	}
+}
+
+// sortKeyUsed 返回 CalVerVersion.sortKey 中的每一部分是否在方案的某个布局中用到
+func (x *CalVerScheme) sortKeyUsed() []bool {
+	used := make([]bool, 6)
+	for _, layout := range x.layouts {
+		for _, segment := range layout.segments {
+			switch calVerSegmentKinds[segment] {
+			case "year":
+				used[0] = true
+			case "month":
+				used[1] = true
+				if segment == calVerSegmentWeek || segment == calVerSegmentPaddedWeek {
+					used[2] = true
+				}
+			case "day":
+				used[2] = true
+			case calVerSegmentMajor:
+				used[3] = true
+			case calVerSegmentMinor:
+				used[4] = true
+			case calVerSegmentMicro:
+				used[5] = true
+			}
+		}
+	}
+	return used
+}
+
+// calVerOf 取出版本对应的 CalVer 版本号，不能按本方案解析时返回nil
+func (x *CalVerScheme) calVerOf(v *Version) *CalVerVersion {
+	if cv, ok := v.Detail.(*CalVerVersion); ok {
+		return cv
+	}
+	cv, err := x.ParseCalVer(v.Raw)
+	if err != nil {
+		return nil
+	}
+	return cv
+}
+
+// CalVerVersionOf 取出版本对应的 CalVer 版本号，版本不是由 CalVer 方案解析得到时返回nil
+//
+// 参数:
+//   - v: 版本对象
+//
+// 返回:
+//   - *CalVerVersion: CalVer 版本号
+func CalVerVersionOf(v *Version) *CalVerVersion {
+	if cv, ok := v.Detail.(*CalVerVersion); ok {
+		return cv
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package versions
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-infrastructure/go-shuffle"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseCalVerLayout 测试 CalVer 布局的解析和校验
+func TestParseCalVerLayout(t *testing.T) {
+	for _, layout := range []string{"YYYY.MM.DD", "YY.0M.MICRO", "0Y.0W", "YYYY.MAJOR.MINOR.MICRO", "MAJOR.YYYY"} {
+		l, err := ParseCalVerLayout(layout)
+		assert.Nil(t, err, layout)
+		assert.Equal(t, layout, l.String())
+	}
+	for _, layout := range []string{"", "YYYY.MMM", "MAJOR.MINOR", "YYYY.YY", "YYYY.DD", "YYYY.MM.0M", "YYYY.WW.DD"} {
+		_, err := ParseCalVerLayout(layout)
+		assert.True(t, errors.Is(err, ErrCalVerLayoutInvalid), layout)
+	}
+}
+
+// TestParseCalVer 测试按布局解析 CalVer 版本号以及日期的校验
+func TestParseCalVer(t *testing.T) {
+	cases := []struct {
+		layout   string
+		version  string
+		expected string
+		date     time.Time
+	}{
+		{"YYYY.0M.0D", "2023.05.31", "2023.05.31", time.Date(2023, 5, 31, 0, 0, 0, 0, time.UTC)},
+		{"YYYY.MM.DD", "2023.05.31", "2023.5.31", time.Date(2023, 5, 31, 0, 0, 0, 0, time.UTC)},
+		{"YY.0M", "23.04", "23.04", time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{"YY.0M.MICRO", "22.04.3", "22.04.3", time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{"YYYY.MM.MICRO", "2024.1.3-beta", "2024.1.3-beta", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"0Y.0W", "06.52", "06.52", time.Date(2006, 12, 24, 0, 0, 0, 0, time.UTC)},
+		{"YYYY.MM.DD", "2024.2.29", "2024.2.29", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"YYYY.MAJOR", "2019.3_rc1", "2019.3-rc1", time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, c := range cases {
+		cv, err := ParseCalVer(c.version, MustParseCalVerLayout(c.layout))
+		assert.Nil(t, err, c.version)
+		assert.Equal(t, c.expected, cv.String(), c.version)
+		assert.Equal(t, c.date, cv.Time(), c.version)
+	}
+
+	cv, err := ParseCalVer("22.04.3-rc.1", MustParseCalVerLayout("YY.0M.MICRO"))
+	assert.Nil(t, err)
+	assert.Equal(t, 2022, cv.Year)
+	assert.Equal(t, 4, cv.Month)
+	assert.Equal(t, 3, cv.Micro)
+	assert.Equal(t, "rc.1", cv.Modifier)
+
+	invalid := []struct {
+		layout  string
+		version string
+	}{
+		{"YYYY.MM.DD", "2023.02.29"},
+		{"YYYY.MM.DD", "2023.13.01"},
+		{"YYYY.MM.DD", "2023.4.31"},
+		{"YYYY.MM.DD", "2023.4"},
+		{"YYYY.0M", "2023.4"},
+		{"YYYY.MM", "23.04"},
+		{"YY.MM", "023.04"},
+		{"YY.MM", "2024.04"},
+		{"YY.MM", "106.04"},
+		{"0Y.MM", "106.04"},
+		{"0Y.MM", "6.04"},
+		{"YYYY.MM.MICRO", "2023.04.01"},
+		{"YYYY.WW", "2023.54"},
+		{"YYYY.MM", "beta"},
+	}
+	for _, c := range invalid {
+		_, err := ParseCalVer(c.version, MustParseCalVerLayout(c.layout))
+		assert.True(t, errors.Is(err, ErrVersionInvalid), "%s %s", c.layout, c.version)
+	}
+}
+
+// TestCalVerScheme 测试默认的 CalVer 方案：布局的自动匹配、发布时间、排序和按年月分组
+func TestCalVerScheme(t *testing.T) {
+	v, err := Parse("2023.05.31", SchemeCalVer)
+	assert.Nil(t, err)
+	assert.Equal(t, "YYYY.MM.DD", CalVerVersionOf(v).Layout.String())
+	assert.Equal(t, time.Date(2023, 5, 31, 0, 0, 0, 0, time.UTC), v.PublicTime)
+	assert.Equal(t, VersionNumbers{2023, 5, 31}, v.VersionNumbers)
+
+	// 默认布局不包含短年份，普通的版本号不会被当成日历版本号
+	for _, notCalVer := range []string{"23.04", "1.2.3", "10.2.3"} {
+		_, err := Parse(notCalVer, SchemeCalVer)
+		assert.True(t, errors.Is(err, ErrVersionInvalid), notCalVer)
+	}
+
+	// 修改返回的布局不会影响默认方案
+	layouts := DefaultCalVerLayouts()
+	layouts[0] = "YY.MM"
+	assert.Equal(t, "YYYY.MM.DD.MICRO", DefaultCalVerLayouts()[0])
+
+	v, err = Parse("2024.1.3-beta", SchemeCalVer)
+	assert.Nil(t, err)
+	assert.Equal(t, VersionSuffix("-beta"), v.Suffix)
+	assert.Equal(t, "2024.1.3-beta", v.Canonical())
+
+	v, err = Parse("2024.1.100", SchemeCalVer)
+	assert.Nil(t, err)
+	assert.Equal(t, "YYYY.MM.MICRO", CalVerVersionOf(v).Layout.String())
+
+	// 形状与日期布局相符但日期不合法的版本号会被拒绝，不会换一个布局重新理解
+	for _, invalid := range []string{"123.45", "2023.02.30.1.1", "v2023.05", "2024.2.30", "2024.12.32", "2024.1.40", "2024.13", "2024.13.100"} {
+		_, err := Parse(invalid, SchemeCalVer)
+		assert.True(t, errors.Is(err, ErrVersionInvalid), invalid)
+	}
+
+	ordered := []string{"2022.12.01", "2023.01.15", "2023.05.31-beta", "2023.05.31-rc.1", "2023.05.31", "2023.05.31.1", "2023.6.2", "2024.1.3"}
+	calVersions, err := ParseAll(SchemeCalVer, ordered...)
+	assert.Nil(t, err)
+	shuffle.Shuffle(calVersions)
+	for i, v := range SortVersionSlice(calVersions) {
+		assert.Equal(t, ordered[i], v.Raw)
+	}
+	assert.Equal(t, []string{"2022.12", "2023.1", "2023.5", "2023.6", "2024.1"}, NewSortedVersionGroups(calVersions).GroupIDs())
+	assert.Len(t, Group(calVersions)["2023.5"].Versions(), 4)
+
+	// 比较与布局无关，没有的部分排在前面
+	ordered = []string{"2024", "2024.100", "2024.1", "2024.1.1", "2024.1.1.1", "2024.1.2", "2024.2", "2024.12.1"}
+	calVersions, err = ParseAll(SchemeCalVer, ordered...)
+	assert.Nil(t, err)
+	shuffle.Shuffle(calVersions)
+	for i, v := range SortVersionSlice(calVersions) {
+		assert.Equal(t, ordered[i], v.Raw)
+	}
+	assert.Equal(t, []string{"2024", "2024.1", "2024.2", "2024.12"}, NewSortedVersionGroups(calVersions).GroupIDs())
+}
+
+// TestNewCalVerScheme 测试自定义布局和分组方式的 CalVer 方案
+func TestNewCalVerScheme(t *testing.T) {
+	_, err := NewCalVerScheme("calver-empty", CalVerGroupByYear)
+	assert.True(t, errors.Is(err, ErrCalVerLayoutInvalid))
+	_, err = NewCalVerScheme("calver-bad", CalVerGroupByYear, "YYYY.MM", "XX")
+	assert.True(t, errors.Is(err, ErrCalVerLayoutInvalid))
+
+	scheme, err := NewCalVerScheme("calver-test-year", CalVerGroupByYear, "YY.0M.MICRO")
+	assert.Nil(t, err)
+	RegisterScheme(scheme)
+
+	_, err = Parse("22.4.1", "calver-test-year")
+	assert.True(t, errors.Is(err, ErrVersionInvalid))
+	_, err = Parse("2024.04.1", "calver-test-year")
+	assert.True(t, errors.Is(err, ErrVersionInvalid))
+
+	ubuntu, err := ParseAll("calver-test-year", "22.10.0", "23.04.1", "22.04.3", "23.10.0", "22.04.1")
+	assert.Nil(t, err)
+	groups := NewSortedVersionGroups(ubuntu)
+	assert.Equal(t, []string{"2022", "2023"}, groups.GroupIDs())
+	c, err := ParseConstraintWithScheme(">=23.04.0", "calver-test-year")
+	assert.Nil(t, err)
+	assert.Equal(t, "23.10.0", groups.QueryHighest(c).Raw)
+
+	numbers, err := NewCalVerScheme("calver-test-numbers", CalVerGroupByNumbers, "YYYY.MICRO")
+	assert.Nil(t, err)
+	v, err := numbers.Parse("2019.3")
+	assert.Nil(t, err)
+	assert.Equal(t, "2019.3", v.BuildGroupID())
+
+	// 按数字分组时数字按照比较的顺序排列
+	majorFirst, err := NewCalVerScheme("calver-test-major-first", CalVerGroupByNumbers, "MAJOR.YYYY")
+	assert.Nil(t, err)
+	v, err = majorFirst.Parse("3.2019")
+	assert.Nil(t, err)
+	assert.Equal(t, "2019.3", v.BuildGroupID())
+}
@@ -0,0 +1,76 @@
+package versions
+
+// SchemeCargo Cargo（Rust）版本号方案的名称
+//
+// 版本号的格式与 SemVer 2.0.0 相同，排序规则与 Rust 的 semver crate 一致：
+// 优先级相同的版本之间再按构建元数据排序，因此 "1.0.0+a" 和 "1.0.0+b" 是两个不同的版本。
+const SchemeCargo = "cargo"
+
+// CargoScheme Cargo 版本号方案
+//
+// 解析得到的 Version 与 SemVerScheme 相同，VersionNumbers 为 [Major, Minor, Patch]，Detail 为 *SemVer。
+//
+// 使用示例:
+//
+//	v1 := versions.MustParse("1.0.0+build.2", versions.SchemeCargo)
+//	v2 := versions.MustParse("1.0.0+build.10", versions.SchemeCargo)
+//	fmt.Println(v1.CompareTo(v2)) // 输出: -1
+type CargoScheme struct {
+}
+
+var _ Scheme = &CargoScheme{}
+
+func init() {
+	RegisterScheme(&CargoScheme{})
+}
+
+// Name 返回方案名称 SchemeCargo
+func (x *CargoScheme) Name() string {
+	return SchemeCargo
+}
+
+// Parse 按照 SemVer 2.0.0 规范解析版本号
+func (x *CargoScheme) Parse(versionStr string) (*Version, error) {
+	v, err := (&SemVerScheme{}).Parse(versionStr)
+	if err != nil {
+		return nil, err
+	}
+	v.Scheme = x
+	return v, nil
+}
+
+// Validate 校验是否是合法的 Cargo 版本号
+func (x *CargoScheme) Validate(versionStr string) error {
+	_, err := ParseSemVer(versionStr)
+	return err
+}
+
+// Compare 先按 SemVer 的优先级比较，相同时再比较构建元数据
+func (x *CargoScheme) Compare(a, b *Version) int {
+	sa, sb := semVerOf(a), semVerOf(b)
+	if r := sa.CompareTo(sb); r != 0 {
+		return r
+	}
+	return compareCargoBuild(sa.Build, sb.Build)
+}
+
+// Canonical 返回 SemVer 的字符串表示
+func (x *CargoScheme) Canonical(v *Version) string {
+	return semVerOf(v).String()
+}
+
+// compareCargoBuild 按照 semver crate 中 BuildMetadata 的规则比较构建元数据
+//
+// 没有构建元数据的版本更小；数字标识符按数值比较，数值相同时前导零多的更大；
+// 数字标识符小于非数字标识符；非数字标识符按字典序比较。
+func compareCargoBuild(a, b []string) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if r := compareSemVerIdentifier(a[i], b[i]); r != 0 {
+			return r
+		}
+		if r := compareInt(len(a[i]), len(b[i])); r != 0 {
+			return r
+		}
+	}
+	return compareInt(len(a), len(b))
+}
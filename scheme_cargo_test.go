@@ -0,0 +1,32 @@
+package versions
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang-infrastructure/go-shuffle"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCargoScheme 测试 Cargo 方案的解析和排序，优先级相同的版本按构建元数据排序
+func TestCargoScheme(t *testing.T) {
+	v, err := Parse("1.2.3-rc.1+build.5", SchemeCargo)
+	assert.Nil(t, err)
+	assert.Equal(t, SchemeCargo, v.SchemeName())
+	assert.Equal(t, VersionNumbers{1, 2, 3}, v.VersionNumbers)
+	assert.Equal(t, VersionSuffix("-rc.1+build.5"), v.Suffix)
+	assert.Equal(t, "1.2.3-rc.1+build.5", v.Canonical())
+
+	for _, invalid := range []string{"1.2", "v1.2.3", "01.2.3", "1.2.3-"} {
+		_, err := Parse(invalid, SchemeCargo)
+		assert.True(t, errors.Is(err, ErrVersionInvalid), invalid)
+	}
+
+	ordered := []string{"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-beta.11", "1.0.0", "1.0.0+1", "1.0.0+01", "1.0.0+2", "1.0.0+10", "1.0.0+a", "1.0.0+a.1", "1.0.1"}
+	cargoVersions, err := ParseAll(SchemeCargo, ordered...)
+	assert.Nil(t, err)
+	shuffle.Shuffle(cargoVersions)
+	for i, v := range SortVersionSlice(cargoVersions) {
+		assert.Equal(t, ordered[i], v.Raw)
+	}
+}
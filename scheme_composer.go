@@ -0,0 +1,458 @@
+package versions
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// SchemeComposer Composer（PHP）版本号方案的名称
+//
+// 规范化规则与 composer/semver 的 VersionParser::normalize 保持一致，
+// 比较规则与 PHP 的 version_compare 保持一致。
+const SchemeComposer = "composer"
+
+// Composer 中的稳定性，与 composer/semver 中的名称一致
+const (
+	ComposerStabilityStable = "stable"
+	ComposerStabilityRC     = "RC"
+	ComposerStabilityBeta   = "beta"
+	ComposerStabilityAlpha  = "alpha"
+	ComposerStabilityDev    = "dev"
+)
+
+// composerStabilityLevels 稳定性的级别，级别越高越不稳定，与 BasePackage::STABILITIES 一致
+var composerStabilityLevels = map[string]int{
+	ComposerStabilityStable: 0,
+	ComposerStabilityRC:     5,
+	ComposerStabilityBeta:   10,
+	ComposerStabilityAlpha:  15,
+	ComposerStabilityDev:    20,
+}
+
+// 下面的正则表达式与 composer/semver 的 VersionParser 中的定义保持一致
+var (
+	composerModifier           = `[._-]?(?:(stable|beta|b|RC|alpha|a|patch|pl|p)((?:[.-]?\d+)*)?)?([.-]?dev)?`
+	composerClassicalRegexp    = regexp.MustCompile(`(?i)^v?(\d{1,5})(\.\d+)?(\.\d+)?(\.\d+)?` + composerModifier + `$`)
+	composerDateRegexp         = regexp.MustCompile(`(?i)^v?(\d{4}(?:[.:-]?\d{2}){1,6}(?:[.:-]?\d{1,3}){0,2})` + composerModifier + `$`)
+	composerBranchRegexp       = regexp.MustCompile(`(?i)^v?(\d+)(\.(?:\d+|[xX*]))?(\.(?:\d+|[xX*]))?(\.(?:\d+|[xX*]))?$`)
+	composerDevSuffixRegexp    = regexp.MustCompile(`(?i)^(.*?)[.-]?dev$`)
+	composerStabilityRegexp    = regexp.MustCompile(`(?i)` + composerModifier + `(?:\+.*)?$`)
+	composerStabilityFlagRegex = regexp.MustCompile(`(?i)@(stable|RC|beta|alpha|dev)$`)
+)
+
+// ComposerVersion 表示一个 Composer 的版本号
+//
+// 例如对于版本号 "v1.2-beta.2"：
+// - Normalized 为 "1.2.0.0-beta2"
+// - Stability 为 "beta"
+//
+// 分支版本（如 "dev-main"、"1.x-dev"）也是合法的版本号。
+type ComposerVersion struct {
+
+	// Normalized 规范化之后的版本号，与 VersionParser::normalize 的结果一致
+	Normalized string
+}
+
+// ParseComposerVersion 按照 VersionParser::normalize 的规则解析版本号
+//
+// 参数:
+//   - versionStr: 要解析的版本号字符串，如 "1.0.0-RC1"、"dev-main"、"2.x-dev"
+//
+// 返回:
+//   - *ComposerVersion: 解析结果
+//   - error: 版本号格式不正确时返回包装了 ErrVersionInvalid 的错误
+func ParseComposerVersion(versionStr string) (*ComposerVersion, error) {
+	normalized, err := normalizeComposerVersion(versionStr)
+	if err != nil {
+		return nil, err
+	}
+	return &ComposerVersion{Normalized: normalized}, nil
+}
+
+// normalizeComposerVersion 规范化版本号，与 VersionParser::normalize 一致
+func normalizeComposerVersion(versionStr string) (string, error) {
+	version := strings.TrimSpace(versionStr)
+
+	// 去掉稳定性标记，如 "1.0@beta"
+	if match := composerStabilityFlagRegex.FindString(version); match != "" {
+		version = version[:len(version)-len(match)]
+	}
+	if version == "master" || version == "trunk" || version == "default" {
+		version = "dev-" + version
+	}
+	if strings.HasPrefix(strings.ToLower(version), "dev-") {
+		return "dev-" + version[4:], nil
+	}
+	// 去掉构建元数据
+	if i := strings.IndexByte(version, '+'); i > 0 && !strings.ContainsAny(version[:i], ", ") && !strings.ContainsAny(version[i+1:], " ") {
+		version = version[:i]
+	}
+
+	var matches []string
+	index := 0
+	if matches = composerClassicalRegexp.FindStringSubmatch(version); matches != nil {
+		version = matches[1]
+		for _, part := range matches[2:5] {
+			if part == "" {
+				part = ".0"
+			}
+			version += part
+		}
+		index = 5
+	} else if matches = composerDateRegexp.FindStringSubmatch(version); matches != nil {
+		version = regexp.MustCompile(`\D`).ReplaceAllString(matches[1], ".")
+		index = 2
+	}
+	if index > 0 {
+		if matches[index] != "" {
+			if strings.ToLower(matches[index]) == "stable" {
+				return version, nil
+			}
+			version += "-" + expandComposerStability(matches[index]) + strings.TrimLeft(matches[index+1], ".-")
+		}
+		if matches[index+2] != "" {
+			version += "-dev"
+		}
+		return version, nil
+	}
+
+	// 以 "-dev" 结尾的数字分支，如 "1.x-dev"
+	if match := composerDevSuffixRegexp.FindStringSubmatch(version); match != nil {
+		if normalized, ok := normalizeComposerBranch(match[1]); ok {
+			return normalized, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %q is not a composer version", ErrVersionInvalid, versionStr)
+}
+
+// normalizeComposerBranch 规范化数字分支名，与 VersionParser::normalizeBranch 一致，如 "1.x" 返回 "1.9999999.9999999.9999999-dev"
+func normalizeComposerBranch(name string) (string, bool) {
+	matches := composerBranchRegexp.FindStringSubmatch(strings.TrimSpace(name))
+	if matches == nil {
+		return "", false
+	}
+	version := matches[1]
+	for _, part := range matches[2:5] {
+		if part == "" {
+			part = ".x"
+		}
+		version += strings.NewReplacer("*", "x", "X", "x").Replace(part)
+	}
+	return strings.ReplaceAll(version, "x", "9999999") + "-dev", true
+}
+
+// expandComposerStability 把稳定性的缩写展开，与 VersionParser::expandStability 一致
+func expandComposerStability(stability string) string {
+	switch s := strings.ToLower(stability); s {
+	case "a":
+		return "alpha"
+	case "b":
+		return "beta"
+	case "p", "pl":
+		return "patch"
+	case "rc":
+		return "RC"
+	default:
+		return s
+	}
+}
+
+// ParseComposerStability 返回版本号的稳定性，与 VersionParser::parseStability 一致
+//
+// 参数:
+//   - versionStr: 版本号，可以是原始的也可以是规范化之后的
+//
+// 返回:
+//   - string: ComposerStabilityStable、ComposerStabilityRC、ComposerStabilityBeta、ComposerStabilityAlpha 或 ComposerStabilityDev
+func ParseComposerStability(versionStr string) string {
+	version := versionStr
+	if i := strings.IndexByte(version, '#'); i >= 0 {
+		version = version[:i]
+	}
+	if strings.HasPrefix(version, "dev-") || strings.HasSuffix(version, "-dev") {
+		return ComposerStabilityDev
+	}
+	match := composerStabilityRegexp.FindStringSubmatch(strings.ToLower(version))
+	if match == nil {
+		return ComposerStabilityStable
+	}
+	if match[3] != "" {
+		return ComposerStabilityDev
+	}
+	switch match[1] {
+	case "beta", "b":
+		return ComposerStabilityBeta
+	case "alpha", "a":
+		return ComposerStabilityAlpha
+	case "rc":
+		return ComposerStabilityRC
+	}
+	return ComposerStabilityStable
+}
+
+// Stability 返回版本的稳定性
+func (x *ComposerVersion) Stability() string {
+	return ParseComposerStability(x.Normalized)
+}
+
+// IsBranch 判断是否是非数字的分支版本，如 "dev-main"
+func (x *ComposerVersion) IsBranch() bool {
+	return strings.HasPrefix(x.Normalized, "dev-")
+}
+
+// String 返回规范化之后的版本号
+func (x *ComposerVersion) String() string {
+	return x.Normalized
+}
+
+// CompareTo 按照 Composer 排序版本时的规则比较两个版本
+//
+// 默认分支 "dev-master"、"dev-main"、"dev-default"、"dev-trunk" 被当成 "9999999-dev"，
+// 然后使用 PHP 的 version_compare 比较，因此 "dev" < "alpha" < "beta" < "RC" < 正式版本 < "patch"。
+//
+// 参数:
+//   - target: 要比较的目标版本
+//
+// 返回:
+//   - int: 小于返回-1，等于返回0，大于返回1
+func (x *ComposerVersion) CompareTo(target *ComposerVersion) int {
+	return PHPVersionCompare(composerDefaultBranch(x.Normalized), composerDefaultBranch(target.Normalized))
+}
+
+// composerDefaultBranch 把默认分支规范化为 "9999999-dev"，与 VersionParser::normalizeDefaultBranch 一致
+func composerDefaultBranch(normalized string) string {
+	switch normalized {
+	case "dev-master", "dev-main", "dev-default", "dev-trunk":
+		return "9999999-dev"
+	}
+	return normalized
+}
+
+// phpSpecialVersionForms version_compare 中特殊版本名称的顺序，按顺序匹配前缀
+var phpSpecialVersionForms = []struct {
+	name  string
+	order int
+}{
+	{"dev", 0},
+	{"alpha", 1},
+	{"a", 1},
+	{"beta", 2},
+	{"b", 2},
+	{"RC", 3},
+	{"rc", 3},
+	{"#", 4},
+	{"pl", 5},
+	{"p", 5},
+}
+
+// PHPVersionCompare 与 PHP 的 version_compare 函数一致地比较两个版本号
+//
+// 版本号先被规范化：数字和非数字之间插入点号，"-"、"_"、"+" 等符号替换为点号，然后逐段比较，
+// 数字段按数值比较，非数字段按特殊名称的顺序比较："dev" < "alpha" = "a" < "beta" = "b" < "RC" = "rc" < 数字 < "pl" = "p"，
+// 不认识的名称小于 "dev"。
+//
+// 参数:
+//   - a: 第一个版本号
+//   - b: 第二个版本号
+//
+// 返回:
+//   - int: 小于返回-1，等于返回0，大于返回1
+func PHPVersionCompare(a, b string) int {
+	if a == "" || b == "" {
+		return compareInt(len(a), len(b))
+	}
+	return comparePHPVersionSegments(canonicalizePHPVersion(a), canonicalizePHPVersion(b))
+}
+
+// canonicalizePHPVersion 规范化版本号并按点号切分，与 php_canonicalize_version 一致
+func canonicalizePHPVersion(version string) []string {
+	isDigit := func(c byte) bool { return isASCIIDigit(c) }
+	s := []byte{version[0]}
+	last := version[0]
+	for i := 1; i < len(version); i++ {
+		c := version[i]
+		switch {
+		case c == '-' || c == '_' || c == '+':
+			if s[len(s)-1] != '.' {
+				s = append(s, '.')
+			}
+		case last != '.' && c != '.' && isDigit(last) != isDigit(c):
+			if s[len(s)-1] != '.' {
+				s = append(s, '.')
+			}
+			s = append(s, c)
+		case !isASCIIAlnum(rune(c)):
+			if s[len(s)-1] != '.' {
+				s = append(s, '.')
+			}
+		default:
+			s = append(s, c)
+		}
+		last = c
+	}
+	return strings.Split(string(s), ".")
+}
+
+// comparePHPVersionSegments 逐段比较规范化之后的版本号
+func comparePHPVersionSegments(a, b []string) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if r := comparePHPVersionSegment(a[i], b[i]); r != 0 {
+			return r
+		}
+	}
+	// 一方还有剩余的段时，剩余部分以数字开头则更大，否则与 "#" 比较
+	switch {
+	case len(a) > len(b):
+		if a[len(b)] != "" && isASCIIDigit(a[len(b)][0]) {
+			return 1
+		}
+		return comparePHPVersionSegments(a[len(b):], []string{"#"})
+	case len(a) < len(b):
+		if b[len(a)] != "" && isASCIIDigit(b[len(a)][0]) {
+			return -1
+		}
+		return comparePHPVersionSegments([]string{"#"}, b[len(a):])
+	}
+	return 0
+}
+
+// comparePHPVersionSegment 比较一段
+func comparePHPVersionSegment(a, b string) int {
+	aDigit := a != "" && isASCIIDigit(a[0])
+	bDigit := b != "" && isASCIIDigit(b[0])
+	switch {
+	case aDigit && bDigit:
+		return compareDigitString(a, b)
+	case aDigit:
+		return comparePHPSpecialForms("#", b)
+	case bDigit:
+		return comparePHPSpecialForms(a, "#")
+	default:
+		return comparePHPSpecialForms(a, b)
+	}
+}
+
+// comparePHPSpecialForms 比较两个特殊版本名称，与 compare_special_version_forms 一致
+func comparePHPSpecialForms(a, b string) int {
+	order := func(form string) int {
+		for _, special := range phpSpecialVersionForms {
+			if strings.HasPrefix(form, special.name) {
+				return special.order
+			}
+		}
+		return -1
+	}
+	return compareInt(order(a), order(b))
+}
+
+// ComposerScheme Composer 版本号方案
+//
+// 解析得到的 Version 中，VersionNumbers 为规范化之后的数字部分（第四段为0时省略），
+// Suffix 为原始版本号中数字部分之后的内容，Detail 为 *ComposerVersion。
+// 分支版本（如 "dev-feature"、"dev-main"）没有数字部分，整个版本号作为前缀；分组时默认分支按 [9999999] 分组，见 GroupNumbers。
+//
+// 使用示例:
+//
+//	v1 := versions.MustParse("1.0.0-RC1", versions.SchemeComposer)
+//	v2 := versions.MustParse("1.0.0-beta2", versions.SchemeComposer)
+//	fmt.Println(v1.CompareTo(v2)) // 输出: 1
+type ComposerScheme struct {
+}
+
+var _ GroupingScheme = &ComposerScheme{}
+
+func init() {
+	RegisterScheme(&ComposerScheme{})
+}
+
+// Name 返回方案名称 SchemeComposer
+func (x *ComposerScheme) Name() string {
+	return SchemeComposer
+}
+
+// Parse 按照 Composer 的规则解析版本号
+func (x *ComposerScheme) Parse(versionStr string) (*Version, error) {
+	cv, err := ParseComposerVersion(versionStr)
+	if err != nil {
+		return nil, err
+	}
+	v := &Version{
+		Raw:            versionStr,
+		VersionNumbers: make([]int, 0),
+		Prefix:         EmptyVersionPrefix,
+		Suffix:         EmptyVersionSuffix,
+		Scheme:         x,
+		Detail:         cv,
+	}
+	if cv.IsBranch() {
+		v.Prefix = VersionPrefix(strings.TrimSpace(versionStr))
+		return v, nil
+	}
+
+	numbers := strings.Split(strings.SplitN(cv.Normalized, "-", 2)[0], ".")
+	for len(numbers) > 3 && numbers[len(numbers)-1] == "0" {
+		numbers = numbers[:len(numbers)-1]
+	}
+	for _, number := range numbers {
+		n, err := strconv.Atoi(number)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q numeric part %q overflows", ErrVersionInvalid, versionStr, number)
+		}
+		v.VersionNumbers = append(v.VersionNumbers, n)
+	}
+	s := strings.TrimSpace(versionStr)
+	if strings.HasPrefix(s, "v") || strings.HasPrefix(s, "V") {
+		v.Prefix = VersionPrefix(s[:1])
+		s = s[1:]
+	}
+	if i := strings.IndexFunc(s, func(r rune) bool { return !isASCIIDigit(byte(r)) && r != '.' }); i >= 0 {
+		v.Suffix = VersionSuffix(s[i:])
+	}
+	v.NumbersRaw = s[:len(s)-len(v.Suffix)]
+	return v, nil
+}
+
+// GroupNumbers 返回用于分组的数字，默认分支按 "9999999-dev" 分组，排在所有的数字版本之后
+func (x *ComposerScheme) GroupNumbers(v *Version) VersionNumbers {
+	if cv := composerVersionOf(v); cv != nil && composerDefaultBranch(cv.Normalized) != cv.Normalized {
+		return NewVersionNumbers([]int{9999999})
+	}
+	return v.VersionNumbers
+}
+
+// Validate 校验是否是合法的 Composer 版本号
+func (x *ComposerScheme) Validate(versionStr string) error {
+	_, err := ParseComposerVersion(versionStr)
+	return err
+}
+
+// Compare 按照 ComposerVersion.CompareTo 的规则比较两个版本
+func (x *ComposerScheme) Compare(a, b *Version) int {
+	ca, cb := composerVersionOf(a), composerVersionOf(b)
+	if ca == nil || cb == nil {
+		return compareGeneric(a, b)
+	}
+	return ca.CompareTo(cb)
+}
+
+// Canonical 返回规范化之后的版本号，如 "v1.2-beta.2" 返回 "1.2.0.0-beta2"
+func (x *ComposerScheme) Canonical(v *Version) string {
+	if cv := composerVersionOf(v); cv != nil {
+		return cv.String()
+	}
+	return v.Raw
+}
+
+// composerVersionOf 取出版本中缓存的 ComposerVersion，没有的话则重新解析，不是合法的 Composer 版本号时返回nil
+func composerVersionOf(v *Version) *ComposerVersion {
+	if cv, ok := v.Detail.(*ComposerVersion); ok {
+		return cv
+	}
+	cv, err := ParseComposerVersion(v.Raw)
+	if err != nil {
+		return nil
+	}
+	return cv
+}
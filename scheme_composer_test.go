@@ -0,0 +1,115 @@
+package versions
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang-infrastructure/go-shuffle"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseComposerVersion 测试 Composer 版本号的规范化，用例来自 composer/semver 的 VersionParserTest
+func TestParseComposerVersion(t *testing.T) {
+	normalized := map[string]string{
+		"1.0.0":            "1.0.0.0",
+		"1.2.3.4":          "1.2.3.4",
+		"v1.0.0":           "1.0.0.0",
+		"1.0.0RC1dev":      "1.0.0.0-RC1-dev",
+		"1.0.0-rC15-dev":   "1.0.0.0-RC15-dev",
+		"1.0.0.RC.15-dev":  "1.0.0.0-RC15-dev",
+		"1.0.0-rc1":        "1.0.0.0-RC1",
+		"1.0.0-pl3":        "1.0.0.0-patch3",
+		"1.0-dev":          "1.0.0.0-dev",
+		"0.9.0-alpha.1":    "0.9.0.0-alpha1",
+		"1.0.0-beta.5+foo": "1.0.0.0-beta5",
+		"1.0.0-stable":     "1.0.0.0",
+		"2010.01":          "2010.01.0.0",
+		"2010-01-02":       "2010.01.02",
+		"20100102-203040":  "20100102.203040",
+		"dev-master":       "dev-master",
+		"dev-feature/foo":  "dev-feature/foo",
+		"1.x-dev":          "1.9999999.9999999.9999999-dev",
+		"2.0.*-dev":        "2.0.9999999.9999999-dev",
+		"master-dev":       "",
+		"1.0.0-meh":        "",
+		"1.0.0.0.0":        "",
+		"":                 "",
+	}
+	for raw, expected := range normalized {
+		cv, err := ParseComposerVersion(raw)
+		if expected == "" {
+			assert.True(t, errors.Is(err, ErrVersionInvalid), raw)
+			continue
+		}
+		if assert.Nil(t, err, raw) {
+			assert.Equal(t, expected, cv.Normalized, raw)
+		}
+	}
+
+	stabilities := map[string]string{
+		"1.0":          ComposerStabilityStable,
+		"1.0-pl1":      ComposerStabilityStable,
+		"1.0-RC1":      ComposerStabilityRC,
+		"1.0.0-beta":   ComposerStabilityBeta,
+		"1.0-alpha2":   ComposerStabilityAlpha,
+		"1.0.0-dev":    ComposerStabilityDev,
+		"1.x-dev":      ComposerStabilityDev,
+		"dev-master":   ComposerStabilityDev,
+		"3.1.2-beta#a": ComposerStabilityBeta,
+	}
+	for raw, expected := range stabilities {
+		assert.Equal(t, expected, ParseComposerStability(raw), raw)
+	}
+}
+
+// TestPHPVersionCompare 测试与 PHP version_compare 一致的比较
+func TestPHPVersionCompare(t *testing.T) {
+	cases := []struct {
+		a, b     string
+		expected int
+	}{
+		{"1.0", "1.0", 0},
+		{"1.0", "1.0.0", -1},
+		{"1.0-dev", "1.0-alpha", -1},
+		{"1.0alpha", "1.0beta", -1},
+		{"1.0a", "1.0alpha", 0},
+		{"1.0b1", "1.0RC1", -1},
+		{"1.0RC1", "1.0", -1},
+		{"1.0", "1.0pl1", -1},
+		{"1.0.0.0-patch1", "1.0.0.0", 1},
+		{"1.0.0.0", "1.0.0.0-dev", 1},
+		{"1.10", "1.9", 1},
+		{"1.0-foo", "1.0-dev", -1},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.expected, PHPVersionCompare(c.a, c.b), "%s <=> %s", c.a, c.b)
+		assert.Equal(t, -c.expected, PHPVersionCompare(c.b, c.a), "%s <=> %s", c.b, c.a)
+	}
+}
+
+// TestComposerScheme 测试 Composer 方案的解析和排序，默认分支排在所有的数字版本之后
+func TestComposerScheme(t *testing.T) {
+	v, err := Parse("v1.2.0-beta2", SchemeComposer)
+	assert.Nil(t, err)
+	assert.Equal(t, SchemeComposer, v.SchemeName())
+	assert.Equal(t, VersionNumbers{1, 2, 0}, v.VersionNumbers)
+	assert.Equal(t, VersionPrefix("v"), v.Prefix)
+	assert.Equal(t, VersionSuffix("-beta2"), v.Suffix)
+	assert.Equal(t, "1.2.0.0-beta2", v.Canonical())
+
+	branch := MustParse("dev-feature/foo", SchemeComposer)
+	assert.Equal(t, VersionNumbers{}, branch.VersionNumbers)
+	assert.Equal(t, VersionPrefix("dev-feature/foo"), branch.Prefix)
+	assert.Equal(t, VersionNumbers{}, MustParse("dev-main", SchemeComposer).VersionNumbers)
+	assert.Equal(t, "9999999", MustParse("dev-main", SchemeComposer).BuildGroupID())
+
+	assert.Equal(t, 0, MustParse("1.0", SchemeComposer).CompareTo(MustParse("v1.0.0.0", SchemeComposer)))
+
+	ordered := []string{"0.9", "1.0.0-dev", "1.0.0-alpha", "1.0.0-alpha2", "1.0.0-beta", "1.0.0-RC1", "1.0.0", "1.0.0-pl1", "1.0.1", "1.10.0", "1.x-dev", "dev-master"}
+	composerVersions, err := ParseAll(SchemeComposer, ordered...)
+	assert.Nil(t, err)
+	shuffle.Shuffle(composerVersions)
+	for i, v := range SortVersionSlice(composerVersions) {
+		assert.Equal(t, ordered[i], v.Raw)
+	}
+}
@@ -0,0 +1,326 @@
+package versions
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SchemeDebian Debian 软件包（dpkg）版本号方案的名称
+//
+// 比较规则与 dpkg --compare-versions 保持一致，参见 Debian Policy 5.6.12
+const SchemeDebian = "debian"
+
+// DebianVersion 表示一个 Debian 软件包的版本号，格式为 [epoch:]upstream_version[-debian_revision]
+//
+// 例如对于版本号 "1:2.30-3ubuntu1~18.04"：
+// - Epoch 为 1
+// - Upstream 为 "2.30"
+// - Revision 为 "3ubuntu1~18.04"
+type DebianVersion struct {
+
+	// Epoch 纪元，没有写明时为0
+	Epoch int
+
+	// Upstream 上游版本号
+	Upstream string
+
+	// Revision Debian 修订号，没有时为空字符串
+	Revision string
+}
+
+// ParseDebianVersion 解析 Debian 软件包的版本号
+//
+// 解析规则与 dpkg 的 parseversion 一致：
+// - 第一个冒号之前的部分是纪元，必须是数字
+// - 最后一个连字符之后的部分是修订号，只能包含字母、数字和 ".+~"
+// - 剩下的部分是上游版本号，必须以数字开头，只能包含字母、数字和 ".+-~:"
+//
+// 参数:
+//   - versionStr: 要解析的版本号字符串，如 "1:2.30-3ubuntu1~18.04"
+//
+// 返回:
+//   - *DebianVersion: 解析结果
+//   - error: 版本号不合法时返回包装了 ErrVersionInvalid 的错误
+func ParseDebianVersion(versionStr string) (*DebianVersion, error) {
+	s := strings.TrimSpace(versionStr)
+	if s == "" {
+		return nil, fmt.Errorf("%w: version string is empty", ErrVersionInvalid)
+	}
+	if strings.ContainsAny(s, " \t") {
+		return nil, fmt.Errorf("%w: %q has embedded spaces", ErrVersionInvalid, versionStr)
+	}
+
+	dv := &DebianVersion{}
+	if i := strings.IndexByte(s, ':'); i >= 0 {
+		epoch, err := strconv.Atoi(s[:i])
+		if err != nil || epoch < 0 || !isNumericIdentifier(s[:i]) {
+			return nil, fmt.Errorf("%w: %q has invalid epoch", ErrVersionInvalid, versionStr)
+		}
+		dv.Epoch = epoch
+		s = s[i+1:]
+		if s == "" {
+			return nil, fmt.Errorf("%w: %q has nothing after colon", ErrVersionInvalid, versionStr)
+		}
+	}
+	if i := strings.LastIndexByte(s, '-'); i >= 0 {
+		dv.Revision = s[i+1:]
+		s = s[:i]
+		if dv.Revision == "" {
+			return nil, fmt.Errorf("%w: %q has empty revision", ErrVersionInvalid, versionStr)
+		}
+	}
+	dv.Upstream = s
+
+	if dv.Upstream == "" {
+		return nil, fmt.Errorf("%w: %q has empty upstream version", ErrVersionInvalid, versionStr)
+	}
+	if !isASCIIDigit(dv.Upstream[0]) {
+		return nil, fmt.Errorf("%w: %q upstream version does not start with digit", ErrVersionInvalid, versionStr)
+	}
+	for _, c := range dv.Upstream {
+		if !isASCIIAlnum(c) && !strings.ContainsRune(".-+~:", c) {
+			return nil, fmt.Errorf("%w: %q has invalid character %q in upstream version", ErrVersionInvalid, versionStr, c)
+		}
+	}
+	for _, c := range dv.Revision {
+		if !isASCIIAlnum(c) && !strings.ContainsRune(".+~", c) {
+			return nil, fmt.Errorf("%w: %q has invalid character %q in revision", ErrVersionInvalid, versionStr, c)
+		}
+	}
+	return dv, nil
+}
+
+// String 返回 [epoch:]upstream_version[-debian_revision] 形式的字符串，纪元为0时省略
+func (x *DebianVersion) String() string {
+	s := strings.Builder{}
+	if x.Epoch != 0 {
+		s.WriteString(strconv.Itoa(x.Epoch))
+		s.WriteString(":")
+	}
+	s.WriteString(x.Upstream)
+	if x.Revision != "" {
+		s.WriteString("-")
+		s.WriteString(x.Revision)
+	}
+	return s.String()
+}
+
+// CompareTo 按照 dpkg --compare-versions 的规则比较两个版本
+//
+// 先比较纪元，再依次用 dpkg 的 verrevcmp 算法比较上游版本号和修订号：
+// 交替比较非数字部分和数字部分，非数字部分中"~"排在一切字符（包括字符串结尾）之前，
+// 字母排在非字母之前；数字部分按数值比较。
+//
+// 参数:
+//   - target: 要比较的目标版本
+//
+// 返回:
+//   - int: 小于返回-1，等于返回0，大于返回1
+//
+// 使用示例:
+//
+//	a, _ := versions.ParseDebianVersion("2.30-3ubuntu1~18.04")
+//	b, _ := versions.ParseDebianVersion("2.30-3ubuntu1")
+//	fmt.Println(a.CompareTo(b)) // 输出: -1
+func (x *DebianVersion) CompareTo(target *DebianVersion) int {
+	if r := compareInt(x.Epoch, target.Epoch); r != 0 {
+		return r
+	}
+	if r := debianVerRevCmp(x.Upstream, target.Upstream); r != 0 {
+		return r
+	}
+	return debianVerRevCmp(x.Revision, target.Revision)
+}
+
+// debianOrder 返回非数字部分中字符的排序权重，0 表示字符串结尾或者数字
+func debianOrder(c byte) int {
+	switch {
+	case isASCIIDigit(c):
+		return 0
+	case isASCIIAlpha(c):
+		return int(c)
+	case c == '~':
+		return -1
+	case c != 0:
+		return int(c) + 256
+	default:
+		return 0
+	}
+}
+
+// debianVerRevCmp dpkg 的 verrevcmp 算法
+func debianVerRevCmp(a, b string) int {
+	i, j := 0, 0
+	at := func(s string, k int) byte {
+		if k < len(s) {
+			return s[k]
+		}
+		return 0
+	}
+	for i < len(a) || j < len(b) {
+		firstDiff := 0
+
+		// 比较非数字部分
+		for (i < len(a) && !isASCIIDigit(a[i])) || (j < len(b) && !isASCIIDigit(b[j])) {
+			ac, bc := debianOrder(at(a, i)), debianOrder(at(b, j))
+			if ac != bc {
+				return compareInt(ac, bc)
+			}
+			i++
+			j++
+		}
+
+		// 比较数字部分，先跳过前导0
+		for i < len(a) && a[i] == '0' {
+			i++
+		}
+		for j < len(b) && b[j] == '0' {
+			j++
+		}
+		for i < len(a) && isASCIIDigit(a[i]) && j < len(b) && isASCIIDigit(b[j]) {
+			if firstDiff == 0 {
+				firstDiff = compareInt(int(a[i]), int(b[j]))
+			}
+			i++
+			j++
+		}
+		if i < len(a) && isASCIIDigit(a[i]) {
+			return 1
+		}
+		if j < len(b) && isASCIIDigit(b[j]) {
+			return -1
+		}
+		if firstDiff != 0 {
+			return firstDiff
+		}
+	}
+	return 0
+}
+
+// isASCIIAlpha 判断是否是ASCII字母
+func isASCIIAlpha(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// isASCIIAlnum 判断是否是ASCII字母或数字
+func isASCIIAlnum(c rune) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// DebianScheme Debian 软件包版本号方案
+//
+// 解析得到的 Version 中，Epoch 为版本号的纪元，VersionNumbers 为上游版本号开头以点号分隔的数字，
+// Suffix 为上游版本号剩下的部分加上修订号（如 "-3ubuntu1~18.04"），Detail 为 *DebianVersion。
+//
+// 使用示例:
+//
+//	debVersions, _ := versions.ParseAll(versions.SchemeDebian, "1:2.30-3ubuntu1~18.04", "2.31-0ubuntu9", "1:2.30-3ubuntu1")
+//	sorted := versions.SortVersionSlice(debVersions)
+//	// 结果: ["2.31-0ubuntu9", "1:2.30-3ubuntu1~18.04", "1:2.30-3ubuntu1"]
+type DebianScheme struct {
+}
+
+var _ PrereleaseScheme = &DebianScheme{}
+
+func init() {
+	RegisterScheme(&DebianScheme{})
+}
+
+// Name 返回方案名称 SchemeDebian
+func (x *DebianScheme) Name() string {
+	return SchemeDebian
+}
+
+// Parse 解析 Debian 软件包的版本号
+func (x *DebianScheme) Parse(versionStr string) (*Version, error) {
+	dv, err := ParseDebianVersion(versionStr)
+	if err != nil {
+		return nil, err
+	}
+	numbers, rest := splitLeadingNumbers(dv.Upstream)
+	suffix := rest
+	if dv.Revision != "" {
+		suffix += "-" + dv.Revision
+	}
+	return &Version{
+		Raw:            strings.TrimSpace(versionStr),
+		Epoch:          dv.Epoch,
+		VersionNumbers: numbers,
+		NumbersRaw:     dv.Upstream[:len(dv.Upstream)-len(rest)],
+		Prefix:         EmptyVersionPrefix,
+		Suffix:         VersionSuffix(suffix),
+		Scheme:         x,
+		Detail:         dv,
+	}, nil
+}
+
+// Validate 校验是否是合法的 Debian 版本号
+func (x *DebianScheme) Validate(versionStr string) error {
+	_, err := ParseDebianVersion(versionStr)
+	return err
+}
+
+// Compare 按照 dpkg --compare-versions 的规则比较两个版本
+func (x *DebianScheme) Compare(a, b *Version) int {
+	da, db := debianVersionOf(a), debianVersionOf(b)
+	if da == nil || db == nil {
+		return compareGeneric(a, b)
+	}
+	return da.CompareTo(db)
+}
+
+// Canonical 返回 [epoch:]upstream_version[-debian_revision] 形式的字符串
+func (x *DebianScheme) Canonical(v *Version) string {
+	if dv := debianVersionOf(v); dv != nil {
+		return dv.String()
+	}
+	return v.Raw
+}
+
+// PrereleaseSuffix 返回 "~" 开头的预发布后缀，如 "~rc1"，波浪号排在一切之前，所以 "1.0~rc1" < "1.0"
+func (x *DebianScheme) PrereleaseSuffix(identifier string) VersionSuffix {
+	return VersionSuffix("~" + identifier + "1")
+}
+
+// debianVersionOf 取出版本中缓存的 DebianVersion，没有的话则解析原始字符串，无法解析时返回nil
+func debianVersionOf(v *Version) *DebianVersion {
+	if dv, ok := v.Detail.(*DebianVersion); ok {
+		return dv
+	}
+	dv, err := ParseDebianVersion(v.Raw)
+	if err != nil {
+		return nil
+	}
+	return dv
+}
+
+// splitLeadingNumbers 切分出字符串开头以点号分隔的数字，返回数字以及剩下的部分
+//
+// 例如 "2.30a.1" 返回 [2,30] 和 "a.1"，"1.2." 返回 [1,2] 和 "."。
+// 数字超出int范围时在该数字之前停止。
+func splitLeadingNumbers(s string) (VersionNumbers, string) {
+	numbers := make([]int, 0)
+	end := 0
+	i := 0
+	for i < len(s) {
+		j := i
+		for j < len(s) && isASCIIDigit(s[j]) {
+			j++
+		}
+		if j == i {
+			break
+		}
+		n, err := strconv.Atoi(s[i:j])
+		if err != nil {
+			break
+		}
+		numbers = append(numbers, n)
+		end = j
+		if j >= len(s) || s[j] != '.' {
+			break
+		}
+		i = j + 1
+	}
+	return numbers, s[end:]
+}
@@ -0,0 +1,138 @@
+package versions
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang-infrastructure/go-shuffle"
+	"github.com/stretchr/testify/assert"
+)
+
+// debianCompareCases 比较用例，期望结果与 dpkg --compare-versions 一致，部分来自 apt 的版本比较测试
+var debianCompareCases = []struct {
+	a, b     string
+	expected int
+}{
+	{"1.0", "1.0", 0},
+	{"0:1.0", "1.0", 0},
+	{"1.0", "1.0-0", 0},
+	{"1.2-3", "1.2-03", 0},
+	{"1:0.0", "0:999", 1},
+	{"1.0~rc1", "1.0", -1},
+	{"1.0", "1.0+b1", -1},
+	{"1.0a", "1.0", 1},
+	{"1.0~~", "1.0~~a", -1},
+	{"1.0~~a", "1.0~", -1},
+	{"1.0~", "1.0", -1},
+	{"2.30-3ubuntu1~18.04", "2.30-3ubuntu1", -1},
+	{"1:2.30-3ubuntu1~18.04", "2.31", 1},
+	{"7.6p2-4", "7.6-0", 1},
+	{"1.0.3-3", "1.0-1", 1},
+	{"1.3", "1.2.2-2", 1},
+	{"1.3", "1.2.2", 1},
+	{"0-pre", "0-pre", 0},
+	{"0-pre", "0-pree", -1},
+	{"1.1.6r2-2", "1.1.6r-1", 1},
+	{"2.6b2-1", "2.6b-2", 1},
+	{"98.1p5-1", "98.1-pre2-b6-2", -1},
+	{"0.4a6-2", "0.4-1", 1},
+	{"1:3.0.5-2", "1:3.0.5.1", -1},
+	{"10.3", "1:0.4", -1},
+	{"1:1.25-4", "1:1.25-8", -1},
+	{"0:1.18.36", "1.18.36", 0},
+	{"1.18.36", "1.18.35", 1},
+	{"0:1.18.36", "1.18.35", 1},
+	{"9:1.18.36:5.4-20", "10:0.5.1-22", -1},
+	{"9:1.18.36:5.4-20", "9:1.18.36:5.5-1", -1},
+	{"9:1.18.36:5.4-20", " 9:1.18.37:4.3-22", -1},
+	{"1.18.36-0.17.35-18", "1.18.36-19", 1},
+	{"1:1.2.13-3", "1:1.2.13-3.1", -1},
+	{"2.0.7pre1-4", "2.0.7r-1", -1},
+	{"0:0-0-0", "0-0-0", 0},
+	{"0:0:0-0", "0:0-0", 1},
+	{"1.2.3-1", "1.2.3-2", -1},
+	{"2.4.7-1", "2.4.7-z", -1},
+	{"1.002-1+b2", "1.00", 1},
+}
+
+// TestDebianVersion_CompareTo 测试 dpkg 版本比较算法
+func TestDebianVersion_CompareTo(t *testing.T) {
+	for _, c := range debianCompareCases {
+		a, err := ParseDebianVersion(c.a)
+		assert.Nil(t, err, c.a)
+		b, err := ParseDebianVersion(c.b)
+		assert.Nil(t, err, c.b)
+		assert.Equal(t, c.expected, a.CompareTo(b), "%s vs %s", c.a, c.b)
+		assert.Equal(t, -c.expected, b.CompareTo(a), "%s vs %s", c.b, c.a)
+	}
+}
+
+// TestParseDebianVersion 测试 Debian 版本号的解析
+func TestParseDebianVersion(t *testing.T) {
+	dv, err := ParseDebianVersion("1:2.30-3ubuntu1~18.04")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, dv.Epoch)
+	assert.Equal(t, "2.30", dv.Upstream)
+	assert.Equal(t, "3ubuntu1~18.04", dv.Revision)
+	assert.Equal(t, "1:2.30-3ubuntu1~18.04", dv.String())
+
+	dv, err = ParseDebianVersion("0:1.18.36-0.17.35-18")
+	assert.Nil(t, err)
+	assert.Equal(t, "1.18.36-0.17.35", dv.Upstream)
+	assert.Equal(t, "18", dv.Revision)
+	assert.Equal(t, "1.18.36-0.17.35-18", dv.String())
+
+	for _, invalid := range []string{"", "a:1.0", "1:", "-1", "1.0-", "a1.0", "1.0 1", "1.0_1", "1.0-1_2", ":1.0"} {
+		_, err := ParseDebianVersion(invalid)
+		assert.True(t, errors.Is(err, ErrVersionInvalid), invalid)
+	}
+}
+
+// TestDebianScheme_Parse 测试 Debian 方案与 Version、分组和排序的集成
+func TestDebianScheme_Parse(t *testing.T) {
+	v, err := Parse("1:2.30-3ubuntu1~18.04", SchemeDebian)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, v.Epoch)
+	assert.Equal(t, VersionNumbers{2, 30}, v.VersionNumbers)
+	assert.Equal(t, EmptyVersionPrefix, v.Prefix)
+	assert.Equal(t, VersionSuffix("-3ubuntu1~18.04"), v.Suffix)
+	assert.Equal(t, "1:2.30", v.BuildGroupID())
+	assert.Equal(t, "1:2.30-3ubuntu1~18.04", v.Canonical())
+
+	ordered := []string{
+		"1.0~rc1-1",
+		"1.0-1",
+		"1.0-1ubuntu1",
+		"1.0+dfsg-1",
+		"1.0.1-1",
+		"2.31-0ubuntu9",
+		"1:2.30-3ubuntu1~18.04",
+		"1:2.30-3ubuntu1",
+		"1:2.30-3ubuntu2",
+	}
+	debVersions, err := ParseAll(SchemeDebian, ordered...)
+	assert.Nil(t, err)
+	shuffle.Shuffle(debVersions)
+	sorted := SortVersionSlice(debVersions)
+	for i, v := range sorted {
+		assert.Equal(t, ordered[i], v.Raw)
+	}
+
+	groups := NewSortedVersionGroups(debVersions)
+	assert.Equal(t, []string{"1.0", "1.0.1", "2.31", "1:2.30"}, groups.GroupIDs())
+}
+
+// TestSplitLeadingNumbers 测试切分开头的数字部分
+func TestSplitLeadingNumbers(t *testing.T) {
+	numbers, rest := splitLeadingNumbers("2.30a.1")
+	assert.Equal(t, VersionNumbers{2, 30}, numbers)
+	assert.Equal(t, "a.1", rest)
+
+	numbers, rest = splitLeadingNumbers("1.2.")
+	assert.Equal(t, VersionNumbers{1, 2}, numbers)
+	assert.Equal(t, ".", rest)
+
+	numbers, rest = splitLeadingNumbers("abc")
+	assert.Equal(t, VersionNumbers{}, numbers)
+	assert.Equal(t, "abc", rest)
+}
@@ -0,0 +1,301 @@
+package versions
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// SchemeGem RubyGems 版本号方案的名称
+//
+// 解析和比较规则与 Ruby 的 Gem::Version 保持一致
+const SchemeGem = "gem"
+
+var (
+	// gemVersionRegexp 合法的 Gem 版本号，与 Gem::Version::ANCHORED_VERSION_PATTERN 一致
+	gemVersionRegexp = regexp.MustCompile(`^[0-9]+(?:\.[0-9a-zA-Z]+)*(?:-[0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*)?$`)
+
+	// gemSegmentRegexp 版本号中的段，连续的数字或者连续的字母
+	gemSegmentRegexp = regexp.MustCompile(`[0-9]+|[a-zA-Z]+`)
+)
+
+// GemVersion 表示一个 RubyGems 的版本号
+//
+// 例如对于版本号 "1.0.0-rc1"：
+// - Version 为 "1.0.0.pre.rc1"，与 Gem::Version 一样连字符会被替换为 ".pre."
+// - Segments 为 ["1", "0", "0", "pre", "rc", "1"]
+type GemVersion struct {
+
+	// Version 规范化之后的版本号字符串
+	Version string
+
+	// Segments 版本号中的段，数字段和字母段交替出现
+	Segments []string
+}
+
+// ParseGemVersion 按照 Gem::Version 的规则解析版本号
+//
+// 空字符串被当成 "0"。只要版本号中出现了字母就是预发布版本，例如 "1.0.0.pre.rc1"、"1.0.a"。
+//
+// 参数:
+//   - versionStr: 要解析的版本号字符串，如 "1.0.0.pre.rc1"
+//
+// 返回:
+//   - *GemVersion: 解析结果
+//   - error: 版本号格式不正确时返回包装了 ErrVersionInvalid 的错误
+func ParseGemVersion(versionStr string) (*GemVersion, error) {
+	s := strings.TrimSpace(versionStr)
+	if s == "" {
+		s = "0"
+	}
+	if !gemVersionRegexp.MatchString(s) {
+		return nil, fmt.Errorf("%w: %q is not a gem version", ErrVersionInvalid, versionStr)
+	}
+	s = strings.ReplaceAll(s, "-", ".pre.")
+	return &GemVersion{
+		Version:  s,
+		Segments: gemSegmentRegexp.FindAllString(s, -1),
+	}, nil
+}
+
+// isGemNumericSegment 判断段是否是数字段
+func isGemNumericSegment(segment string) bool {
+	return segment != "" && isASCIIDigit(segment[0])
+}
+
+// IsPrerelease 判断是否是预发布版本，版本号中出现字母即为预发布版本
+func (x *GemVersion) IsPrerelease() bool {
+	for _, segment := range x.Segments {
+		if !isGemNumericSegment(segment) {
+			return true
+		}
+	}
+	return false
+}
+
+// releaseSegments 返回第一个字母段之前的数字段
+func (x *GemVersion) releaseSegments() []string {
+	for i, segment := range x.Segments {
+		if !isGemNumericSegment(segment) {
+			return x.Segments[:i]
+		}
+	}
+	return x.Segments
+}
+
+// Release 返回去掉预发布部分的版本，与 Gem::Version#release 一致，如 "1.2.0.a" 返回 "1.2.0"
+func (x *GemVersion) Release() *GemVersion {
+	if !x.IsPrerelease() {
+		return x
+	}
+	segments := x.releaseSegments()
+	return &GemVersion{
+		Version:  strings.Join(segments, "."),
+		Segments: segments,
+	}
+}
+
+// Bump 返回下一个大版本，与 Gem::Version#bump 一致
+//
+// 去掉预发布部分，再去掉最后一段（只剩一段时保留），然后把最后一段加1，
+// 如 "5.3.1" 返回 "5.4"，"5.3.1.b.2" 返回 "5.4"，"5" 返回 "6"。
+func (x *GemVersion) Bump() *GemVersion {
+	segments := append([]string{}, x.releaseSegments()...)
+	if len(segments) > 1 {
+		segments = segments[:len(segments)-1]
+	}
+	segments[len(segments)-1] = incrementDigitString(segments[len(segments)-1])
+	return &GemVersion{
+		Version:  strings.Join(segments, "."),
+		Segments: segments,
+	}
+}
+
+// incrementDigitString 把纯数字字符串表示的数值加1，不会溢出
+func incrementDigitString(s string) string {
+	digits := []byte(strings.TrimLeft(s, "0"))
+	for i := len(digits) - 1; i >= 0; i-- {
+		if digits[i] != '9' {
+			digits[i]++
+			return string(digits)
+		}
+		digits[i] = '0'
+	}
+	return "1" + string(digits)
+}
+
+// canonicalSegments 返回用于比较的段，与 Gem::Version#canonical_segments 一致
+//
+// 数字部分和预发布部分各自去掉末尾的0，因此 "1.0" 和 "1"、"1.0.a.0" 和 "1.a" 是相等的。
+func (x *GemVersion) canonicalSegments() []string {
+	release := x.releaseSegments()
+	prerelease := x.Segments[len(release):]
+	segments := append([]string{}, trimGemZeroSegments(release)...)
+	return append(segments, trimGemZeroSegments(prerelease)...)
+}
+
+// trimGemZeroSegments 去掉末尾数值为0的数字段
+func trimGemZeroSegments(segments []string) []string {
+	end := len(segments)
+	for end > 0 && isGemNumericSegment(segments[end-1]) && strings.TrimLeft(segments[end-1], "0") == "" {
+		end--
+	}
+	return segments[:end]
+}
+
+// CompareTo 按照 Gem::Version#<=> 的规则比较两个版本
+//
+// 逐段比较，缺少的段按0处理；数字段按数值比较，字母段按字典序比较，字母段小于数字段，
+// 所以 "1.0.a" < "1.0" < "1.0.1"。
+//
+// 参数:
+//   - target: 要比较的目标版本
+//
+// 返回:
+//   - int: 小于返回-1，等于返回0，大于返回1
+func (x *GemVersion) CompareTo(target *GemVersion) int {
+	a, b := x.canonicalSegments(), target.canonicalSegments()
+	for i := 0; i < len(a) || i < len(b); i++ {
+		left, right := "0", "0"
+		if i < len(a) {
+			left = a[i]
+		}
+		if i < len(b) {
+			right = b[i]
+		}
+		leftNumeric, rightNumeric := isGemNumericSegment(left), isGemNumericSegment(right)
+		switch {
+		case leftNumeric && rightNumeric:
+			if r := compareDigitString(left, right); r != 0 {
+				return r
+			}
+		case leftNumeric:
+			return 1
+		case rightNumeric:
+			return -1
+		default:
+			if r := strings.Compare(left, right); r != 0 {
+				return r
+			}
+		}
+	}
+	return 0
+}
+
+// String 返回规范化之后的版本号字符串
+func (x *GemVersion) String() string {
+	return x.Version
+}
+
+// GemScheme RubyGems 版本号方案
+//
+// 解析得到的 Version 中，VersionNumbers 为第一个字母段之前的数字段，Suffix 为剩下的部分，
+// Detail 为 *GemVersion。分组时去掉数字段末尾的0，所以 "1"、"1.0" 和 "1.0.a" 在同一个组 "1" 中。
+//
+// 使用示例:
+//
+//	v1 := versions.MustParse("1.0.0.pre.rc1", versions.SchemeGem)
+//	v2 := versions.MustParse("1.0.0", versions.SchemeGem)
+//	fmt.Println(v1.CompareTo(v2)) // 输出: -1
+type GemScheme struct {
+}
+
+var _ GroupingScheme = &GemScheme{}
+
+func init() {
+	RegisterScheme(&GemScheme{})
+}
+
+// Name 返回方案名称 SchemeGem
+func (x *GemScheme) Name() string {
+	return SchemeGem
+}
+
+// Parse 按照 Gem::Version 的规则解析版本号
+func (x *GemScheme) Parse(versionStr string) (*Version, error) {
+	gv, err := ParseGemVersion(versionStr)
+	if err != nil {
+		return nil, err
+	}
+	release := gv.releaseSegments()
+	numbers := make([]int, 0, len(release))
+	for _, segment := range release {
+		n, err := strconv.Atoi(segment)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q segment %q overflows", ErrVersionInvalid, versionStr, segment)
+		}
+		numbers = append(numbers, n)
+	}
+	// 数字部分之后第一个字母之前的点号归到后缀中，如 "1.0.0.pre" 的后缀为 ".pre"
+	s := strings.TrimSpace(versionStr)
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return !isASCIIDigit(byte(r)) && r != '.'
+	})
+	suffix := EmptyVersionSuffix
+	if i >= 0 {
+		if i > 0 && s[i-1] == '.' {
+			i--
+		}
+		suffix = VersionSuffix(s[i:])
+	}
+	return &Version{
+		Raw:            versionStr,
+		VersionNumbers: NewVersionNumbers(numbers),
+		NumbersRaw:     s[:len(s)-len(suffix)],
+		Prefix:         EmptyVersionPrefix,
+		Suffix:         suffix,
+		Scheme:         x,
+		Detail:         gv,
+	}, nil
+}
+
+// Validate 校验是否是合法的 Gem 版本号
+func (x *GemScheme) Validate(versionStr string) error {
+	_, err := ParseGemVersion(versionStr)
+	return err
+}
+
+// Compare 按照 Gem::Version#<=> 的规则比较两个版本
+func (x *GemScheme) Compare(a, b *Version) int {
+	ga, gb := gemVersionOf(a), gemVersionOf(b)
+	if ga == nil || gb == nil {
+		return compareGeneric(a, b)
+	}
+	return ga.CompareTo(gb)
+}
+
+// Canonical 返回规范化之后的版本号，如 "1.0.0-rc1" 返回 "1.0.0.pre.rc1"
+func (x *GemScheme) Canonical(v *Version) string {
+	if gv := gemVersionOf(v); gv != nil {
+		return gv.String()
+	}
+	return v.Raw
+}
+
+// GroupNumbers 返回第一个字母段之前的数字段去掉末尾的0之后的数值，至少保留一个数字
+//
+// Gem::Version 比较时会忽略数字部分末尾的0，并且预发布版本排在对应的正式版本之前（"1.0.a" < "1"），
+// 所以按照去掉末尾0的数字分组才能使组的顺序与 Compare 的顺序一致。
+func (x *GemScheme) GroupNumbers(v *Version) VersionNumbers {
+	end := len(v.VersionNumbers)
+	for end > 1 && v.VersionNumbers[end-1] == 0 {
+		end--
+	}
+	if end == 0 {
+		return NewVersionNumbers([]int{0})
+	}
+	return v.VersionNumbers[:end]
+}
+
+// gemVersionOf 取出版本中缓存的 GemVersion，没有的话则重新解析，不是合法的 Gem 版本号时返回nil
+func gemVersionOf(v *Version) *GemVersion {
+	if gv, ok := v.Detail.(*GemVersion); ok {
+		return gv
+	}
+	gv, err := ParseGemVersion(v.Raw)
+	if err != nil {
+		return nil
+	}
+	return gv
+}
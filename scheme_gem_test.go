@@ -0,0 +1,113 @@
+package versions
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang-infrastructure/go-shuffle"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseGemVersion 测试 Gem 版本号的解析、预发布判断、release 和 bump，用例来自 RubyGems 的 test_gem_version.rb
+func TestParseGemVersion(t *testing.T) {
+	gv, err := ParseGemVersion("1.0.0-rc1")
+	assert.Nil(t, err)
+	assert.Equal(t, "1.0.0.pre.rc1", gv.String())
+	assert.Equal(t, []string{"1", "0", "0", "pre", "rc", "1"}, gv.Segments)
+
+	gv, err = ParseGemVersion(" ")
+	assert.Nil(t, err)
+	assert.Equal(t, "0", gv.String())
+
+	for _, invalid := range []string{"junk", "1.0\n2.0", "1..2", "1.2 3.4", "1.0-", "v1.0", "1.0+build"} {
+		_, err := ParseGemVersion(invalid)
+		assert.True(t, errors.Is(err, ErrVersionInvalid), invalid)
+	}
+
+	prerelease := map[string]bool{"1.2.0.a": true, "2.9.b": true, "22.1.50.0.d": true, "1.2.d.42": true, "1.A": true, "1-1": true, "1-a": true, "1.2.0": false, "2.9": false, "22.1.50.0": false}
+	for v, expected := range prerelease {
+		assert.Equal(t, expected, mustParseGemVersion(v).IsPrerelease(), v)
+	}
+
+	releases := map[string]string{"1.2.0.a": "1.2.0", "1.1.rc10": "1.1", "1.9.3.alpha.5": "1.9.3", "1.9.3": "1.9.3"}
+	for v, expected := range releases {
+		assert.Equal(t, expected, mustParseGemVersion(v).Release().String(), v)
+	}
+
+	bumps := map[string]string{"5.2.4": "5.3", "5.2.4.a": "5.3", "5.2.4.a10": "5.3", "5.0.0": "5.1", "5": "6", "19.9": "20", "1.9.a": "2"}
+	for v, expected := range bumps {
+		assert.Equal(t, expected, mustParseGemVersion(v).Bump().String(), v)
+	}
+}
+
+// TestGemVersion_CompareTo 测试 Gem::Version#<=> 的比较规则
+func TestGemVersion_CompareTo(t *testing.T) {
+	cases := []struct {
+		a, b     string
+		expected int
+	}{
+		{"1.0", "1.0.0", 0},
+		{"1.0", "1", 0},
+		{"1.0.a.0", "1.0.a", 0},
+		{"1.0.0.pre.rc1", "1.0.0-rc1", 0},
+		{"1.0", "1.0.a", 1},
+		{"1.8.2", "0.0.0", 1},
+		{"1.8.2", "1.8.2.a", 1},
+		{"1.8.2.b", "1.8.2.a", 1},
+		{"1.8.2.a", "1.8.2", -1},
+		{"1.8.2.a10", "1.8.2.a9", 1},
+		{"0.beta.1", "0.0.beta.1", 0},
+		{"0.0.beta", "0.0.beta.1", -1},
+		{"5.a", "5.0.0.rc2", -1},
+		{"5.x", "5.0.0.rc2", 1},
+		{"1.9.3", "1.9.3.0", 0},
+		{"99999999999999999999999", "1", 1},
+	}
+	for _, c := range cases {
+		a, b := mustParseGemVersion(c.a), mustParseGemVersion(c.b)
+		assert.Equal(t, c.expected, a.CompareTo(b), "%s <=> %s", c.a, c.b)
+		assert.Equal(t, -c.expected, b.CompareTo(a), "%s <=> %s", c.b, c.a)
+	}
+}
+
+// TestGemScheme 测试 Gem 方案与 Version 和排序的集成
+func TestGemScheme(t *testing.T) {
+	v, err := Parse("1.0.0.pre.rc1", SchemeGem)
+	assert.Nil(t, err)
+	assert.Equal(t, VersionNumbers{1, 0, 0}, v.VersionNumbers)
+	assert.Equal(t, VersionSuffix(".pre.rc1"), v.Suffix)
+	assert.Equal(t, "1.0.0.pre.rc1", MustParse("1.0.0-rc1", SchemeGem).Canonical())
+	assert.Equal(t, VersionSuffix("a10"), MustParse("1.8.2a10", SchemeGem).Suffix)
+
+	ordered := []string{"0.9", "1.0.0.a", "1.0.0.b1", "1.0.0.pre.rc1", "1.0.0.rc1", "1.0.0", "1.0.1", "1.10", "2.0.0.alpha"}
+	gemVersions, err := ParseAll(SchemeGem, ordered...)
+	assert.Nil(t, err)
+	shuffle.Shuffle(gemVersions)
+	for i, v := range SortVersionSlice(gemVersions) {
+		assert.Equal(t, ordered[i], v.Raw)
+	}
+
+	// 数字部分末尾的0不影响分组，预发布版本排在对应的正式版本之前
+	gemVersions, err = ParseAll(SchemeGem, "1", "1.0.a", "0.9", "1.0.0.pre.rc1", "1.1")
+	assert.Nil(t, err)
+	sorted := SortVersionSlice(gemVersions)
+	assert.Equal(t, "0.9", sorted[0].Raw)
+	assert.ElementsMatch(t, []string{"1.0.a", "1.0.0.pre.rc1"}, []string{sorted[1].Raw, sorted[2].Raw})
+	assert.Equal(t, "1", sorted[3].Raw)
+	assert.Equal(t, "1.1", sorted[4].Raw)
+	groups := NewSortedVersionGroups(gemVersions)
+	assert.Equal(t, []string{"0.9", "1", "1.1"}, groups.GroupIDs())
+	req := MustParseGemRequirement(">= 1")
+	assert.Equal(t, FilterVersions(sorted, req), groups.QueryMatcher(req))
+	assert.Equal(t, "1.1", groups.QueryHighest(MustParseGemRequirement("~> 1.0")).Raw)
+	assert.Equal(t, "0", MustParse("0.0.a", SchemeGem).BuildGroupID())
+}
+
+// mustParseGemVersion 解析测试用的 Gem 版本号，解析失败时 panic
+func mustParseGemVersion(versionStr string) *GemVersion {
+	gv, err := ParseGemVersion(versionStr)
+	if err != nil {
+		panic(err)
+	}
+	return gv
+}
@@ -0,0 +1,86 @@
+package versions
+
+import "strings"
+
+// SchemeGeneric 通用版本号方案的名称
+//
+// 通用方案使用启发式的 VersionStringParser 解析版本号，能够处理绝大多数"前缀+数字+后缀"
+// 形式的版本号，是 NewVersion 使用的默认方案。
+const SchemeGeneric = "generic"
+
+// GenericScheme 通用版本号方案
+//
+// GenericScheme 是对 VersionStringParser 以及 Version 默认比较规则的包装，
+// 在注册表中以 SchemeGeneric 的名称注册。零值使用默认的解析规则，
+// 可以通过 NewGenericScheme 创建使用自定义规则的方案，注册为某个生态专用的方案。
+type GenericScheme struct {
+
+	// name 方案名称，为空时为 SchemeGeneric
+	name string
+
+	// config 解析规则，为nil时使用默认规则
+	config *ParserConfig
+}
+
+var _ Scheme = &GenericScheme{}
+
+func init() {
+	RegisterScheme(&GenericScheme{})
+}
+
+// NewGenericScheme 创建一个使用自定义解析规则的通用方案
+//
+// 参数:
+//   - name: 方案名称，注册之后可以通过该名称使用
+//   - config: 解析规则，为nil时使用默认规则
+//
+// 返回:
+//   - *GenericScheme: 新创建的方案，比较规则与通用方案相同
+//
+// 使用示例:
+//
+//	config := versions.DefaultParserConfig()
+//	config.NumberDelimiters = []rune{'.', '_'}
+//	versions.RegisterScheme(versions.NewGenericScheme("curl", config))
+//	v := versions.MustParse("curl-7_85_0", "curl")
+//	fmt.Println(v.VersionNumbers) // 输出: [7 85 0]
+func NewGenericScheme(name string, config *ParserConfig) *GenericScheme {
+	return &GenericScheme{
+		name:   name,
+		config: config,
+	}
+}
+
+// Name 返回方案名称，默认为 SchemeGeneric
+func (x *GenericScheme) Name() string {
+	if x.name == "" {
+		return SchemeGeneric
+	}
+	return x.name
+}
+
+// Parse 使用 VersionStringParser 解析版本号，版本号无效时返回包装了 ErrVersionInvalid 的 *ParseError
+func (x *GenericScheme) Parse(versionStr string) (*Version, error) {
+	v, err := NewVersionStringParserWithConfig(versionStr, x.config).ParseE()
+	if err != nil {
+		return nil, err
+	}
+	v.Scheme = x
+	return v, nil
+}
+
+// Validate 校验版本号中是否包含数字部分
+func (x *GenericScheme) Validate(versionStr string) error {
+	_, err := x.Parse(versionStr)
+	return err
+}
+
+// Compare 使用 Version 默认的比较规则比较两个版本
+func (x *GenericScheme) Compare(a, b *Version) int {
+	return compareGeneric(a, b)
+}
+
+// Canonical 返回"前缀+以点分隔的数字部分+后缀"形式的字符串，超出 int 范围的数字使用精确的十进制数字
+func (x *GenericScheme) Canonical(v *Version) string {
+	return string(v.Prefix) + strings.Join(v.numberDigits(), DefaultVersionDelimiter) + string(v.Suffix)
+}
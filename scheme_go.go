@@ -0,0 +1,356 @@
+package versions
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// SchemeGo Go 模块版本号方案的名称
+//
+// 比较规则与 golang.org/x/mod/semver 保持一致，并且能够识别伪版本和 "+incompatible"
+const SchemeGo = "go"
+
+// goPseudoVersionRegexp 伪版本的格式，与 golang.org/x/mod/module 中的定义一致
+var goPseudoVersionRegexp = regexp.MustCompile(`^v[0-9]+\.(0\.0-|\d+\.\d+-([^+]*\.)?0\.)\d{14}-[A-Za-z0-9]+(\+[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*)?$`)
+
+// goPseudoVersionTimeLayout 伪版本中提交时间的格式，时区为 UTC
+const goPseudoVersionTimeLayout = "20060102150405"
+
+// GoModuleVersion 表示一个 Go 模块的版本号
+//
+// Go 模块的版本号是带有 "v" 前缀的 SemVer，允许 "v1"、"v1.2" 这样的简写。伪版本有三种形式：
+// - "vX.0.0-yyyymmddhhmmss-abcdefabcdef"：之前没有任何标签
+// - "vX.Y.Z-pre.0.yyyymmddhhmmss-abcdefabcdef"：基于预发布标签 vX.Y.Z-pre
+// - "vX.Y.(Z+1)-0.yyyymmddhhmmss-abcdefabcdef"：基于正式标签 vX.Y.Z
+//
+// 例如对于版本号 "v1.2.4-0.20230108071748-35501d697475"：
+// - SemVer 为 1.2.4-0.20230108071748-35501d697475
+// - Pseudo 为 true，Base 为 "v1.2.3"
+// - Time 为 2023-01-08 07:17:48 UTC，Revision 为 "35501d697475"
+type GoModuleVersion struct {
+
+	// SemVer 去掉 "v" 前缀并补全简写之后的 SemVer
+	SemVer *SemVer
+
+	// Incompatible 是否带有 "+incompatible"，表示没有使用模块路径后缀的 v2 及以上的版本
+	Incompatible bool
+
+	// Pseudo 是否是伪版本
+	Pseudo bool
+
+	// Base 伪版本所基于的标签，没有基于任何标签时为空字符串
+	Base string
+
+	// Time 伪版本对应的提交时间
+	Time time.Time
+
+	// Revision 伪版本对应的提交哈希
+	Revision string
+}
+
+// ParseGoModuleVersion 解析 Go 模块的版本号
+//
+// 参数:
+//   - versionStr: 要解析的版本号字符串，如 "v1.2.3"、"v2.3.0+incompatible"、"v0.0.0-20230108071748-35501d697475"
+//
+// 返回:
+//   - *GoModuleVersion: 解析结果
+//   - error: 版本号不符合 golang.org/x/mod/semver 的规则时返回包装了 ErrVersionInvalid 的错误
+//
+// 使用示例:
+//
+//	gv, err := versions.ParseGoModuleVersion("v0.0.0-20230108071748-35501d697475")
+//	if err != nil {
+//	    log.Fatalf("不是合法的Go模块版本号: %v", err)
+//	}
+//	fmt.Println(gv.Revision) // 输出: 35501d697475
+func ParseGoModuleVersion(versionStr string) (*GoModuleVersion, error) {
+	if !strings.HasPrefix(versionStr, "v") {
+		return nil, fmt.Errorf("%w: %q must start with \"v\"", ErrVersionInvalid, versionStr)
+	}
+	s := versionStr[1:]
+
+	// 简写形式不允许带有预发布和构建元数据，"v1" 等价于 "v1.0.0"，"v1.2" 等价于 "v1.2.0"
+	if i := strings.IndexAny(s, "-+"); i < 0 {
+		switch strings.Count(s, ".") {
+		case 0:
+			s += ".0.0"
+		case 1:
+			s += ".0"
+		}
+	}
+	sv, err := ParseSemVer(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q is not a valid go module version", ErrVersionInvalid, versionStr)
+	}
+
+	gv := &GoModuleVersion{
+		SemVer: sv,
+	}
+	if len(sv.Build) == 1 && sv.Build[0] == "incompatible" {
+		if sv.Major < 2 {
+			return nil, fmt.Errorf("%w: %q +incompatible suffix not allowed for major version v%d", ErrVersionInvalid, versionStr, sv.Major)
+		}
+		gv.Incompatible = true
+	}
+
+	if goPseudoVersionRegexp.MatchString(versionStr) {
+		if err := gv.parsePseudo(versionStr); err != nil {
+			return nil, err
+		}
+	}
+	return gv, nil
+}
+
+// parsePseudo 从伪版本中提取所基于的标签、提交时间和提交哈希
+func (x *GoModuleVersion) parsePseudo(versionStr string) error {
+	s := versionStr
+	if i := strings.IndexByte(s, '+'); i >= 0 {
+		s = s[:i]
+	}
+	j := strings.LastIndexByte(s, '-')
+	revision := s[j+1:]
+	s = s[:j]
+	i := strings.LastIndexByte(s, '-')
+	if k := strings.LastIndexByte(s, '.'); k > i {
+		i = k
+	}
+	t, err := time.Parse(goPseudoVersionTimeLayout, s[i+1:])
+	if err != nil {
+		return fmt.Errorf("%w: %q has invalid pseudo-version timestamp", ErrVersionInvalid, versionStr)
+	}
+	x.Pseudo = true
+	x.Time = t
+	x.Revision = revision
+
+	// 去掉时间戳之后：形式一为 "vX.0.0"，形式二为 "vX.Y.Z-pre.0"，形式三为 "vX.Y.(Z+1)-0"
+	base := s[:i]
+	switch {
+	case !strings.Contains(base, "-"):
+		x.Base = ""
+	case strings.HasSuffix(base, "-0"):
+		if x.SemVer.Patch == 0 {
+			return fmt.Errorf("%w: %q has invalid pseudo-version base", ErrVersionInvalid, versionStr)
+		}
+		digits := x.SemVer.numberDigits()
+		x.Base = "v" + digits[0] + "." + digits[1] + "." + decrementDigitString(digits[2])
+	case strings.HasSuffix(base, ".0"):
+		x.Base = strings.TrimSuffix(base, ".0")
+	}
+	if x.Incompatible && x.Base != "" {
+		x.Base += "+incompatible"
+	}
+	return nil
+}
+
+// decrementDigitString 把纯数字字符串表示的正整数减1，不会溢出，如 "10" 变为 "9"
+func decrementDigitString(s string) string {
+	digits := []byte(trimLeadingZeros(s))
+	for i := len(digits) - 1; i >= 0; i-- {
+		if digits[i] != '0' {
+			digits[i]--
+			break
+		}
+		digits[i] = '9'
+	}
+	return trimLeadingZeros(string(digits))
+}
+
+// Major 返回 "vN" 形式的主版本号
+func (x *GoModuleVersion) Major() string {
+	return "v" + x.SemVer.numberDigits()[0]
+}
+
+// String 返回规范形式的版本号，补全简写并去掉除 "+incompatible" 以外的构建元数据
+func (x *GoModuleVersion) String() string {
+	s := "v" + (&SemVer{Major: x.SemVer.Major, Minor: x.SemVer.Minor, Patch: x.SemVer.Patch, Prerelease: x.SemVer.Prerelease, NumberDigits: x.SemVer.NumberDigits}).String()
+	if x.Incompatible {
+		s += "+incompatible"
+	}
+	return s
+}
+
+// CompareTo 按照 golang.org/x/mod/semver 的规则比较两个版本，构建元数据（包括 "+incompatible"）不参与比较
+func (x *GoModuleVersion) CompareTo(target *GoModuleVersion) int {
+	return x.SemVer.CompareTo(target.SemVer)
+}
+
+// MatchesModulePath 判断版本的主版本号是否与模块路径的主版本后缀相符，与 golang.org/x/mod/module 的 MatchPathMajor 一致
+//
+// 没有后缀的路径只能使用 v0、v1 或者带有 "+incompatible" 的版本；"/vN" 后缀只能使用 vN 版本；
+// "gopkg.in/xxx.vN" 只能使用 vN 版本。
+//
+// 参数:
+//   - modulePath: 模块路径，如 "github.com/foo/bar/v2"
+//
+// 返回:
+//   - bool: 相符时返回 true
+//
+// 使用示例:
+//
+//	gv, _ := versions.ParseGoModuleVersion("v2.3.0+incompatible")
+//	fmt.Println(gv.MatchesModulePath("github.com/foo/bar"))    // 输出: true
+//	fmt.Println(gv.MatchesModulePath("github.com/foo/bar/v2")) // 输出: false
+func (x *GoModuleVersion) MatchesModulePath(modulePath string) bool {
+	_, pathMajor, ok := SplitGoModulePath(modulePath)
+	if !ok {
+		return false
+	}
+	if strings.HasPrefix(pathMajor, ".v") {
+		pathMajor = strings.TrimSuffix(pathMajor, "-unstable")
+		// gopkg.in 的伪版本
+		if pathMajor == ".v1" && x.Pseudo && x.Base == "" && x.SemVer.Major == 0 {
+			return true
+		}
+	}
+	if pathMajor == "" {
+		return x.SemVer.Major <= 1 || x.Incompatible
+	}
+	return !x.Incompatible && pathMajor[1:] == x.Major()
+}
+
+// SplitGoModulePath 把模块路径拆分为前缀和主版本后缀，与 golang.org/x/mod/module 的 SplitPathVersion 一致
+//
+// 参数:
+//   - modulePath: 模块路径
+//
+// 返回:
+//   - prefix: 去掉主版本后缀之后的路径
+//   - pathMajor: 主版本后缀，如 "/v2"、".v1"，没有后缀时为空字符串
+//   - ok: 路径的主版本后缀是否合法，如 "/v1"、"/v0" 是不合法的
+//
+// 使用示例:
+//
+//	prefix, pathMajor, _ := versions.SplitGoModulePath("github.com/foo/bar/v2")
+//	fmt.Println(prefix, pathMajor) // 输出: github.com/foo/bar /v2
+func SplitGoModulePath(modulePath string) (prefix, pathMajor string, ok bool) {
+	if strings.HasPrefix(modulePath, "gopkg.in/") {
+		return splitGopkgInPath(modulePath)
+	}
+
+	i := len(modulePath)
+	dot := false
+	for i > 0 && (isASCIIDigit(modulePath[i-1]) || modulePath[i-1] == '.') {
+		if modulePath[i-1] == '.' {
+			dot = true
+		}
+		i--
+	}
+	if i <= 1 || i == len(modulePath) || modulePath[i-1] != 'v' || modulePath[i-2] != '/' {
+		return modulePath, "", true
+	}
+	prefix, pathMajor = modulePath[:i-2], modulePath[i-2:]
+	if dot || len(pathMajor) <= 2 || pathMajor[2] == '0' || pathMajor == "/v1" {
+		return modulePath, "", false
+	}
+	return prefix, pathMajor, true
+}
+
+// splitGopkgInPath 拆分 gopkg.in 的模块路径，如 "gopkg.in/yaml.v3"
+func splitGopkgInPath(modulePath string) (prefix, pathMajor string, ok bool) {
+	i := len(modulePath)
+	if strings.HasSuffix(modulePath, "-unstable") {
+		i -= len("-unstable")
+	}
+	for i > 0 && isASCIIDigit(modulePath[i-1]) {
+		i--
+	}
+	if i <= 1 || modulePath[i-1] != 'v' || modulePath[i-2] != '.' {
+		return modulePath, "", false
+	}
+	prefix, pathMajor = modulePath[:i-2], modulePath[i-2:]
+	if len(pathMajor) <= 2 || pathMajor[2] == '0' && pathMajor != ".v0" {
+		return modulePath, "", false
+	}
+	return prefix, pathMajor, true
+}
+
+// GoScheme Go 模块版本号方案
+//
+// 解析得到的 Version 中，Prefix 为 "v"，VersionNumbers 为补全之后的主版本号、次版本号和修订号，
+// 伪版本的 PublicTime 为提交时间，Detail 为 *GoModuleVersion，可以通过 GoModuleVersionOf 取出。
+//
+// 使用示例:
+//
+//	v := versions.MustParse("v0.0.0-20230108071748-35501d697475", versions.SchemeGo)
+//	fmt.Println(v.PublicTime) // 输出: 2023-01-08 07:17:48 +0000 UTC
+type GoScheme struct {
+}
+
+var _ Scheme = &GoScheme{}
+
+func init() {
+	RegisterScheme(&GoScheme{})
+}
+
+// Name 返回方案名称 SchemeGo
+func (x *GoScheme) Name() string {
+	return SchemeGo
+}
+
+// Parse 解析 Go 模块的版本号
+func (x *GoScheme) Parse(versionStr string) (*Version, error) {
+	gv, err := ParseGoModuleVersion(versionStr)
+	if err != nil {
+		return nil, err
+	}
+	// 数字部分只会包含数字和点号，因此第一个"-"或"+"就是后缀的开始
+	suffix := EmptyVersionSuffix
+	if i := strings.IndexAny(versionStr, "-+"); i >= 0 {
+		suffix = VersionSuffix(versionStr[i:])
+	}
+	return &Version{
+		Raw:            versionStr,
+		PublicTime:     gv.Time,
+		VersionNumbers: NewVersionNumbers([]int{gv.SemVer.Major, gv.SemVer.Minor, gv.SemVer.Patch}),
+		NumberDigits:   gv.SemVer.NumberDigits,
+		NumbersRaw:     versionStr[1 : len(versionStr)-len(suffix)],
+		Prefix:         VersionPrefix("v"),
+		Suffix:         suffix,
+		Scheme:         x,
+		Detail:         gv,
+	}, nil
+}
+
+// Validate 校验是否是合法的 Go 模块版本号
+func (x *GoScheme) Validate(versionStr string) error {
+	_, err := ParseGoModuleVersion(versionStr)
+	return err
+}
+
+// Compare 按照 golang.org/x/mod/semver 的规则比较两个版本
+func (x *GoScheme) Compare(a, b *Version) int {
+	ga, gb := GoModuleVersionOf(a), GoModuleVersionOf(b)
+	if ga == nil || gb == nil {
+		return compareGeneric(a, b)
+	}
+	return ga.CompareTo(gb)
+}
+
+// Canonical 返回规范形式的版本号，如 "v1.2" 返回 "v1.2.0"
+func (x *GoScheme) Canonical(v *Version) string {
+	if gv := GoModuleVersionOf(v); gv != nil {
+		return gv.String()
+	}
+	return v.Raw
+}
+
+// GoModuleVersionOf 取出版本对应的 Go 模块版本号，版本不是合法的 Go 模块版本号时返回nil
+//
+// 参数:
+//   - v: 版本对象，通常由 Go 方案解析得到
+//
+// 返回:
+//   - *GoModuleVersion: Go 模块版本号
+func GoModuleVersionOf(v *Version) *GoModuleVersion {
+	if gv, ok := v.Detail.(*GoModuleVersion); ok {
+		return gv
+	}
+	gv, err := ParseGoModuleVersion(v.Raw)
+	if err != nil {
+		return nil
+	}
+	return gv
+}
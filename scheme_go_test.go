@@ -0,0 +1,188 @@
+package versions
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-infrastructure/go-shuffle"
+	"github.com/stretchr/testify/assert"
+)
+
+// goSemVerTests 来自 golang.org/x/mod/semver 的测试用例，合法的版本按从小到大排列，期望值为规范形式
+var goSemVerTests = []struct {
+	in  string
+	out string
+}{
+	{"bad", ""},
+	{"v1-alpha.beta.gamma", ""},
+	{"v1-pre", ""},
+	{"v1+meta", ""},
+	{"v1-pre+meta", ""},
+	{"v1.2-pre", ""},
+	{"v1.2+meta", ""},
+	{"v1.2-pre+meta", ""},
+	{"v1.0.0-alpha", "v1.0.0-alpha"},
+	{"v1.0.0-alpha.1", "v1.0.0-alpha.1"},
+	{"v1.0.0-alpha.beta", "v1.0.0-alpha.beta"},
+	{"v1.0.0-beta", "v1.0.0-beta"},
+	{"v1.0.0-beta.2", "v1.0.0-beta.2"},
+	{"v1.0.0-beta.11", "v1.0.0-beta.11"},
+	{"v1.0.0-rc.1", "v1.0.0-rc.1"},
+	{"v1", "v1.0.0"},
+	{"v1.0", "v1.0.0"},
+	{"v1.0.0", "v1.0.0"},
+	{"v1.2", "v1.2.0"},
+	{"v1.2.0", "v1.2.0"},
+	{"v1.2.3-456", "v1.2.3-456"},
+	{"v1.2.3-456.789", "v1.2.3-456.789"},
+	{"v1.2.3-456-789", "v1.2.3-456-789"},
+	{"v1.2.3-456a", "v1.2.3-456a"},
+	{"v1.2.3-pre", "v1.2.3-pre"},
+	{"v1.2.3-pre+meta", "v1.2.3-pre"},
+	{"v1.2.3-pre.1", "v1.2.3-pre.1"},
+	{"v1.2.3-zzz", "v1.2.3-zzz"},
+	{"v1.2.3", "v1.2.3"},
+	{"v1.2.3+meta", "v1.2.3"},
+	{"v1.2.3+meta-pre", "v1.2.3"},
+	{"v1.2.3+meta-pre.sha.256a", "v1.2.3"},
+}
+
+// TestParseGoModuleVersion 测试 Go 模块版本号的校验、规范形式和排序
+func TestParseGoModuleVersion(t *testing.T) {
+	var previous *GoModuleVersion
+	for _, c := range goSemVerTests {
+		gv, err := ParseGoModuleVersion(c.in)
+		if c.out == "" {
+			assert.True(t, errors.Is(err, ErrVersionInvalid), c.in)
+			continue
+		}
+		assert.Nil(t, err, c.in)
+		assert.Equal(t, c.out, gv.String(), c.in)
+		if previous != nil {
+			assert.True(t, previous.CompareTo(gv) <= 0, "%s <= %s", previous.String(), c.in)
+		}
+		previous = gv
+	}
+
+	for _, invalid := range []string{"1.2.3", "v01.2.3", "v1.2.3+incompatible", "v0.1.0+incompatible", "v1.2.3-01"} {
+		_, err := ParseGoModuleVersion(invalid)
+		assert.True(t, errors.Is(err, ErrVersionInvalid), invalid)
+	}
+
+	gv, err := ParseGoModuleVersion("v2.3.0+incompatible")
+	assert.Nil(t, err)
+	assert.True(t, gv.Incompatible)
+	assert.False(t, gv.Pseudo)
+	assert.Equal(t, "v2", gv.Major())
+	assert.Equal(t, "v2.3.0+incompatible", gv.String())
+
+	// 超出 int 范围的数字
+	gv, err = ParseGoModuleVersion("v99999999999999999999.1")
+	assert.Nil(t, err)
+	assert.Equal(t, "v99999999999999999999", gv.Major())
+	assert.Equal(t, "v99999999999999999999.1.0", gv.String())
+	assert.Equal(t, 1, gv.CompareTo(&GoModuleVersion{SemVer: &SemVer{Major: 9223372036854775807}}))
+}
+
+// TestGoModuleVersion_Pseudo 测试三种形式的伪版本
+func TestGoModuleVersion_Pseudo(t *testing.T) {
+	cases := []struct {
+		version  string
+		base     string
+		revision string
+	}{
+		{"v0.0.0-20230108071748-35501d697475", "", "35501d697475"},
+		{"v1.2.4-0.20230108071748-35501d697475", "v1.2.3", "35501d697475"},
+		{"v1.2.3-pre.0.20230108071748-35501d697475", "v1.2.3-pre", "35501d697475"},
+		{"v1.2.3-0.0.20230108071748-35501d697475", "v1.2.3-0", "35501d697475"},
+		{"v2.0.1-0.20230108071748-abcdefabcdef+incompatible", "v2.0.0+incompatible", "abcdefabcdef"},
+		{"v1.2.10000000000000000000-0.20230108071748-35501d697475", "v1.2.9999999999999999999", "35501d697475"},
+	}
+	for _, c := range cases {
+		gv, err := ParseGoModuleVersion(c.version)
+		assert.Nil(t, err, c.version)
+		assert.True(t, gv.Pseudo, c.version)
+		assert.Equal(t, c.base, gv.Base, c.version)
+		assert.Equal(t, c.revision, gv.Revision, c.version)
+		assert.Equal(t, time.Date(2023, 1, 8, 7, 17, 48, 0, time.UTC), gv.Time, c.version)
+	}
+
+	_, err := ParseGoModuleVersion("v1.2.0-0.20230108071748-35501d697475")
+	assert.True(t, errors.Is(err, ErrVersionInvalid))
+	_, err = ParseGoModuleVersion("v0.0.0-20231308071748-35501d697475")
+	assert.True(t, errors.Is(err, ErrVersionInvalid))
+
+	gv, err := ParseGoModuleVersion("v0.0.0-2023010807174-35501d697475")
+	assert.Nil(t, err)
+	assert.False(t, gv.Pseudo)
+}
+
+// TestGoModuleVersion_MatchesModulePath 测试版本与模块路径的主版本后缀是否相符
+func TestGoModuleVersion_MatchesModulePath(t *testing.T) {
+	cases := []struct {
+		version  string
+		path     string
+		expected bool
+	}{
+		{"v1.2.3", "github.com/foo/bar", true},
+		{"v0.1.0", "github.com/foo/bar", true},
+		{"v2.0.0", "github.com/foo/bar", false},
+		{"v2.3.0+incompatible", "github.com/foo/bar", true},
+		{"v2.3.0+incompatible", "github.com/foo/bar/v2", false},
+		{"v2.0.0", "github.com/foo/bar/v2", true},
+		{"v3.0.0", "github.com/foo/bar/v2", false},
+		{"v1.0.0", "github.com/foo/bar/v1", false},
+		{"v3.0.0", "gopkg.in/yaml.v3", true},
+		{"v2.4.0", "gopkg.in/yaml.v3", false},
+		{"v0.0.0-20230108071748-35501d697475", "gopkg.in/check.v1", true},
+		{"v1.0.0", "gopkg.in/check.v1-unstable", true},
+	}
+	for _, c := range cases {
+		gv, err := ParseGoModuleVersion(c.version)
+		assert.Nil(t, err, c.version)
+		assert.Equal(t, c.expected, gv.MatchesModulePath(c.path), "%s %s", c.path, c.version)
+	}
+
+	prefix, pathMajor, ok := SplitGoModulePath("github.com/foo/bar/v2")
+	assert.Equal(t, []any{"github.com/foo/bar", "/v2", true}, []any{prefix, pathMajor, ok})
+	prefix, pathMajor, ok = SplitGoModulePath("gopkg.in/yaml.v3")
+	assert.Equal(t, []any{"gopkg.in/yaml", ".v3", true}, []any{prefix, pathMajor, ok})
+	_, _, ok = SplitGoModulePath("github.com/foo/bar/v1")
+	assert.False(t, ok)
+	_, _, ok = SplitGoModulePath("github.com/foo/bar/v2.1")
+	assert.False(t, ok)
+}
+
+// TestGoScheme_Parse 测试 Go 方案与 Version、分组和排序的集成
+func TestGoScheme_Parse(t *testing.T) {
+	v, err := Parse("v0.0.2-0.20230108071748-35501d697475", SchemeGo)
+	assert.Nil(t, err)
+	assert.Equal(t, VersionPrefix("v"), v.Prefix)
+	assert.Equal(t, VersionNumbers{0, 0, 2}, v.VersionNumbers)
+	assert.Equal(t, VersionSuffix("-0.20230108071748-35501d697475"), v.Suffix)
+	assert.Equal(t, time.Date(2023, 1, 8, 7, 17, 48, 0, time.UTC), v.PublicTime)
+	assert.Equal(t, "v0.0.1", GoModuleVersionOf(v).Base)
+	assert.Equal(t, "v1.2.0", MustParse("v1.2", SchemeGo).Canonical())
+
+	ordered := []string{
+		"v0.0.0-20221215155811-4ed54fe7d579",
+		"v0.0.0-20230108071748-35501d697475",
+		"v0.0.1",
+		"v0.0.2-0.20230108071748-35501d697475",
+		"v0.0.2",
+		"v1.0.0-rc.1",
+		"v1.0.0-rc.1.0.20230108071748-35501d697475",
+		"v1.0.0",
+		"v1.8.2",
+		"v2.0.0+incompatible",
+		"v2.3.0+incompatible",
+	}
+	goVersions, err := ParseAll(SchemeGo, ordered...)
+	assert.Nil(t, err)
+	shuffle.Shuffle(goVersions)
+	for i, v := range SortVersionSlice(goVersions) {
+		assert.Equal(t, ordered[i], v.Raw)
+	}
+	assert.Equal(t, []string{"0.0.0", "0.0.1", "0.0.2", "1.0.0", "1.8.2", "2.0.0", "2.3.0"}, NewSortedVersionGroups(goVersions).GroupIDs())
+}
@@ -0,0 +1,446 @@
+package versions
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// SchemeMaven Maven 版本号方案的名称
+//
+// 比较规则与 org.apache.maven.artifact.versioning.ComparableVersion 保持一致
+const SchemeMaven = "maven"
+
+// mavenQualifiers 已知的限定词，按照优先级从低到高排列，空字符串表示正式版本
+var mavenQualifiers = []string{"alpha", "beta", "milestone", "rc", "snapshot", "", "sp"}
+
+// mavenQualifierAliases 限定词的别名
+var mavenQualifierAliases = map[string]string{
+	"ga":      "",
+	"final":   "",
+	"release": "",
+	"cr":      "rc",
+}
+
+// mavenReleaseVersionIndex 正式版本（空限定词）在 mavenQualifiers 中的位置
+var mavenReleaseVersionIndex = strconv.Itoa(indexOfString(mavenQualifiers, ""))
+
+// mavenItemKind Maven 版本号中元素的种类
+type mavenItemKind int
+
+const (
+	// mavenItemInt 数字元素
+	mavenItemInt mavenItemKind = iota
+	// mavenItemString 限定词元素
+	mavenItemString
+	// mavenItemList 列表元素，由"-"或者数字与字母之间的切换引入
+	mavenItemList
+)
+
+// mavenItem 对应 ComparableVersion 中的 Item，可能是数字、字符串或者列表
+type mavenItem struct {
+	kind mavenItemKind
+
+	// value 数字元素为去掉前导0的数字字符串，字符串元素为处理过别名的限定词
+	value string
+
+	// items 列表元素包含的子元素
+	items []*mavenItem
+}
+
+// newMavenIntItem 创建数字元素，数字以字符串形式保存，因此不会溢出
+func newMavenIntItem(digits string) *mavenItem {
+	digits = strings.TrimLeft(digits, "0")
+	if digits == "" {
+		digits = "0"
+	}
+	return &mavenItem{kind: mavenItemInt, value: digits}
+}
+
+// newMavenStringItem 创建字符串元素，紧跟着数字的单字母 a、b、m 分别是 alpha、beta、milestone 的缩写
+func newMavenStringItem(value string, followedByDigit bool) *mavenItem {
+	if followedByDigit && len(value) == 1 {
+		switch value[0] {
+		case 'a':
+			value = "alpha"
+		case 'b':
+			value = "beta"
+		case 'm':
+			value = "milestone"
+		}
+	}
+	if alias, ok := mavenQualifierAliases[value]; ok {
+		value = alias
+	}
+	return &mavenItem{kind: mavenItemString, value: value}
+}
+
+// comparableQualifier 把限定词转换为可以按字典序比较的形式，未知的限定词排在所有已知限定词之后
+func comparableQualifier(qualifier string) string {
+	i := indexOfString(mavenQualifiers, qualifier)
+	if i == -1 {
+		return strconv.Itoa(len(mavenQualifiers)) + "-" + qualifier
+	}
+	return strconv.Itoa(i)
+}
+
+// isNull 元素是否等价于"不存在"：数字0、正式版本限定词、空列表
+func (x *mavenItem) isNull() bool {
+	switch x.kind {
+	case mavenItemInt:
+		return x.value == "0"
+	case mavenItemString:
+		return comparableQualifier(x.value) == mavenReleaseVersionIndex
+	default:
+		return len(x.items) == 0
+	}
+}
+
+// normalize 去掉列表末尾等价于"不存在"的元素
+func (x *mavenItem) normalize() {
+	for i := len(x.items) - 1; i >= 0; i-- {
+		last := x.items[i]
+		if last.isNull() {
+			x.items = append(x.items[:i], x.items[i+1:]...)
+		} else if last.kind != mavenItemList {
+			break
+		}
+	}
+}
+
+// compareTo 比较两个元素，target 为 nil 表示与"不存在"比较
+func (x *mavenItem) compareTo(target *mavenItem) int {
+	switch x.kind {
+	case mavenItemInt:
+		if target == nil {
+			if x.value == "0" {
+				return 0
+			}
+			return 1
+		}
+		switch target.kind {
+		case mavenItemInt:
+			return compareDigitString(x.value, target.value)
+		default:
+			// 1.1 > 1-sp 并且 1.1 > 1-1
+			return 1
+		}
+	case mavenItemString:
+		if target == nil {
+			// 1-rc < 1, 1-ga > 1
+			return strings.Compare(comparableQualifier(x.value), mavenReleaseVersionIndex)
+		}
+		switch target.kind {
+		case mavenItemString:
+			return strings.Compare(comparableQualifier(x.value), comparableQualifier(target.value))
+		default:
+			// 1.any < 1.1 并且 1.any < 1-1
+			return -1
+		}
+	default:
+		if target == nil {
+			if len(x.items) == 0 {
+				return 0
+			}
+			return x.items[0].compareTo(nil)
+		}
+		switch target.kind {
+		case mavenItemInt:
+			// 1-1 < 1.0.x
+			return -1
+		case mavenItemString:
+			// 1-1 > 1-sp
+			return 1
+		default:
+			for i := 0; i < len(x.items) || i < len(target.items); i++ {
+				var l, r *mavenItem
+				if i < len(x.items) {
+					l = x.items[i]
+				}
+				if i < len(target.items) {
+					r = target.items[i]
+				}
+				var result int
+				if l == nil {
+					if r != nil {
+						result = -1 * r.compareTo(l)
+					}
+				} else {
+					result = l.compareTo(r)
+				}
+				if result != 0 {
+					return result
+				}
+			}
+			return 0
+		}
+	}
+}
+
+// String 返回元素的规范化字符串，列表中的子列表用"-"连接，其它元素用"."连接
+func (x *mavenItem) String() string {
+	if x.kind != mavenItemList {
+		return x.value
+	}
+	s := strings.Builder{}
+	for i, item := range x.items {
+		if i > 0 {
+			if item.kind == mavenItemList {
+				s.WriteString("-")
+			} else {
+				s.WriteString(".")
+			}
+		}
+		s.WriteString(item.String())
+	}
+	return s.String()
+}
+
+// MavenVersion 表示一个按照 Maven ComparableVersion 规则解析的版本号
+//
+// Maven 的版本号可以是任意字符串，解析时会被拆分为数字、限定词和列表三种元素：
+// - "."分隔同一个列表中的元素，"-"以及数字与字母之间的切换会开启一个新的子列表
+// - 已知的限定词按 alpha < beta < milestone < rc = cr < snapshot < "" = ga = final = release < sp 排序
+// - 未知的限定词排在已知限定词之后，彼此之间按字典序比较
+// - 末尾的0和正式版本限定词会被忽略，因此 "1" = "1.0" = "1.0.0" = "1-ga"
+//
+// 使用示例:
+//
+//	v1 := versions.ParseMavenVersion("10.0.0-M1")
+//	v2 := versions.ParseMavenVersion("10.0.0")
+//	fmt.Println(v1.CompareTo(v2))   // 输出: -1
+//	fmt.Println(v1.Canonical())     // 输出: 10-milestone-1
+type MavenVersion struct {
+
+	// raw 原始版本字符串
+	raw string
+
+	// items 解析并规范化之后的元素列表
+	items *mavenItem
+
+	// leadingNumbers 规范化之前，开头连续的数字元素
+	leadingNumbers []string
+}
+
+// ParseMavenVersion 按照 Maven ComparableVersion 的规则解析版本号，任何字符串都可以被解析
+//
+// 参数:
+//   - versionStr: 要解析的版本号字符串，如 "1.0.0.Beta1"
+//
+// 返回:
+//   - *MavenVersion: 解析结果
+func ParseMavenVersion(versionStr string) *MavenVersion {
+	root := &mavenItem{kind: mavenItemList}
+	version := strings.ToLower(versionStr)
+
+	list := root
+	stack := []*mavenItem{list}
+	isDigit := false
+	startIndex := 0
+
+	// 开启一个新的子列表
+	pushList := func() {
+		newList := &mavenItem{kind: mavenItemList}
+		list.items = append(list.items, newList)
+		list = newList
+		stack = append(stack, list)
+	}
+
+	runes := []rune(version)
+	for i, c := range runes {
+		switch {
+		case c == '.':
+			if i == startIndex {
+				list.items = append(list.items, newMavenIntItem("0"))
+			} else {
+				list.items = append(list.items, parseMavenItem(isDigit, string(runes[startIndex:i])))
+			}
+			startIndex = i + 1
+		case c == '-':
+			if i == startIndex {
+				list.items = append(list.items, newMavenIntItem("0"))
+			} else {
+				list.items = append(list.items, parseMavenItem(isDigit, string(runes[startIndex:i])))
+			}
+			startIndex = i + 1
+			pushList()
+		case unicode.IsDigit(c):
+			if !isDigit && i > startIndex {
+				list.items = append(list.items, newMavenStringItem(string(runes[startIndex:i]), true))
+				startIndex = i
+				pushList()
+			}
+			isDigit = true
+		default:
+			if isDigit && i > startIndex {
+				list.items = append(list.items, parseMavenItem(true, string(runes[startIndex:i])))
+				startIndex = i
+				pushList()
+			}
+			isDigit = false
+		}
+	}
+	if len(runes) > startIndex {
+		list.items = append(list.items, parseMavenItem(isDigit, string(runes[startIndex:])))
+	}
+
+	// 规范化之前先记下开头连续的数字元素，用来构造 VersionNumbers
+	leadingNumbers := make([]string, 0)
+	for _, item := range root.items {
+		if item.kind != mavenItemInt {
+			break
+		}
+		leadingNumbers = append(leadingNumbers, item.value)
+	}
+
+	for i := len(stack) - 1; i >= 0; i-- {
+		stack[i].normalize()
+	}
+
+	return &MavenVersion{
+		raw:            versionStr,
+		items:          root,
+		leadingNumbers: leadingNumbers,
+	}
+}
+
+// parseMavenItem 把一段数字或字母解析为元素
+func parseMavenItem(isDigit bool, buf string) *mavenItem {
+	if isDigit {
+		return newMavenIntItem(buf)
+	}
+	return newMavenStringItem(buf, false)
+}
+
+// CompareTo 按照 Maven ComparableVersion 的规则比较两个版本
+//
+// 参数:
+//   - target: 要比较的目标版本
+//
+// 返回:
+//   - int: 小于返回负数，等于返回0，大于返回正数
+func (x *MavenVersion) CompareTo(target *MavenVersion) int {
+	return x.items.compareTo(target.items)
+}
+
+// Canonical 返回规范化的版本字符串，与 ComparableVersion.getCanonical() 一致
+func (x *MavenVersion) Canonical() string {
+	return x.items.String()
+}
+
+// String 返回原始版本字符串
+func (x *MavenVersion) String() string {
+	return x.raw
+}
+
+// VersionNumbers 返回版本号开头连续的数字部分，去掉末尾的0但至少保留一位
+//
+// 例如 "1.0.0-M1" 返回 [1]，"2.3.1.Final" 返回 [2,3,1]，"0" 返回 [0]。
+// 去掉末尾的0是为了让 Maven 认为相等的版本（如 "1" 和 "1.0"）落在同一个版本组中。
+func (x *MavenVersion) VersionNumbers() VersionNumbers {
+	numbers := make([]int, 0, len(x.leadingNumbers))
+	for _, s := range x.leadingNumbers {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			break
+		}
+		numbers = append(numbers, n)
+	}
+	for len(numbers) > 1 && numbers[len(numbers)-1] == 0 {
+		numbers = numbers[:len(numbers)-1]
+	}
+	return numbers
+}
+
+// MavenScheme Maven 版本号方案
+//
+// 解析得到的 Version 中，VersionNumbers 为 MavenVersion.VersionNumbers()，Suffix 为数字部分之后的内容，
+// Detail 为 *MavenVersion。
+//
+// 使用示例:
+//
+//	mavenVersions, _ := versions.ParseAll(versions.SchemeMaven, "1.0-SNAPSHOT", "1.0", "1.0-rc1")
+//	sorted := versions.SortVersionSlice(mavenVersions)
+//	// 结果: ["1.0-rc1", "1.0-SNAPSHOT", "1.0"]
+type MavenScheme struct {
+}
+
+var _ Scheme = &MavenScheme{}
+
+func init() {
+	RegisterScheme(&MavenScheme{})
+}
+
+// Name 返回方案名称 SchemeMaven
+func (x *MavenScheme) Name() string {
+	return SchemeMaven
+}
+
+// Parse 按照 Maven 的规则解析版本号，版本号必须以数字开头
+func (x *MavenScheme) Parse(versionStr string) (*Version, error) {
+	versionStr = strings.TrimSpace(versionStr)
+	if err := x.Validate(versionStr); err != nil {
+		return nil, err
+	}
+	mv := ParseMavenVersion(versionStr)
+
+	// 数字部分为开头连续的数字和点号，末尾的点号属于后缀
+	end := 0
+	for end < len(versionStr) && (isASCIIDigit(versionStr[end]) || versionStr[end] == '.') {
+		end++
+	}
+	for end > 0 && versionStr[end-1] == '.' {
+		end--
+	}
+
+	return &Version{
+		Raw:            versionStr,
+		VersionNumbers: mv.VersionNumbers(),
+		NumbersRaw:     versionStr[:end],
+		Prefix:         EmptyVersionPrefix,
+		Suffix:         VersionSuffix(versionStr[end:]),
+		Scheme:         x,
+		Detail:         mv,
+	}, nil
+}
+
+// Validate Maven 本身接受任意字符串，这里要求版本号以数字开头，否则无法确定数字部分
+func (x *MavenScheme) Validate(versionStr string) error {
+	if versionStr == "" || !isASCIIDigit(versionStr[0]) {
+		return ErrVersionInvalid
+	}
+	return nil
+}
+
+// Compare 按照 Maven ComparableVersion 的规则比较两个版本
+func (x *MavenScheme) Compare(a, b *Version) int {
+	return mavenVersionOf(a).CompareTo(mavenVersionOf(b))
+}
+
+// Canonical 返回 ComparableVersion.getCanonical() 形式的字符串
+func (x *MavenScheme) Canonical(v *Version) string {
+	return mavenVersionOf(v).Canonical()
+}
+
+// mavenVersionOf 取出版本中缓存的 MavenVersion，没有的话则解析原始字符串
+func mavenVersionOf(v *Version) *MavenVersion {
+	if mv, ok := v.Detail.(*MavenVersion); ok {
+		return mv
+	}
+	return ParseMavenVersion(v.Raw)
+}
+
+// isASCIIDigit 判断字节是否是ASCII数字
+func isASCIIDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// indexOfString 返回字符串在切片中第一次出现的下标，不存在时返回-1
+func indexOfString(slice []string, s string) int {
+	for i, item := range slice {
+		if item == s {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,119 @@
+package versions
+
+import (
+	"testing"
+
+	"github.com/golang-infrastructure/go-shuffle"
+	"github.com/stretchr/testify/assert"
+)
+
+// mavenVersionsQualifier 来自 Maven ComparableVersionTest 的 VERSIONS_QUALIFIER，按从小到大排列
+var mavenVersionsQualifier = []string{
+	"1-alpha2snapshot", "1-alpha2", "1-alpha-123", "1-beta-2", "1-beta123", "1-m2", "1-m11", "1-rc", "1-cr2",
+	"1-rc123", "1-SNAPSHOT", "1", "1-sp", "1-sp2", "1-sp123", "1-abc", "1-def", "1-pom-1", "1-1-snapshot",
+	"1-1", "1-2", "1-123",
+}
+
+// mavenVersionsNumber 来自 Maven ComparableVersionTest 的 VERSIONS_NUMBER，按从小到大排列
+var mavenVersionsNumber = []string{
+	"2.0", "2-1", "2.0.a", "2.0.0.a", "2.0.2", "2.0.123", "2.1.0", "2.1-a", "2.1b", "2.1-c", "2.1-1", "2.1.0.1",
+	"2.2", "2.123", "11.a2", "11.a11", "11.b2", "11.b11", "11.m2", "11.m11", "11", "11.a", "11b", "11c", "11m",
+}
+
+// mavenVersionsEqual 来自 Maven ComparableVersionTest 的 testVersionsEqual，每一组中的版本都相等
+var mavenVersionsEqual = [][2]string{
+	{"1", "1"}, {"1", "1.0"}, {"1", "1.0.0"}, {"1.0", "1.0.0"}, {"1", "1-0"}, {"1", "1.0-0"}, {"1.0", "1.0-0"},
+	{"1a", "1-a"}, {"1a", "1.0-a"}, {"1a", "1.0.0-a"}, {"1.0a", "1-a"}, {"1.0.0a", "1-a"},
+	{"1x", "1-x"}, {"1x", "1.0-x"}, {"1x", "1.0.0-x"}, {"1.0x", "1-x"}, {"1.0.0x", "1-x"},
+	{"1ga", "1"}, {"1release", "1"}, {"1final", "1"}, {"1cr", "1rc"},
+	{"1a1", "1-alpha-1"}, {"1b2", "1-beta-2"}, {"1m3", "1-milestone-3"},
+	{"1X", "1x"}, {"1A", "1a"}, {"1B", "1b"}, {"1M", "1m"}, {"1Ga", "1"}, {"1GA", "1"}, {"1RELEASE", "1"},
+	{"1RELeaSE", "1"}, {"1Final", "1"}, {"1FinaL", "1"}, {"1Cr", "1Rc"}, {"1cR", "1rC"},
+	{"1m3", "1Milestone3"}, {"1m3", "1MileStone3"}, {"1m3", "1MILESTONE3"},
+}
+
+// TestMavenVersion_CompareTo 使用 Maven 自己的测试表验证比较规则
+func TestMavenVersion_CompareTo(t *testing.T) {
+	for _, ordered := range [][]string{mavenVersionsQualifier, mavenVersionsNumber} {
+		for i := 0; i < len(ordered); i++ {
+			for j := i + 1; j < len(ordered); j++ {
+				low := ParseMavenVersion(ordered[i])
+				high := ParseMavenVersion(ordered[j])
+				assert.True(t, low.CompareTo(high) < 0, "%s < %s", ordered[i], ordered[j])
+				assert.True(t, high.CompareTo(low) > 0, "%s > %s", ordered[j], ordered[i])
+			}
+		}
+	}
+	for _, pair := range mavenVersionsEqual {
+		assert.Equal(t, 0, ParseMavenVersion(pair[0]).CompareTo(ParseMavenVersion(pair[1])), "%s = %s", pair[0], pair[1])
+		assert.Equal(t, 0, ParseMavenVersion(pair[1]).CompareTo(ParseMavenVersion(pair[0])), "%s = %s", pair[1], pair[0])
+	}
+}
+
+// TestMavenVersion_Canonical 测试规范化字符串
+func TestMavenVersion_Canonical(t *testing.T) {
+	assert.Equal(t, "10-milestone-1", ParseMavenVersion("10.0.0-M1").Canonical())
+	assert.Equal(t, "1", ParseMavenVersion("1.0.0.Final").Canonical())
+	assert.Equal(t, "1.0.0.beta-1", ParseMavenVersion("1.0.0.Beta1").Canonical())
+	assert.Equal(t, "1.2.3-rc-1", ParseMavenVersion("1.2.3-CR1").Canonical())
+	assert.Equal(t, "1.0.0.a", ParseMavenVersion("1.0.0.a").String()) // String 返回原始字符串
+}
+
+// TestMavenScheme_Parse 测试 Maven 方案解析出的 Version
+func TestMavenScheme_Parse(t *testing.T) {
+	v, err := Parse("1.0.0.Beta1", SchemeMaven)
+	assert.Nil(t, err)
+	assert.Equal(t, VersionNumbers{1}, v.VersionNumbers)
+	assert.Equal(t, VersionSuffix(".Beta1"), v.Suffix)
+	assert.Equal(t, "1.0.0.beta-1", v.Canonical())
+
+	v, err = Parse("2.3.1.Final", SchemeMaven)
+	assert.Nil(t, err)
+	assert.Equal(t, VersionNumbers{2, 3, 1}, v.VersionNumbers)
+
+	v, err = Parse("0", SchemeMaven)
+	assert.Nil(t, err)
+	assert.Equal(t, VersionNumbers{0}, v.VersionNumbers)
+
+	_, err = Parse("RELEASE", SchemeMaven)
+	assert.Equal(t, ErrVersionInvalid, err)
+}
+
+// TestMavenScheme_SortTestData 使用 Maven 方案对 test_data 中的 Maven 制品版本排序
+func TestMavenScheme_SortTestData(t *testing.T) {
+	for _, file := range []string{
+		"./test_data/org.apache.tomcat_tomcat-juli.txt",
+		"./test_data/org.jboss_jboss-ejb-client.txt",
+		"./test_data/de.tum.in.ase_artemis-java-test-sandbox.txt",
+	} {
+		versionStrings, err := ReadVersionsStringFromFile(file)
+		assert.Nil(t, err)
+		mavenVersions, err := ParseAll(SchemeMaven, versionStrings...)
+		assert.Nil(t, err)
+		shuffle.Shuffle(mavenVersions)
+
+		sorted := SortVersionSlice(mavenVersions)
+		assert.Equal(t, len(mavenVersions), len(sorted))
+		for i := 1; i < len(sorted); i++ {
+			assert.True(t, sorted[i-1].CompareTo(sorted[i]) <= 0, "%s <= %s", sorted[i-1].Raw, sorted[i].Raw)
+		}
+	}
+
+	mavenVersions, err := ParseAll(SchemeMaven, "10.0.0", "10.0.0-M10", "10.0.0-M1", "10.0.0-M9", "9.0.70")
+	assert.Nil(t, err)
+	sorted := SortVersionSlice(mavenVersions)
+	raws := make([]string, 0)
+	for _, v := range sorted {
+		raws = append(raws, v.Raw)
+	}
+	assert.Equal(t, []string{"9.0.70", "10.0.0-M1", "10.0.0-M9", "10.0.0-M10", "10.0.0"}, raws)
+
+	mavenVersions, err = ParseAll(SchemeMaven, "1.0.0.Final", "1.0.0.Beta1", "1.0.0.Beta10", "1.0.0.CR1", "1.0.1.Final")
+	assert.Nil(t, err)
+	sorted = SortVersionSlice(mavenVersions)
+	raws = make([]string, 0)
+	for _, v := range sorted {
+		raws = append(raws, v.Raw)
+	}
+	assert.Equal(t, []string{"1.0.0.Beta1", "1.0.0.Beta10", "1.0.0.CR1", "1.0.0.Final", "1.0.1.Final"}, raws)
+}
@@ -0,0 +1,259 @@
+package versions
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// SchemeNuGet NuGet 版本号方案的名称
+//
+// 解析和比较规则与 NuGet.Versioning 的 NuGetVersion 保持一致
+const SchemeNuGet = "nuget"
+
+// NuGetVersion 表示一个 NuGet 的版本号
+//
+// 例如对于版本号 "1.2.3.4-Beta.2+abc"：
+// - Major、Minor、Patch、Revision 分别为 1、2、3、4
+// - Release 为 ["Beta", "2"]
+// - Metadata 为 "abc"
+type NuGetVersion struct {
+
+	// Major 主版本号
+	Major int
+
+	// Minor 次版本号
+	Minor int
+
+	// Patch 修订号
+	Patch int
+
+	// Revision 第四段版本号，没有写明时为0
+	Revision int
+
+	// Release 预发布标签，按点号切分，比较时不区分大小写
+	Release []string
+
+	// Metadata 构建元数据，比较时会被忽略
+	Metadata string
+
+	// NumberDigits 4段数字去掉前导0之后的十进制字符串，只有在某个数字超出 int 的范围时才有，
+	// 此时对应的数字为 math.MaxInt，比较时使用这里的精确数字
+	NumberDigits []string
+}
+
+// ParseNuGetVersion 按照 NuGetVersion.Parse 的规则解析版本号
+//
+// 数字部分可以有1到4段，省略的部分按0处理，数字允许有前导0；预发布标签和构建元数据的格式与 SemVer 2.0.0 相同。
+//
+// 参数:
+//   - versionStr: 要解析的版本号字符串，如 "1.0.0.1-beta"
+//
+// 返回:
+//   - *NuGetVersion: 解析结果
+//   - error: 版本号格式不正确时返回包装了 ErrVersionInvalid 的错误
+func ParseNuGetVersion(versionStr string) (*NuGetVersion, error) {
+	s := strings.TrimSpace(versionStr)
+	nv := &NuGetVersion{}
+	if i := strings.IndexByte(s, '+'); i >= 0 {
+		if _, err := splitSemVerIdentifiers(versionStr, s[i+1:], false); err != nil {
+			return nil, err
+		}
+		nv.Metadata = s[i+1:]
+		s = s[:i]
+	}
+	if i := strings.IndexByte(s, '-'); i >= 0 {
+		release, err := splitSemVerIdentifiers(versionStr, s[i+1:], true)
+		if err != nil {
+			return nil, err
+		}
+		nv.Release = release
+		s = s[:i]
+	}
+
+	parts := strings.Split(s, ".")
+	if len(parts) > 4 {
+		return nil, fmt.Errorf("%w: %q has more than four numeric parts", ErrVersionInvalid, versionStr)
+	}
+	numbers := make([]int, 4)
+	digits := []string{"0", "0", "0", "0"}
+	overflow := false
+	for i, part := range parts {
+		if !isNumericIdentifier(part) {
+			return nil, fmt.Errorf("%w: %q has invalid numeric part %q", ErrVersionInvalid, versionStr, part)
+		}
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			n, overflow = math.MaxInt, true
+		}
+		numbers[i] = n
+		digits[i] = trimLeadingZeros(part)
+	}
+	nv.Major, nv.Minor, nv.Patch, nv.Revision = numbers[0], numbers[1], numbers[2], numbers[3]
+	if overflow {
+		nv.NumberDigits = digits
+	}
+	return nv, nil
+}
+
+// IsPrerelease 判断是否是预发布版本
+func (x *NuGetVersion) IsPrerelease() bool {
+	return len(x.Release) > 0
+}
+
+// numbers 返回规范化之后的数字部分，第四段为0时省略
+func (x *NuGetVersion) numbers() []int {
+	if x.Revision != 0 {
+		return []int{x.Major, x.Minor, x.Patch, x.Revision}
+	}
+	return []int{x.Major, x.Minor, x.Patch}
+}
+
+// normalizedDigits 返回规范化之后的数字部分的十进制字符串，第四段为0时省略
+func (x *NuGetVersion) normalizedDigits() []string {
+	if x.NumberDigits == nil {
+		digits := make([]string, 0, 4)
+		for _, n := range x.numbers() {
+			digits = append(digits, strconv.Itoa(n))
+		}
+		return digits
+	}
+	if x.NumberDigits[3] != "0" {
+		return x.NumberDigits
+	}
+	return x.NumberDigits[:3]
+}
+
+// String 返回规范化的版本号，与 NuGetVersion.ToNormalizedString 一致，如 "1.0" 返回 "1.0.0"，"1.0.0.0" 返回 "1.0.0"
+func (x *NuGetVersion) String() string {
+	s := strings.Builder{}
+	s.WriteString(strings.Join(x.normalizedDigits(), "."))
+	if len(x.Release) > 0 {
+		s.WriteString("-")
+		s.WriteString(strings.Join(x.Release, "."))
+	}
+	if x.Metadata != "" {
+		s.WriteString("+")
+		s.WriteString(x.Metadata)
+	}
+	return s.String()
+}
+
+// CompareTo 按照 NuGet 的 VersionComparer.Default 比较两个版本
+//
+// 先比较4段数字，再比较预发布标签：没有预发布标签的版本更大，数字标签按数值比较，
+// 数字标签小于字母标签，字母标签不区分大小写比较。构建元数据被忽略。
+//
+// 参数:
+//   - target: 要比较的目标版本
+//
+// 返回:
+//   - int: 小于返回-1，等于返回0，大于返回1
+func (x *NuGetVersion) CompareTo(target *NuGetVersion) int {
+	if x.NumberDigits != nil || target.NumberDigits != nil {
+		if r := compareNumberDigits(x.normalizedDigits(), target.normalizedDigits()); r != 0 {
+			return r
+		}
+	} else if r := NewVersionNumbers([]int{x.Major, x.Minor, x.Patch, x.Revision}).CompareTo([]int{target.Major, target.Minor, target.Patch, target.Revision}); r != 0 {
+		return compareInt(r, 0)
+	}
+	return compareSemVerPrerelease(lowerIdentifiers(x.Release), lowerIdentifiers(target.Release))
+}
+
+// lowerIdentifiers 把标识符都转为小写，用于不区分大小写的比较
+func lowerIdentifiers(identifiers []string) []string {
+	if len(identifiers) == 0 {
+		return identifiers
+	}
+	lower := make([]string, len(identifiers))
+	for i, identifier := range identifiers {
+		lower[i] = strings.ToLower(identifier)
+	}
+	return lower
+}
+
+// NuGetScheme NuGet 版本号方案
+//
+// 解析得到的 Version 中，VersionNumbers 为规范化之后的数字部分（第四段为0时省略），
+// Suffix 为预发布和构建元数据部分，Detail 为 *NuGetVersion。
+//
+// 使用示例:
+//
+//	v1 := versions.MustParse("1.0.0-Beta", versions.SchemeNuGet)
+//	v2 := versions.MustParse("1.0.0.0-beta", versions.SchemeNuGet)
+//	fmt.Println(v1.CompareTo(v2)) // 输出: 0
+type NuGetScheme struct {
+}
+
+var _ Scheme = &NuGetScheme{}
+
+func init() {
+	RegisterScheme(&NuGetScheme{})
+}
+
+// Name 返回方案名称 SchemeNuGet
+func (x *NuGetScheme) Name() string {
+	return SchemeNuGet
+}
+
+// Parse 按照 NuGet 的规则解析版本号
+func (x *NuGetScheme) Parse(versionStr string) (*Version, error) {
+	nv, err := ParseNuGetVersion(versionStr)
+	if err != nil {
+		return nil, err
+	}
+	s := strings.TrimSpace(versionStr)
+	suffix := EmptyVersionSuffix
+	if i := strings.IndexAny(s, "-+"); i >= 0 {
+		suffix = VersionSuffix(s[i:])
+	}
+	v := &Version{
+		Raw:            versionStr,
+		VersionNumbers: NewVersionNumbers(nv.numbers()),
+		NumbersRaw:     s[:len(s)-len(suffix)],
+		Prefix:         EmptyVersionPrefix,
+		Suffix:         suffix,
+		Scheme:         x,
+		Detail:         nv,
+	}
+	if nv.NumberDigits != nil {
+		v.NumberDigits = nv.normalizedDigits()
+	}
+	return v, nil
+}
+
+// Validate 校验是否是合法的 NuGet 版本号
+func (x *NuGetScheme) Validate(versionStr string) error {
+	_, err := ParseNuGetVersion(versionStr)
+	return err
+}
+
+// Compare 按照 NuGet 的 VersionComparer.Default 比较两个版本
+func (x *NuGetScheme) Compare(a, b *Version) int {
+	na, nb := nuGetVersionOf(a), nuGetVersionOf(b)
+	if na == nil || nb == nil {
+		return compareGeneric(a, b)
+	}
+	return na.CompareTo(nb)
+}
+
+// Canonical 返回规范化的版本号
+func (x *NuGetScheme) Canonical(v *Version) string {
+	if nv := nuGetVersionOf(v); nv != nil {
+		return nv.String()
+	}
+	return v.Raw
+}
+
+// nuGetVersionOf 取出版本中缓存的 NuGetVersion，没有的话则重新解析，不是合法的 NuGet 版本号时返回nil
+func nuGetVersionOf(v *Version) *NuGetVersion {
+	if nv, ok := v.Detail.(*NuGetVersion); ok {
+		return nv
+	}
+	nv, err := ParseNuGetVersion(v.Raw)
+	if err != nil {
+		return nil
+	}
+	return nv
+}
@@ -0,0 +1,62 @@
+package versions
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang-infrastructure/go-shuffle"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseNuGetVersion 测试 NuGet 版本号的解析和规范化
+func TestParseNuGetVersion(t *testing.T) {
+	nv, err := ParseNuGetVersion("1.2.3.4-Beta.2+abc")
+	assert.Nil(t, err)
+	assert.Equal(t, &NuGetVersion{Major: 1, Minor: 2, Patch: 3, Revision: 4, Release: []string{"Beta", "2"}, Metadata: "abc"}, nv)
+	assert.True(t, nv.IsPrerelease())
+
+	normalized := map[string]string{
+		"1":            "1.0.0",
+		"1.0":          "1.0.0",
+		"1.0.0.0":      "1.0.0",
+		"01.002.3":     "1.2.3",
+		"1.0.0.1-rc.1": "1.0.0.1-rc.1",
+		"1.0.0-Beta+a": "1.0.0-Beta+a",
+	}
+	for raw, expected := range normalized {
+		assert.Equal(t, expected, MustParse(raw, SchemeNuGet).Canonical(), raw)
+	}
+
+	// 超出 int 范围的数字按照十进制字符串比较
+	huge := MustParse("1.0.099999999999999999999", SchemeNuGet)
+	assert.Equal(t, "1.0.99999999999999999999", huge.Canonical())
+	assert.Equal(t, []string{"1", "0", "99999999999999999999"}, huge.NumberDigits)
+	assert.Equal(t, 1, huge.CompareTo(MustParse("1.0.9223372036854775807.1", SchemeNuGet)))
+	assert.Equal(t, -1, huge.CompareTo(MustParse("1.1", SchemeNuGet)))
+	assert.Equal(t, 0, huge.CompareTo(MustParse("1.0.99999999999999999999.0", SchemeNuGet)))
+
+	for _, invalid := range []string{"", "1.2.3.4.5", "a.b", "1.0.0-", "1.0.0-beta..1", "1.0.0+", "-1.0"} {
+		_, err := Parse(invalid, SchemeNuGet)
+		assert.True(t, errors.Is(err, ErrVersionInvalid), invalid)
+	}
+}
+
+// TestNuGetScheme 测试 NuGet 方案的排序，第四段版本号参与比较，预发布标签不区分大小写
+func TestNuGetScheme(t *testing.T) {
+	v, err := Parse("1.0.0.1-rc.1", SchemeNuGet)
+	assert.Nil(t, err)
+	assert.Equal(t, SchemeNuGet, v.SchemeName())
+	assert.Equal(t, VersionNumbers{1, 0, 0, 1}, v.VersionNumbers)
+	assert.Equal(t, VersionSuffix("-rc.1"), v.Suffix)
+
+	assert.Equal(t, 0, MustParse("1.0.0-Beta", SchemeNuGet).CompareTo(MustParse("1.0.0.0-beta", SchemeNuGet)))
+	assert.Equal(t, 0, MustParse("1.0.0+a", SchemeNuGet).CompareTo(MustParse("1.0.0+b", SchemeNuGet)))
+
+	ordered := []string{"0.9", "1.0.0-alpha", "1.0.0-alpha.2", "1.0.0-alpha.10", "1.0.0-Beta", "1.0.0-rc.1", "1.0.0", "1.0.0.1", "1.0.1", "1.10.0"}
+	nuGetVersions, err := ParseAll(SchemeNuGet, ordered...)
+	assert.Nil(t, err)
+	shuffle.Shuffle(nuGetVersions)
+	for i, v := range SortVersionSlice(nuGetVersions) {
+		assert.Equal(t, ordered[i], v.Raw)
+	}
+}
@@ -0,0 +1,357 @@
+package versions
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// SchemePacman Arch Linux 软件包（pacman）版本号方案的名称
+//
+// 比较规则与 libalpm 的 alpm_pkg_vercmp（即 vercmp 命令）保持一致
+const SchemePacman = "pacman"
+
+// PacmanVersion 表示一个 pacman 软件包的 epoch:pkgver-pkgrel
+//
+// 例如对于版本号 "1:2.3.4-1"：
+// - Epoch 为 1
+// - Version 为 "2.3.4"
+// - Release 为 "1"
+type PacmanVersion struct {
+
+	// Epoch 纪元，没有写明时为0
+	Epoch int
+
+	// Version 上游版本号 pkgver
+	Version string
+
+	// Release 发行号 pkgrel，没有时为空字符串
+	Release string
+}
+
+// ParsePacmanVersion 解析 pacman 的版本号，解析规则与 libalpm 的 parseEVR 一致
+//
+// 开头连续的数字后面紧跟冒号时，这些数字是纪元；最后一个连字符之后的部分是发行号；
+// 剩下的部分是版本号。
+//
+// 参数:
+//   - versionStr: 要解析的版本号字符串，如 "1:2.3.4-1"
+//
+// 返回:
+//   - *PacmanVersion: 解析结果
+//   - error: 版本号为空或者纪元溢出时返回包装了 ErrVersionInvalid 的错误
+func ParsePacmanVersion(versionStr string) (*PacmanVersion, error) {
+	rv, err := ParseRPMVersion(versionStr)
+	if err != nil {
+		return nil, err
+	}
+	return &PacmanVersion{
+		Epoch:   rv.Epoch,
+		Version: rv.Version,
+		Release: rv.Release,
+	}, nil
+}
+
+// String 返回 [epoch:]pkgver[-pkgrel] 形式的字符串，纪元为0时省略
+func (x *PacmanVersion) String() string {
+	return (&RPMVersion{Epoch: x.Epoch, Version: x.Version, Release: x.Release}).String()
+}
+
+// CompareTo 按照 alpm_pkg_vercmp 的规则比较两个版本，依次比较纪元、版本号和发行号，
+// 只有两个版本都有发行号时才比较发行号，所以 "1.5" 和 "1.5-1" 相等
+//
+// 参数:
+//   - target: 要比较的目标版本
+//
+// 返回:
+//   - int: 小于返回-1，等于返回0，大于返回1
+func (x *PacmanVersion) CompareTo(target *PacmanVersion) int {
+	if r := compareInt(x.Epoch, target.Epoch); r != 0 {
+		return r
+	}
+	if r := PacmanVerCmp(x.Version, target.Version); r != 0 {
+		return r
+	}
+	if x.Release == "" || target.Release == "" {
+		return 0
+	}
+	return PacmanVerCmp(x.Release, target.Release)
+}
+
+// PacmanVerCmp libalpm 中的 rpmvercmp 算法，比较两个版本号或者发行号字符串
+//
+// 与 RPMVerCmp 的区别在于：不支持"~"和"^"，分隔符的个数不同时分隔符多的更大，
+// 以及版本号结束时剩下字母段的一方更小，如 "1.5b" < "1.5"，而 "1.5.a" > "1.5"。
+//
+// 参数:
+//   - a: 第一个字符串
+//   - b: 第二个字符串
+//
+// 返回:
+//   - int: a 小于 b 返回-1，相等返回0，大于返回1
+func PacmanVerCmp(a, b string) int {
+	if a == b {
+		return 0
+	}
+	at := func(s string, k int) byte {
+		if k < len(s) {
+			return s[k]
+		}
+		return 0
+	}
+
+	one, two := 0, 0
+	ptr1, ptr2 := 0, 0
+	for one < len(a) && two < len(b) {
+		for one < len(a) && !isASCIIAlnum(rune(a[one])) {
+			one++
+		}
+		for two < len(b) && !isASCIIAlnum(rune(b[two])) {
+			two++
+		}
+		if one >= len(a) || two >= len(b) {
+			break
+		}
+
+		// 分隔符的个数不同，分隔符多的更大
+		if r := compareInt(one-ptr1, two-ptr2); r != 0 {
+			return r
+		}
+
+		// 取出一个完整的数字段或者字母段
+		ptr1, ptr2 = one, two
+		isNum := isASCIIDigit(a[ptr1])
+		if isNum {
+			for ptr1 < len(a) && isASCIIDigit(a[ptr1]) {
+				ptr1++
+			}
+			for ptr2 < len(b) && isASCIIDigit(b[ptr2]) {
+				ptr2++
+			}
+		} else {
+			for ptr1 < len(a) && isASCIIAlpha(a[ptr1]) {
+				ptr1++
+			}
+			for ptr2 < len(b) && isASCIIAlpha(b[ptr2]) {
+				ptr2++
+			}
+		}
+
+		// 两段的类型不同，数字段更新
+		if two == ptr2 {
+			if isNum {
+				return 1
+			}
+			return -1
+		}
+
+		var r int
+		if isNum {
+			r = compareDigitString(a[one:ptr1], b[two:ptr2])
+		} else {
+			r = strings.Compare(a[one:ptr1], b[two:ptr2])
+		}
+		if r != 0 {
+			return r
+		}
+		one, two = ptr1, ptr2
+	}
+
+	// 所有的段都相同，只是分隔符不同
+	if one >= len(a) && two >= len(b) {
+		return 0
+	}
+
+	// 剩下的是字母段时更小，否则剩下内容的一方更大
+	if (one >= len(a) && !isASCIIAlpha(at(b, two))) || isASCIIAlpha(at(a, one)) {
+		return -1
+	}
+	return 1
+}
+
+// PacmanScheme Arch Linux 软件包版本号方案
+//
+// 解析得到的 Version 中，Epoch 为纪元，VersionNumbers 为版本号开头以单个分隔符分隔的数字，
+// Suffix 为版本号剩下的部分加上发行号，Detail 为 *PacmanVersion，可以通过 PacmanVersionOf 取出。
+// 版本控制系统打包的版本号（如 "r1234.5b55fc2"）不以数字开头，这时 VersionNumbers 为空。
+// 分组方式见 GroupNumbers，保证组的顺序与 vercmp 的顺序一致。
+//
+// 使用示例:
+//
+//	v1 := versions.MustParse("1:1.0-1", versions.SchemePacman)
+//	v2 := versions.MustParse("2.0-1", versions.SchemePacman)
+//	fmt.Println(v1.CompareTo(v2)) // 输出: 1
+type PacmanScheme struct {
+}
+
+var (
+	_ GroupingScheme   = &PacmanScheme{}
+	_ PrereleaseScheme = &PacmanScheme{}
+)
+
+func init() {
+	RegisterScheme(&PacmanScheme{})
+}
+
+// Name 返回方案名称 SchemePacman
+func (x *PacmanScheme) Name() string {
+	return SchemePacman
+}
+
+// Parse 解析 pacman 的版本号
+func (x *PacmanScheme) Parse(versionStr string) (*Version, error) {
+	pv, err := ParsePacmanVersion(versionStr)
+	if err != nil {
+		return nil, err
+	}
+	numbers, rest := splitPacmanLeadingNumbers(pv.Version)
+	suffix := rest
+	if pv.Release != "" {
+		suffix += "-" + pv.Release
+	}
+	return &Version{
+		Raw:            strings.TrimSpace(versionStr),
+		Epoch:          pv.Epoch,
+		VersionNumbers: numbers,
+		NumbersRaw:     pv.Version[:len(pv.Version)-len(rest)],
+		Prefix:         EmptyVersionPrefix,
+		Suffix:         VersionSuffix(suffix),
+		Scheme:         x,
+		Detail:         pv,
+	}, nil
+}
+
+// Validate 校验是否是合法的 pacman 版本号
+func (x *PacmanScheme) Validate(versionStr string) error {
+	_, err := ParsePacmanVersion(versionStr)
+	return err
+}
+
+// Compare 按照 alpm_pkg_vercmp 的规则比较两个版本
+func (x *PacmanScheme) Compare(a, b *Version) int {
+	pa, pb := PacmanVersionOf(a), PacmanVersionOf(b)
+	if pa == nil || pb == nil {
+		return compareGeneric(a, b)
+	}
+	return pa.CompareTo(pb)
+}
+
+// Canonical 返回 [epoch:]pkgver[-pkgrel] 形式的字符串
+func (x *PacmanScheme) Canonical(v *Version) string {
+	if pv := PacmanVersionOf(v); pv != nil {
+		return pv.String()
+	}
+	return v.Raw
+}
+
+// PrereleaseSuffix 返回紧跟在版本号后面的预发布后缀，如 "rc1"
+//
+// vercmp 不支持 "~"，"1.0~rc1" 反而比 "1.0" 大，只有版本号结束时剩下字母段的一方更小，所以写作 "1.0rc1"。
+func (x *PacmanScheme) PrereleaseSuffix(identifier string) VersionSuffix {
+	return VersionSuffix(identifier + "1")
+}
+
+// GroupNumbers 返回版本号开头以单个分隔符分隔的数字，用于分组
+//
+// vercmp 中分隔符多的一方更大，所以 "1..0" 比所有 "1.x" 形式的版本都大、又比 "2" 小。
+// 遇到后面还有内容的连续分隔符或者超出 int 范围的数字时，在已有的数字后面追加 math.MaxInt，
+// 如 "1..0" 的分组数字为 [1, math.MaxInt]，这样组的顺序与 Compare 的顺序一致。
+func (x *PacmanScheme) GroupNumbers(v *Version) VersionNumbers {
+	pv := PacmanVersionOf(v)
+	if pv == nil {
+		return v.VersionNumbers
+	}
+	return pacmanGroupNumbers(pv.Version)
+}
+
+// pacmanGroupNumbers 按照 vercmp 的规则切分出版本号开头用于分组的数字
+func pacmanGroupNumbers(s string) VersionNumbers {
+	numbers := make([]int, 0)
+	i := 0
+	for i < len(s) {
+		// 跳过分隔符，后面还有字母或数字并且分隔符不是恰好一个（开头则不能有分隔符）时，这个版本比同一前缀下的其它版本都大
+		j := i
+		for j < len(s) && !isASCIIAlnum(rune(s[j])) {
+			j++
+		}
+		if j >= len(s) {
+			break
+		}
+		separators := 1
+		if i == 0 {
+			separators = 0
+		}
+		if j-i != separators {
+			if j-i > separators {
+				numbers = append(numbers, math.MaxInt)
+			}
+			break
+		}
+		k := j
+		for k < len(s) && isASCIIDigit(s[k]) {
+			k++
+		}
+		if k == j {
+			break
+		}
+		n, err := strconv.Atoi(s[j:k])
+		if err != nil {
+			numbers = append(numbers, math.MaxInt)
+			break
+		}
+		numbers = append(numbers, n)
+		if k < len(s) && isASCIIAlpha(s[k]) {
+			break
+		}
+		i = k
+	}
+	return numbers
+}
+
+// PacmanVersionOf 取出版本对应的 epoch:pkgver-pkgrel，版本不是合法的 pacman 版本号时返回nil
+//
+// 参数:
+//   - v: 版本对象，通常由 pacman 方案解析得到
+//
+// 返回:
+//   - *PacmanVersion: 解析结果
+func PacmanVersionOf(v *Version) *PacmanVersion {
+	if pv, ok := v.Detail.(*PacmanVersion); ok {
+		return pv
+	}
+	pv, err := ParsePacmanVersion(v.Raw)
+	if err != nil {
+		return nil
+	}
+	return pv
+}
+
+// splitPacmanLeadingNumbers 切分出版本号开头以单个非字母数字字符分隔的数字段
+//
+// 遇到连续的分隔符时停止切分。注意 PacmanVerCmp 中分隔符多的一方更大（"1..5" > "1.6"），
+// 所以分组使用的是 GroupNumbers 而不是这里切分出来的数字。
+func splitPacmanLeadingNumbers(s string) (VersionNumbers, string) {
+	numbers := make([]int, 0)
+	end := 0
+	i := 0
+	for i < len(s) {
+		j := i
+		for j < len(s) && isASCIIDigit(s[j]) {
+			j++
+		}
+		if j == i {
+			break
+		}
+		n, err := strconv.Atoi(s[i:j])
+		if err != nil {
+			break
+		}
+		numbers = append(numbers, n)
+		end = j
+		if j+1 >= len(s) || isASCIIAlnum(rune(s[j])) || !isASCIIDigit(s[j+1]) {
+			break
+		}
+		i = j + 1
+	}
+	return numbers, s[end:]
+}
@@ -0,0 +1,164 @@
+package versions
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/golang-infrastructure/go-shuffle"
+	"github.com/stretchr/testify/assert"
+)
+
+// pacmanVerCmpCases 来自 pacman 项目 test/util/vercmptest.sh 的测试表
+var pacmanVerCmpCases = []struct {
+	a, b     string
+	expected int
+}{
+	// 长度相同，没有发行号
+	{"1.5.0", "1.5.0", 0},
+	{"1.5.1", "1.5.0", 1},
+
+	// 长度不同
+	{"1.5.1", "1.5", 1},
+
+	// 带发行号
+	{"1.5.0-1", "1.5.0-1", 0},
+	{"1.5.0-1", "1.5.0-2", -1},
+	{"1.5.0-1", "1.5.1-1", -1},
+	{"1.5.0-2", "1.5.1-1", -1},
+
+	// 带发行号，长度不同
+	{"1.5-1", "1.5.1-1", -1},
+	{"1.5-2", "1.5.1-1", -1},
+	{"1.5-2", "1.5.1-2", -1},
+
+	// 只有一方带发行号
+	{"1.5", "1.5-1", 0},
+	{"1.5-1", "1.5", 0},
+	{"1.1-1", "1.1", 0},
+	{"1.0-1", "1.1", -1},
+	{"1.1-1", "1.0", 1},
+
+	// 带字母的版本号
+	{"1.5b-1", "1.5-1", -1},
+	{"1.5b", "1.5", -1},
+	{"1.5b-1", "1.5", -1},
+	{"1.5b", "1.5.1", -1},
+
+	// vercmp 手册中的例子
+	{"1.0a", "1.0alpha", -1},
+	{"1.0alpha", "1.0b", -1},
+	{"1.0b", "1.0beta", -1},
+	{"1.0beta", "1.0rc", -1},
+	{"1.0rc", "1.0", -1},
+
+	// 点号分隔的字母
+	{"1.5.a", "1.5", 1},
+	{"1.5.b", "1.5.a", 1},
+	{"1.5.1", "1.5.b", 1},
+
+	// 点号分隔的字母加上发行号
+	{"1.5.b-1", "1.5.b", 0},
+	{"1.5-1", "1.5.b", -1},
+
+	// 内容相同但是分隔符不同
+	{"2.0", "2_0", 0},
+	{"2.0_a", "2_0.a", 0},
+	{"2.0a", "2.0.a", -1},
+	{"2___a", "2_a", 1},
+
+	// 带纪元
+	{"0:1.0", "0:1.0", 0},
+	{"0:1.0", "0:1.1", -1},
+	{"1:1.0", "0:1.0", 1},
+	{"1:1.0", "0:1.1", 1},
+	{"1:1.0", "2:1.1", -1},
+
+	// 带纪元，有时带发行号
+	{"1:1.0", "0:1.0-1", 1},
+	{"1:1.0-1", "0:1.1-1", 1},
+
+	// 只有一方带纪元
+	{"0:1.0", "1.0", 0},
+	{"0:1.0", "1.1", -1},
+	{"0:1.1", "1.0", 1},
+	{"1:1.0", "1.0", 1},
+	{"1:1.0", "1.1", 1},
+	{"1:1.1", "1.1", 1},
+}
+
+// TestPacmanVersion_CompareTo 使用 pacman 自己的测试表验证 alpm_pkg_vercmp 算法
+func TestPacmanVersion_CompareTo(t *testing.T) {
+	for _, c := range pacmanVerCmpCases {
+		a, err := ParsePacmanVersion(c.a)
+		assert.Nil(t, err, c.a)
+		b, err := ParsePacmanVersion(c.b)
+		assert.Nil(t, err, c.b)
+		assert.Equal(t, c.expected, a.CompareTo(b), "%s vs %s", c.a, c.b)
+		assert.Equal(t, -c.expected, b.CompareTo(a), "%s vs %s", c.b, c.a)
+	}
+}
+
+// TestParsePacmanVersion 测试 epoch:pkgver-pkgrel 的解析
+func TestParsePacmanVersion(t *testing.T) {
+	pv, err := ParsePacmanVersion("1:2.3.4-1")
+	assert.Nil(t, err)
+	assert.Equal(t, &PacmanVersion{Epoch: 1, Version: "2.3.4", Release: "1"}, pv)
+	assert.Equal(t, "1:2.3.4-1", pv.String())
+
+	pv, err = ParsePacmanVersion("r1234.5b55fc2-1")
+	assert.Nil(t, err)
+	assert.Equal(t, "r1234.5b55fc2", pv.Version)
+
+	for _, invalid := range []string{"", "1:", "-1"} {
+		_, err := ParsePacmanVersion(invalid)
+		assert.True(t, errors.Is(err, ErrVersionInvalid), invalid)
+	}
+}
+
+// TestPacmanScheme 测试 pacman 方案与 Version、分组和排序的集成
+func TestPacmanScheme(t *testing.T) {
+	v, err := Parse("1:2.3.4rc1-2", SchemePacman)
+	assert.Nil(t, err)
+	assert.Equal(t, SchemePacman, v.SchemeName())
+	assert.Equal(t, 1, v.Epoch)
+	assert.Equal(t, VersionNumbers{2, 3, 4}, v.VersionNumbers)
+	assert.Equal(t, VersionSuffix("rc1-2"), v.Suffix)
+	assert.Equal(t, "1:2.3.4", v.BuildGroupID())
+	assert.Equal(t, "2", PacmanVersionOf(v).Release)
+
+	vcs := MustParse("r1234.5b55fc2-1", SchemePacman)
+	assert.Equal(t, VersionNumbers{}, vcs.VersionNumbers)
+
+	ordered := []string{
+		"r1234.5b55fc2-1",
+		"1.0rc-1",
+		"1.0-1",
+		"1.0-2",
+		"1.0.a-1",
+		"1.0.1-1",
+		"1.5b-1",
+		"1.5-1",
+		"1.5.1-1",
+		"1..0-1",
+		"1..1-1",
+		"1...0-1",
+		"2_0a-1",
+		"2.0.1-1",
+		"1:0.1-1",
+	}
+	pacmanVersions, err := ParseAll(SchemePacman, ordered...)
+	assert.Nil(t, err)
+	for i := 0; i < 10; i++ {
+		shuffle.Shuffle(pacmanVersions)
+		assert.Equal(t, ordered, versionRaws(SortVersionSlice(pacmanVersions)))
+	}
+
+	// 分隔符多的版本更大，分组时排在同一前缀的其它组之后
+	assert.Equal(t, VersionNumbers{1, math.MaxInt}, groupNumbersOf(MustParse("1..0", SchemePacman)))
+	assert.Equal(t, VersionNumbers{1, 5}, groupNumbersOf(MustParse("1.5..", SchemePacman)))
+	assert.Equal(t, VersionNumbers{math.MaxInt}, groupNumbersOf(MustParse(".1", SchemePacman)))
+	assert.Equal(t, VersionNumbers{1, math.MaxInt}, groupNumbersOf(MustParse("1.99999999999999999999", SchemePacman)))
+	assertQueryMatcherMatchesFilter(t, SchemePacman, NewVersionInterval(MustParse("1.0", SchemePacman), ContainsPolicyNo, nil, ContainsPolicyNone),
+		"1", "1..0", "1.0", "1.0.0", "1.0.1", "2")
+}
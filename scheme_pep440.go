@@ -0,0 +1,410 @@
+package versions
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// SchemePEP440 Python 包使用的 PEP 440 版本号方案的名称
+//
+// 规范参见 https://peps.python.org/pep-0440/
+const SchemePEP440 = "pep440"
+
+// pep440Regexp 与 packaging.version.VERSION_PATTERN 等价的正则表达式，忽略大小写
+var pep440Regexp = regexp.MustCompile(`(?i)^\s*v?` +
+	`(?:(?P<epoch>[0-9]+)!)?` +
+	`(?P<release>[0-9]+(?:\.[0-9]+)*)` +
+	`(?P<pre>[-_.]?(?P<pre_l>alpha|a|beta|b|preview|pre|c|rc)[-_.]?(?P<pre_n>[0-9]+)?)?` +
+	`(?P<post>(?:-(?P<post_n1>[0-9]+))|(?:[-_.]?(?P<post_l>post|rev|r)[-_.]?(?P<post_n2>[0-9]+)?))?` +
+	`(?P<dev>[-_.]?(?P<dev_l>dev)[-_.]?(?P<dev_n>[0-9]+)?)?` +
+	`(?:\+(?P<local>[a-z0-9]+(?:[-_.][a-z0-9]+)*))?` +
+	`\s*$`)
+
+// pep440PreLabelAliases 预发布标签的规范化形式
+var pep440PreLabelAliases = map[string]string{
+	"a":       "a",
+	"alpha":   "a",
+	"b":       "b",
+	"beta":    "b",
+	"c":       "rc",
+	"rc":      "rc",
+	"pre":     "rc",
+	"preview": "rc",
+}
+
+// PEP440Version 表示一个按照 PEP 440 规范解析的版本号
+//
+// 一个完整的 PEP 440 版本号形如 "1!2.0rc1.post2.dev3+ubuntu.1"，其中：
+// - "1" 是 Epoch
+// - "2.0" 是 Release
+// - "rc1" 是预发布部分
+// - ".post2" 是后发布部分
+// - ".dev3" 是开发版本部分
+// - "ubuntu.1" 是本地版本标签
+type PEP440Version struct {
+
+	// Epoch 纪元，没有写明时为0
+	Epoch int
+
+	// Release 发布号的数字部分
+	Release []int
+
+	// PreLabel 规范化后的预发布标签，取值为 "a"、"b"、"rc"，不是预发布版本时为空字符串
+	PreLabel string
+
+	// PreNumber 预发布版本的序号
+	PreNumber int
+
+	// PostNumber 后发布版本的序号，不是后发布版本时为-1
+	PostNumber int
+
+	// DevNumber 开发版本的序号，不是开发版本时为-1
+	DevNumber int
+
+	// Local 本地版本标签，按分隔符切分并转为小写
+	Local []string
+}
+
+// ParsePEP440Version 按照 PEP 440 规范解析版本号
+//
+// 参数:
+//   - versionStr: 要解析的版本号字符串，如 "1!2.0.post1.dev3"、"1.0-RC1"
+//
+// 返回:
+//   - *PEP440Version: 解析结果
+//   - error: 版本号不符合规范时返回包装了 ErrVersionInvalid 的错误
+//
+// 使用示例:
+//
+//	pv, err := versions.ParsePEP440Version("1.0-RC1")
+//	if err != nil {
+//	    log.Fatalf("不是合法的PEP 440版本号: %v", err)
+//	}
+//	fmt.Println(pv.String()) // 输出: 1.0rc1
+func ParsePEP440Version(versionStr string) (*PEP440Version, error) {
+	match := pep440Regexp.FindStringSubmatch(versionStr)
+	if match == nil {
+		return nil, fmt.Errorf("%w: %q is not a valid PEP 440 version", ErrVersionInvalid, versionStr)
+	}
+	group := func(name string) string {
+		return match[pep440Regexp.SubexpIndex(name)]
+	}
+	atoi := func(s string) (int, error) {
+		if s == "" {
+			return 0, nil
+		}
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %q numeric part %q overflows", ErrVersionInvalid, versionStr, s)
+		}
+		return n, nil
+	}
+
+	pv := &PEP440Version{
+		PostNumber: -1,
+		DevNumber:  -1,
+	}
+	var err error
+	if pv.Epoch, err = atoi(group("epoch")); err != nil {
+		return nil, err
+	}
+	for _, part := range strings.Split(group("release"), ".") {
+		n, err := atoi(part)
+		if err != nil {
+			return nil, err
+		}
+		pv.Release = append(pv.Release, n)
+	}
+	if group("pre") != "" {
+		pv.PreLabel = pep440PreLabelAliases[strings.ToLower(group("pre_l"))]
+		if pv.PreNumber, err = atoi(group("pre_n")); err != nil {
+			return nil, err
+		}
+	}
+	if group("post") != "" {
+		if pv.PostNumber, err = atoi(group("post_n1") + group("post_n2")); err != nil {
+			return nil, err
+		}
+	}
+	if group("dev") != "" {
+		if pv.DevNumber, err = atoi(group("dev_n")); err != nil {
+			return nil, err
+		}
+	}
+	if local := group("local"); local != "" {
+		pv.Local = strings.FieldsFunc(strings.ToLower(local), func(c rune) bool {
+			return c == '-' || c == '_' || c == '.'
+		})
+	}
+	return pv, nil
+}
+
+// IsPrerelease 是否是预发布版本，开发版本也被认为是预发布版本
+func (x *PEP440Version) IsPrerelease() bool {
+	return x.PreLabel != "" || x.DevNumber >= 0
+}
+
+// IsPostRelease 是否是后发布版本
+func (x *PEP440Version) IsPostRelease() bool {
+	return x.PostNumber >= 0
+}
+
+// String 返回规范化的版本字符串，与 packaging.version.Version 的 str() 一致
+func (x *PEP440Version) String() string {
+	s := strings.Builder{}
+	if x.Epoch != 0 {
+		s.WriteString(strconv.Itoa(x.Epoch))
+		s.WriteString("!")
+	}
+	s.WriteString(NewVersionNumbers(x.Release).BuildGroupID())
+	if x.PreLabel != "" {
+		s.WriteString(x.PreLabel)
+		s.WriteString(strconv.Itoa(x.PreNumber))
+	}
+	if x.PostNumber >= 0 {
+		s.WriteString(".post")
+		s.WriteString(strconv.Itoa(x.PostNumber))
+	}
+	if x.DevNumber >= 0 {
+		s.WriteString(".dev")
+		s.WriteString(strconv.Itoa(x.DevNumber))
+	}
+	if len(x.Local) > 0 {
+		s.WriteString("+")
+		s.WriteString(strings.Join(x.Local, "."))
+	}
+	return s.String()
+}
+
+// CompareTo 按照 PEP 440 规范比较两个版本
+//
+// 比较规则:
+// 1. 先比较 Epoch
+// 2. 再比较 Release，末尾的0不影响比较结果
+// 3. 然后依次比较预发布、后发布、开发版本部分：
+//   - 只有开发版本部分的版本（如 1.0.dev1）排在所有预发布版本之前
+//   - 没有预发布部分的版本排在预发布版本之后
+//   - 没有后发布部分的版本排在后发布版本之前
+//   - 没有开发版本部分的版本排在开发版本之后
+//
+// 4. 最后比较本地版本标签，有标签的排在没有标签的之后，数字段按数值比较并且大于字母段
+//
+// 参数:
+//   - target: 要比较的目标版本
+//
+// 返回:
+//   - int: 小于返回-1，等于返回0，大于返回1
+func (x *PEP440Version) CompareTo(target *PEP440Version) int {
+	if r := compareInt(x.Epoch, target.Epoch); r != 0 {
+		return r
+	}
+	if r := compareTrimmedNumbers(x.Release, target.Release); r != 0 {
+		return r
+	}
+	if r := x.comparePre(target); r != 0 {
+		return r
+	}
+	// 没有后发布部分时为-1，正好排在所有后发布版本之前
+	if r := compareInt(x.PostNumber, target.PostNumber); r != 0 {
+		return r
+	}
+	if r := compareOptionalLast(x.DevNumber, target.DevNumber); r != 0 {
+		return r
+	}
+	return comparePEP440Local(x.Local, target.Local)
+}
+
+// pep440PreRank 把预发布部分转换为可以比较的排名：只有开发版本的最小，没有预发布部分的最大
+func (x *PEP440Version) pep440PreRank() int {
+	switch {
+	case x.PreLabel == "" && x.PostNumber < 0 && x.DevNumber >= 0:
+		return 0
+	case x.PreLabel == "a":
+		return 1
+	case x.PreLabel == "b":
+		return 2
+	case x.PreLabel == "rc":
+		return 3
+	default:
+		return 4
+	}
+}
+
+// comparePre 比较预发布部分
+func (x *PEP440Version) comparePre(target *PEP440Version) int {
+	if r := compareInt(x.pep440PreRank(), target.pep440PreRank()); r != 0 {
+		return r
+	}
+	if x.PreLabel != "" {
+		return compareInt(x.PreNumber, target.PreNumber)
+	}
+	return 0
+}
+
+// compareOptionalLast 比较两个可选的序号，-1 表示不存在并且排在所有存在的序号之后
+func compareOptionalLast(a, b int) int {
+	if a < 0 && b < 0 {
+		return 0
+	} else if a < 0 {
+		return 1
+	} else if b < 0 {
+		return -1
+	}
+	return compareInt(a, b)
+}
+
+// compareTrimmedNumbers 去掉末尾的0之后比较两个数字序列
+func compareTrimmedNumbers(a, b []int) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var l, r int
+		if i < len(a) {
+			l = a[i]
+		}
+		if i < len(b) {
+			r = b[i]
+		}
+		if c := compareInt(l, r); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// comparePEP440Local 比较本地版本标签
+func comparePEP440Local(a, b []string) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		aNumeric, bNumeric := isNumericIdentifier(a[i]), isNumericIdentifier(b[i])
+		var r int
+		switch {
+		case aNumeric && bNumeric:
+			r = compareDigitString(a[i], b[i])
+		case aNumeric:
+			r = 1
+		case bNumeric:
+			r = -1
+		default:
+			r = strings.Compare(a[i], b[i])
+		}
+		if r != 0 {
+			return r
+		}
+	}
+	return compareInt(len(a), len(b))
+}
+
+// PEP440Scheme PEP 440 版本号方案
+//
+// 解析得到的 Version 中，VersionNumbers 为去掉末尾0的 Release，Suffix 为发布号之后的原始内容，
+// Detail 为 *PEP440Version，Canonical() 返回规范化后的版本字符串。
+//
+// 使用示例:
+//
+//	v := versions.MustParse("1.0-RC1", versions.SchemePEP440)
+//	fmt.Println(v.Canonical()) // 输出: 1.0rc1
+type PEP440Scheme struct {
+}
+
+var (
+	_ EpochSeparatorScheme = &PEP440Scheme{}
+	_ PrereleaseScheme     = &PEP440Scheme{}
+)
+
+func init() {
+	RegisterScheme(&PEP440Scheme{})
+}
+
+// Name 返回方案名称 SchemePEP440
+func (x *PEP440Scheme) Name() string {
+	return SchemePEP440
+}
+
+// EpochSeparator 返回纪元的分隔符 "!"
+func (x *PEP440Scheme) EpochSeparator() string {
+	return "!"
+}
+
+// Parse 按照 PEP 440 规范解析版本号
+func (x *PEP440Scheme) Parse(versionStr string) (*Version, error) {
+	pv, err := ParsePEP440Version(versionStr)
+	if err != nil {
+		return nil, err
+	}
+	raw := strings.TrimSpace(versionStr)
+
+	prefix := EmptyVersionPrefix
+	rest := raw
+	if len(rest) > 0 && (rest[0] == 'v' || rest[0] == 'V') {
+		prefix = VersionPrefix(rest[:1])
+		rest = rest[1:]
+	}
+	if i := strings.IndexByte(rest, '!'); i >= 0 {
+		rest = rest[i+1:]
+	}
+
+	// 发布号是 Epoch 之后连续的数字和点号，末尾的点号属于后缀
+	end := 0
+	for end < len(rest) && (isASCIIDigit(rest[end]) || rest[end] == '.') {
+		end++
+	}
+	for end > 0 && rest[end-1] == '.' {
+		end--
+	}
+
+	numbers := append([]int{}, pv.Release...)
+	for len(numbers) > 1 && numbers[len(numbers)-1] == 0 {
+		numbers = numbers[:len(numbers)-1]
+	}
+
+	return &Version{
+		Raw:            raw,
+		Epoch:          pv.Epoch,
+		VersionNumbers: numbers,
+		NumbersRaw:     rest[:end],
+		Prefix:         prefix,
+		Suffix:         VersionSuffix(rest[end:]),
+		Scheme:         x,
+		Detail:         pv,
+	}, nil
+}
+
+// Validate 校验是否是合法的 PEP 440 版本号
+func (x *PEP440Scheme) Validate(versionStr string) error {
+	_, err := ParsePEP440Version(versionStr)
+	return err
+}
+
+// Compare 按照 PEP 440 规范比较两个版本
+func (x *PEP440Scheme) Compare(a, b *Version) int {
+	pa, pb := pep440VersionOf(a), pep440VersionOf(b)
+	if pa == nil || pb == nil {
+		return compareGeneric(a, b)
+	}
+	return pa.CompareTo(pb)
+}
+
+// Canonical 返回规范化后的版本字符串，如 "1.0-RC1" 返回 "1.0rc1"
+func (x *PEP440Scheme) Canonical(v *Version) string {
+	if pv := pep440VersionOf(v); pv != nil {
+		return pv.String()
+	}
+	return v.Raw
+}
+
+// PrereleaseSuffix 返回紧跟在版本号后面的预发布后缀，如 "rc1"，限定词只能是 a、b、rc 以及它们的别名
+func (x *PEP440Scheme) PrereleaseSuffix(identifier string) VersionSuffix {
+	return VersionSuffix(identifier + "1")
+}
+
+// pep440VersionOf 取出版本中缓存的 PEP440Version，没有的话则解析原始字符串，无法解析时返回nil
+func pep440VersionOf(v *Version) *PEP440Version {
+	if pv, ok := v.Detail.(*PEP440Version); ok {
+		return pv
+	}
+	pv, err := ParsePEP440Version(v.Raw)
+	if err != nil {
+		return nil
+	}
+	return pv
+}
@@ -0,0 +1,116 @@
+package versions
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang-infrastructure/go-shuffle"
+	"github.com/stretchr/testify/assert"
+)
+
+// pep440OrderedVersions 来自 packaging 项目 tests/test_version.py 的 VERSIONS，按从小到大排列
+var pep440OrderedVersions = []string{
+	// 隐式的 epoch 0
+	"1.0.dev456", "1.0a1", "1.0a2.dev456", "1.0a12.dev456", "1.0a12", "1.0b1.dev456", "1.0b2",
+	"1.0b2.post345.dev456", "1.0b2.post345", "1.0b2-346", "1.0c1.dev456", "1.0c1", "1.0rc2", "1.0c3", "1.0",
+	"1.0.post456.dev34", "1.0.post456", "1.1.dev1", "1.2+123abc", "1.2+123abc456", "1.2+abc", "1.2+abc123",
+	"1.2+abc123def", "1.2+1234.abc", "1.2+123456", "1.2.r32+123abc", "1.2.rev33+123abc",
+	// 显式的 epoch 1
+	"1!1.0.dev456", "1!1.0a1", "1!1.0a2.dev456", "1!1.0a12.dev456", "1!1.0a12", "1!1.0b1.dev456", "1!1.0b2",
+	"1!1.0b2.post345.dev456", "1!1.0b2.post345", "1!1.0b2-346", "1!1.0c1.dev456", "1!1.0c1", "1!1.0rc2",
+	"1!1.0c3", "1!1.0", "1!1.0.post456.dev34", "1!1.0.post456", "1!1.1.dev1", "1!1.2+123abc", "1!1.2+123abc456",
+	"1!1.2+abc", "1!1.2+abc123", "1!1.2+abc123def", "1!1.2+1234.abc", "1!1.2+123456", "1!1.2.r32+123abc",
+	"1!1.2.rev33+123abc",
+}
+
+// TestPEP440Version_CompareTo 使用 packaging 的测试表验证比较规则
+func TestPEP440Version_CompareTo(t *testing.T) {
+	for i := 0; i < len(pep440OrderedVersions); i++ {
+		for j := 0; j < len(pep440OrderedVersions); j++ {
+			a, err := ParsePEP440Version(pep440OrderedVersions[i])
+			assert.Nil(t, err)
+			b, err := ParsePEP440Version(pep440OrderedVersions[j])
+			assert.Nil(t, err)
+			assert.Equal(t, compareInt(i, j), a.CompareTo(b), "%s vs %s", pep440OrderedVersions[i], pep440OrderedVersions[j])
+		}
+	}
+
+	// 末尾的0不影响比较
+	a, _ := ParsePEP440Version("1.0")
+	b, _ := ParsePEP440Version("1.0.0")
+	assert.Equal(t, 0, a.CompareTo(b))
+}
+
+// TestParsePEP440Version 测试解析与规范化
+func TestParsePEP440Version(t *testing.T) {
+	normalized := map[string]string{
+		"1.0-RC1":           "1.0rc1",
+		"1.0rc1":            "1.0rc1",
+		"2.0.0a1":           "2.0.0a1",
+		"1.0alpha1":         "1.0a1",
+		"1.0.BETA.2":        "1.0b2",
+		"1.0c1":             "1.0rc1",
+		"1.0preview1":       "1.0rc1",
+		"1.0a":              "1.0a0",
+		"1!2.0.post1.dev3":  "1!2.0.post1.dev3",
+		"1.0-1":             "1.0.post1",
+		"1.0.rev2":          "1.0.post2",
+		"1.0-r":             "1.0.post0",
+		"1.0-dev":           "1.0.dev0",
+		"1.0+ubuntu-1":      "1.0+ubuntu.1",
+		"1.0+Ubuntu_1":      "1.0+ubuntu.1",
+		"v1.0":              "1.0",
+		"  1.0  ":           "1.0",
+		"1.0.0+local.7.abc": "1.0.0+local.7.abc",
+	}
+	for input, expected := range normalized {
+		pv, err := ParsePEP440Version(input)
+		assert.Nil(t, err, input)
+		assert.Equal(t, expected, pv.String(), input)
+	}
+
+	pv, err := ParsePEP440Version("1!2.0rc1.post2.dev3+ubuntu1")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, pv.Epoch)
+	assert.Equal(t, []int{2, 0}, pv.Release)
+	assert.Equal(t, "rc", pv.PreLabel)
+	assert.Equal(t, 1, pv.PreNumber)
+	assert.Equal(t, 2, pv.PostNumber)
+	assert.Equal(t, 3, pv.DevNumber)
+	assert.Equal(t, []string{"ubuntu1"}, pv.Local)
+	assert.True(t, pv.IsPrerelease())
+	assert.True(t, pv.IsPostRelease())
+
+	for _, invalid := range []string{"", "french toast", "1.0+", "1.0++1", "1.0-", "1..0", "1.0.post.a"} {
+		_, err := ParsePEP440Version(invalid)
+		assert.True(t, errors.Is(err, ErrVersionInvalid), invalid)
+	}
+}
+
+// TestPEP440Scheme_Parse 测试 PEP 440 方案解析出的 Version 并排序
+func TestPEP440Scheme_Parse(t *testing.T) {
+	v, err := Parse("1.0-RC1", SchemePEP440)
+	assert.Nil(t, err)
+	assert.Equal(t, "1.0rc1", v.Canonical())
+	assert.Equal(t, VersionNumbers{1}, v.VersionNumbers)
+	assert.Equal(t, VersionSuffix("-RC1"), v.Suffix)
+
+	v, err = Parse("v2.0.0a1", SchemePEP440)
+	assert.Nil(t, err)
+	assert.Equal(t, VersionPrefix("v"), v.Prefix)
+	assert.Equal(t, VersionNumbers{2}, v.VersionNumbers)
+	assert.Equal(t, VersionSuffix("a1"), v.Suffix)
+
+	_, err = Parse("1.0-foo", SchemePEP440)
+	assert.True(t, errors.Is(err, ErrVersionInvalid))
+
+	// 不带 epoch 的版本排序
+	ordered := pep440OrderedVersions[:27]
+	pythonVersions, err := ParseAll(SchemePEP440, ordered...)
+	assert.Nil(t, err)
+	shuffle.Shuffle(pythonVersions)
+	sorted := SortVersionSlice(pythonVersions)
+	for i, v := range sorted {
+		assert.Equal(t, ordered[i], v.Raw)
+	}
+}
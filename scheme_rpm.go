@@ -0,0 +1,368 @@
+package versions
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SchemeRPM RPM 软件包版本号方案的名称
+//
+// 比较规则与 rpm 的 rpmvercmp 保持一致
+const SchemeRPM = "rpm"
+
+// RPMVersion 表示一个 RPM 软件包的 EVR（epoch:version-release）三元组
+//
+// 例如对于版本号 "2:1.8.3-12.el8_4.1"：
+// - Epoch 为 2
+// - Version 为 "1.8.3"
+// - Release 为 "12.el8_4.1"
+type RPMVersion struct {
+
+	// Epoch 纪元，没有写明时为0
+	Epoch int
+
+	// Version 上游版本号
+	Version string
+
+	// Release 发行号，没有时为空字符串
+	Release string
+}
+
+// ParseRPMVersion 解析 RPM 的 EVR 字符串，解析规则与 rpm 的 parseEVR 一致
+//
+// 开头连续的数字后面紧跟冒号时，这些数字是纪元；最后一个连字符之后的部分是发行号；
+// 剩下的部分是版本号。
+//
+// 参数:
+//   - versionStr: 要解析的版本号字符串，如 "2:1.8.3-12.el8_4.1"
+//
+// 返回:
+//   - *RPMVersion: 解析结果
+//   - error: 版本号为空或者纪元溢出时返回包装了 ErrVersionInvalid 的错误
+func ParseRPMVersion(versionStr string) (*RPMVersion, error) {
+	s := strings.TrimSpace(versionStr)
+	if s == "" {
+		return nil, fmt.Errorf("%w: version string is empty", ErrVersionInvalid)
+	}
+
+	rv := &RPMVersion{}
+	i := 0
+	for i < len(s) && isASCIIDigit(s[i]) {
+		i++
+	}
+	if i < len(s) && s[i] == ':' {
+		if i > 0 {
+			epoch, err := strconv.Atoi(s[:i])
+			if err != nil {
+				return nil, fmt.Errorf("%w: %q epoch overflows", ErrVersionInvalid, versionStr)
+			}
+			rv.Epoch = epoch
+		}
+		s = s[i+1:]
+	}
+	if j := strings.LastIndexByte(s, '-'); j >= 0 {
+		rv.Release = s[j+1:]
+		s = s[:j]
+	}
+	rv.Version = s
+	if rv.Version == "" {
+		return nil, fmt.Errorf("%w: %q has empty version", ErrVersionInvalid, versionStr)
+	}
+	return rv, nil
+}
+
+// String 返回 [epoch:]version[-release] 形式的字符串，纪元为0时省略
+func (x *RPMVersion) String() string {
+	s := strings.Builder{}
+	if x.Epoch != 0 {
+		s.WriteString(strconv.Itoa(x.Epoch))
+		s.WriteString(":")
+	}
+	s.WriteString(x.Version)
+	if x.Release != "" {
+		s.WriteString("-")
+		s.WriteString(x.Release)
+	}
+	return s.String()
+}
+
+// CompareTo 按照 rpm 的规则比较两个 EVR，依次比较纪元、版本号和发行号，
+// 与 rpm 一样只有两个版本都有发行号时才比较发行号，所以 "1.0" 和 "1.0-1" 相等
+//
+// 参数:
+//   - target: 要比较的目标版本
+//
+// 返回:
+//   - int: 小于返回-1，等于返回0，大于返回1
+//
+// 使用示例:
+//
+//	a, _ := versions.ParseRPMVersion("1.0^git20200101")
+//	b, _ := versions.ParseRPMVersion("1.0")
+//	fmt.Println(a.CompareTo(b)) // 输出: 1
+func (x *RPMVersion) CompareTo(target *RPMVersion) int {
+	if r := compareInt(x.Epoch, target.Epoch); r != 0 {
+		return r
+	}
+	if r := RPMVerCmp(x.Version, target.Version); r != 0 {
+		return r
+	}
+	if x.Release == "" || target.Release == "" {
+		return 0
+	}
+	return RPMVerCmp(x.Release, target.Release)
+}
+
+// RPMVerCmp rpm 的 rpmvercmp 算法，比较两个版本号或者发行号字符串
+//
+// 字符串被拆分为连续的数字段或字母段，其它字符只起到分隔的作用：
+// - 数字段按数值比较，字母段按字典序比较，数字段总是比字母段新
+// - "~"排在一切之前，常用于预发布版本，如 "1.0~rc1" < "1.0"
+// - "^"排在一切之后但是在更长的版本号之前，常用于快照版本，如 "1.0" < "1.0^git1" < "1.0.1"
+//
+// 参数:
+//   - a: 第一个字符串
+//   - b: 第二个字符串
+//
+// 返回:
+//   - int: a 小于 b 返回-1，相等返回0，大于返回1
+func RPMVerCmp(a, b string) int {
+	if a == b {
+		return 0
+	}
+	at := func(s string, k int) byte {
+		if k < len(s) {
+			return s[k]
+		}
+		return 0
+	}
+	isSeparator := func(c byte) bool {
+		return c != 0 && !isASCIIAlnum(rune(c)) && c != '~' && c != '^'
+	}
+
+	one, two := 0, 0
+	for one < len(a) || two < len(b) {
+		for isSeparator(at(a, one)) {
+			one++
+		}
+		for isSeparator(at(b, two)) {
+			two++
+		}
+
+		// 波浪号排在一切之前
+		c1, c2 := at(a, one), at(b, two)
+		if c1 == '~' || c2 == '~' {
+			if c1 != '~' {
+				return 1
+			}
+			if c2 != '~' {
+				return -1
+			}
+			one++
+			two++
+			continue
+		}
+
+		// 脱字符与波浪号类似，但是已经结束的版本号排在它之前
+		if c1 == '^' || c2 == '^' {
+			if c1 == 0 {
+				return -1
+			}
+			if c2 == 0 {
+				return 1
+			}
+			if c1 != '^' {
+				return 1
+			}
+			if c2 != '^' {
+				return -1
+			}
+			one++
+			two++
+			continue
+		}
+
+		if c1 == 0 || c2 == 0 {
+			break
+		}
+
+		// 取出一个完整的数字段或者字母段
+		end1, end2 := one, two
+		isNum := isASCIIDigit(c1)
+		if isNum {
+			for end1 < len(a) && isASCIIDigit(a[end1]) {
+				end1++
+			}
+			for end2 < len(b) && isASCIIDigit(b[end2]) {
+				end2++
+			}
+		} else {
+			for end1 < len(a) && isASCIIAlpha(a[end1]) {
+				end1++
+			}
+			for end2 < len(b) && isASCIIAlpha(b[end2]) {
+				end2++
+			}
+		}
+
+		// 两段的类型不同，数字段更新
+		if two == end2 {
+			if isNum {
+				return 1
+			}
+			return -1
+		}
+
+		var r int
+		if isNum {
+			r = compareDigitString(a[one:end1], b[two:end2])
+		} else {
+			r = strings.Compare(a[one:end1], b[two:end2])
+		}
+		if r != 0 {
+			return r
+		}
+		one, two = end1, end2
+	}
+
+	if one >= len(a) && two >= len(b) {
+		return 0
+	}
+	// 还有剩余字符的版本号更新
+	if one >= len(a) {
+		return -1
+	}
+	return 1
+}
+
+// RPMScheme RPM 软件包版本号方案
+//
+// 解析得到的 Version 中，Epoch 为纪元，VersionNumbers 为版本号开头的数字段，Suffix 为版本号剩下的部分加上发行号，
+// Detail 为 *RPMVersion，可以通过 RPMVersionOf 取出 EVR 三元组。
+//
+// 使用示例:
+//
+//	v := versions.MustParse("2:1.8.3-12.el8_4.1", versions.SchemeRPM)
+//	evr := versions.RPMVersionOf(v)
+//	fmt.Println(evr.Epoch, evr.Version, evr.Release) // 输出: 2 1.8.3 12.el8_4.1
+type RPMScheme struct {
+}
+
+var _ PrereleaseScheme = &RPMScheme{}
+
+func init() {
+	RegisterScheme(&RPMScheme{})
+}
+
+// Name 返回方案名称 SchemeRPM
+func (x *RPMScheme) Name() string {
+	return SchemeRPM
+}
+
+// Parse 解析 RPM 的 EVR 字符串，版本号必须以数字开头
+func (x *RPMScheme) Parse(versionStr string) (*Version, error) {
+	rv, err := ParseRPMVersion(versionStr)
+	if err != nil {
+		return nil, err
+	}
+	numbers, rest := splitRPMLeadingNumbers(rv.Version)
+	if len(numbers) == 0 {
+		return nil, fmt.Errorf("%w: %q version does not start with digit", ErrVersionInvalid, versionStr)
+	}
+	suffix := rest
+	if rv.Release != "" {
+		suffix += "-" + rv.Release
+	}
+	return &Version{
+		Raw:            strings.TrimSpace(versionStr),
+		Epoch:          rv.Epoch,
+		VersionNumbers: numbers,
+		NumbersRaw:     rv.Version[:len(rv.Version)-len(rest)],
+		Prefix:         EmptyVersionPrefix,
+		Suffix:         VersionSuffix(suffix),
+		Scheme:         x,
+		Detail:         rv,
+	}, nil
+}
+
+// Validate 校验是否是合法的 RPM 版本号
+func (x *RPMScheme) Validate(versionStr string) error {
+	_, err := x.Parse(versionStr)
+	return err
+}
+
+// Compare 按照 rpm 的规则比较两个版本
+func (x *RPMScheme) Compare(a, b *Version) int {
+	ra, rb := RPMVersionOf(a), RPMVersionOf(b)
+	if ra == nil || rb == nil {
+		return compareGeneric(a, b)
+	}
+	return ra.CompareTo(rb)
+}
+
+// Canonical 返回 [epoch:]version[-release] 形式的字符串
+func (x *RPMScheme) Canonical(v *Version) string {
+	if rv := RPMVersionOf(v); rv != nil {
+		return rv.String()
+	}
+	return v.Raw
+}
+
+// PrereleaseSuffix 返回 "~" 开头的预发布后缀，如 "~rc1"，与 Fedora 打包规范中预发布版本的写法一致
+func (x *RPMScheme) PrereleaseSuffix(identifier string) VersionSuffix {
+	return VersionSuffix("~" + identifier + "1")
+}
+
+// RPMVersionOf 取出版本对应的 EVR 三元组，版本不是合法的 RPM 版本号时返回nil
+//
+// 参数:
+//   - v: 版本对象，通常由 RPM 方案解析得到
+//
+// 返回:
+//   - *RPMVersion: EVR 三元组
+func RPMVersionOf(v *Version) *RPMVersion {
+	if rv, ok := v.Detail.(*RPMVersion); ok {
+		return rv
+	}
+	rv, err := ParseRPMVersion(v.Raw)
+	if err != nil {
+		return nil
+	}
+	return rv
+}
+
+// splitRPMLeadingNumbers 切分出版本号开头的数字段，任何非字母数字并且不是"~^"的字符都被当作分隔符
+//
+// 例如 "2_0.1a" 返回 [2,0,1] 和 "a"，遇到字母、"~"、"^"时停止，保证分组的顺序与 rpmvercmp 一致。
+func splitRPMLeadingNumbers(s string) (VersionNumbers, string) {
+	numbers := make([]int, 0)
+	end := 0
+	i := 0
+	for i < len(s) {
+		j := i
+		for j < len(s) && isASCIIDigit(s[j]) {
+			j++
+		}
+		if j == i {
+			break
+		}
+		n, err := strconv.Atoi(s[i:j])
+		if err != nil {
+			break
+		}
+		numbers = append(numbers, n)
+		end = j
+
+		// 跳过分隔符，分隔符后面必须还是数字才继续
+		k := j
+		for k < len(s) && !isASCIIAlnum(rune(s[k])) && s[k] != '~' && s[k] != '^' {
+			k++
+		}
+		if k == j || k >= len(s) || !isASCIIDigit(s[k]) {
+			break
+		}
+		i = k
+	}
+	return numbers, s[end:]
+}
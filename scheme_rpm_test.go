@@ -0,0 +1,205 @@
+package versions
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang-infrastructure/go-shuffle"
+	"github.com/stretchr/testify/assert"
+)
+
+// rpmVerCmpCases 来自 rpm 项目 tests/rpmvercmp.at 的测试表
+var rpmVerCmpCases = []struct {
+	a, b     string
+	expected int
+}{
+	{"1.0", "1.0", 0},
+	{"1.0", "2.0", -1},
+	{"2.0", "1.0", 1},
+	{"2.0.1", "2.0.1", 0},
+	{"2.0", "2.0.1", -1},
+	{"2.0.1", "2.0", 1},
+	{"2.0.1a", "2.0.1a", 0},
+	{"2.0.1a", "2.0.1", 1},
+	{"2.0.1", "2.0.1a", -1},
+	{"5.5p1", "5.5p1", 0},
+	{"5.5p1", "5.5p2", -1},
+	{"5.5p2", "5.5p1", 1},
+	{"5.5p10", "5.5p10", 0},
+	{"5.5p1", "5.5p10", -1},
+	{"5.5p10", "5.5p1", 1},
+	{"10xyz", "10.1xyz", -1},
+	{"10.1xyz", "10xyz", 1},
+	{"xyz10", "xyz10", 0},
+	{"xyz10", "xyz10.1", -1},
+	{"xyz10.1", "xyz10", 1},
+	{"xyz.4", "xyz.4", 0},
+	{"xyz.4", "8", -1},
+	{"8", "xyz.4", 1},
+	{"xyz.4", "2", -1},
+	{"2", "xyz.4", 1},
+	{"5.5p2", "5.6p1", -1},
+	{"5.6p1", "5.5p2", 1},
+	{"5.6p1", "6.5p1", -1},
+	{"6.5p1", "5.6p1", 1},
+	{"6.0.rc1", "6.0", 1},
+	{"6.0", "6.0.rc1", -1},
+	{"10b2", "10a1", 1},
+	{"10a2", "10b2", -1},
+	{"1.0aa", "1.0aa", 0},
+	{"1.0a", "1.0aa", -1},
+	{"1.0aa", "1.0a", 1},
+	{"10.0001", "10.0001", 0},
+	{"10.0001", "10.1", 0},
+	{"10.1", "10.0001", 0},
+	{"10.0001", "10.0039", -1},
+	{"10.0039", "10.0001", 1},
+	{"4.999.9", "5.0", -1},
+	{"5.0", "4.999.9", 1},
+	{"20101121", "20101121", 0},
+	{"20101121", "20101122", -1},
+	{"20101122", "20101121", 1},
+	{"2_0", "2_0", 0},
+	{"2.0", "2_0", 0},
+	{"2_0", "2.0", 0},
+	{"a", "a", 0},
+	{"a+", "a+", 0},
+	{"a+", "a_", 0},
+	{"a_", "a+", 0},
+	{"+a", "+a", 0},
+	{"+a", "_a", 0},
+	{"_a", "+a", 0},
+	{"+_", "+_", 0},
+	{"_+", "+_", 0},
+	{"_+", "_", 0},
+	{"+", "_", 0},
+	{"_", "+", 0},
+	{"1.0~rc1", "1.0~rc1", 0},
+	{"1.0~rc1", "1.0", -1},
+	{"1.0", "1.0~rc1", 1},
+	{"1.0~rc1", "1.0~rc2", -1},
+	{"1.0~rc2", "1.0~rc1", 1},
+	{"1.0~rc1~git123", "1.0~rc1~git123", 0},
+	{"1.0~rc1~git123", "1.0~rc1", -1},
+	{"1.0~rc1", "1.0~rc1~git123", 1},
+	{"1.0^", "1.0^", 0},
+	{"1.0^", "1.0", 1},
+	{"1.0", "1.0^", -1},
+	{"1.0^git1", "1.0^git1", 0},
+	{"1.0^git1", "1.0", 1},
+	{"1.0", "1.0^git1", -1},
+	{"1.0^git1", "1.0^git2", -1},
+	{"1.0^git2", "1.0^git1", 1},
+	{"1.0^git1", "1.01", -1},
+	{"1.01", "1.0^git1", 1},
+	{"1.0^20160101", "1.0^20160101", 0},
+	{"1.0^20160101", "1.0.1", -1},
+	{"1.0.1", "1.0^20160101", 1},
+	{"1.0^20160101^git1", "1.0^20160101^git1", 0},
+	{"1.0^20160102", "1.0^20160101^git1", 1},
+	{"1.0^20160101^git1", "1.0^20160102", -1},
+	{"1.0~rc1^git1", "1.0~rc1^git1", 0},
+	{"1.0~rc1^git1", "1.0~rc1", 1},
+	{"1.0~rc1", "1.0~rc1^git1", -1},
+	{"1.0^git1~pre", "1.0^git1~pre", 0},
+	{"1.0^git1", "1.0^git1~pre", 1},
+	{"1.0^git1~pre", "1.0^git1", -1},
+	{"1b.fc17", "1b.fc17", 0},
+	{"1b.fc17", "1.fc17", -1},
+	{"1.fc17", "1b.fc17", 1},
+	{"1g.fc17", "1g.fc17", 0},
+	{"1g.fc17", "1.fc17", 1},
+	{"1.fc17", "1g.fc17", -1},
+}
+
+// TestRPMVerCmp 使用 rpm 自己的测试表验证 rpmvercmp 算法
+func TestRPMVerCmp(t *testing.T) {
+	for _, c := range rpmVerCmpCases {
+		assert.Equal(t, c.expected, RPMVerCmp(c.a, c.b), "%s vs %s", c.a, c.b)
+	}
+}
+
+// TestParseRPMVersion 测试 EVR 的解析与比较
+func TestParseRPMVersion(t *testing.T) {
+	rv, err := ParseRPMVersion("2:1.8.3-12.el8_4.1")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, rv.Epoch)
+	assert.Equal(t, "1.8.3", rv.Version)
+	assert.Equal(t, "12.el8_4.1", rv.Release)
+	assert.Equal(t, "2:1.8.3-12.el8_4.1", rv.String())
+
+	rv, err = ParseRPMVersion("1.0^git20200101")
+	assert.Nil(t, err)
+	assert.Equal(t, 0, rv.Epoch)
+	assert.Equal(t, "1.0^git20200101", rv.Version)
+	assert.Equal(t, "", rv.Release)
+
+	rv, err = ParseRPMVersion(":1.0-1")
+	assert.Nil(t, err)
+	assert.Equal(t, 0, rv.Epoch)
+	assert.Equal(t, "1.0", rv.Version)
+
+	for _, invalid := range []string{"", "1:", "-1", "99999999999999999999:1.0"} {
+		_, err := ParseRPMVersion(invalid)
+		assert.True(t, errors.Is(err, ErrVersionInvalid), invalid)
+	}
+
+	a, _ := ParseRPMVersion("1:1.0-1")
+	b, _ := ParseRPMVersion("2.0-1")
+	assert.Equal(t, 1, a.CompareTo(b))
+	a, _ = ParseRPMVersion("1.0-1.el8")
+	b, _ = ParseRPMVersion("1.0-1.el8_4.1")
+	assert.Equal(t, -1, a.CompareTo(b))
+
+	// 只有一边有发行号时不比较发行号
+	a, _ = ParseRPMVersion("1.0")
+	b, _ = ParseRPMVersion("1.0-1")
+	assert.Equal(t, 0, a.CompareTo(b))
+	assert.Equal(t, 0, b.CompareTo(a))
+	b, _ = ParseRPMVersion("1.0.1-1")
+	assert.Equal(t, -1, a.CompareTo(b))
+}
+
+// TestRPMScheme_Parse 测试 RPM 方案与 Version、分组和排序的集成
+func TestRPMScheme_Parse(t *testing.T) {
+	v, err := Parse("2:1.8.3-12.el8_4.1", SchemeRPM)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, v.Epoch)
+	assert.Equal(t, VersionNumbers{1, 8, 3}, v.VersionNumbers)
+	assert.Equal(t, VersionSuffix("-12.el8_4.1"), v.Suffix)
+	assert.Equal(t, "2:1.8.3", v.BuildGroupID())
+	evr := RPMVersionOf(v)
+	assert.Equal(t, "12.el8_4.1", evr.Release)
+
+	_, err = Parse("git1.0", SchemeRPM)
+	assert.True(t, errors.Is(err, ErrVersionInvalid))
+
+	ordered := []string{
+		"1.0~rc1-1",
+		"1.0-1",
+		"1.0-1.el8",
+		"1.0^git20200101-1",
+		"1.0.1-1",
+		"2_1-1",
+		"2.1.1-1",
+		"1:1.0-1",
+	}
+	rpmVersions, err := ParseAll(SchemeRPM, ordered...)
+	assert.Nil(t, err)
+	shuffle.Shuffle(rpmVersions)
+	sorted := SortVersionSlice(rpmVersions)
+	for i, v := range sorted {
+		assert.Equal(t, ordered[i], v.Raw)
+	}
+}
+
+// TestSplitRPMLeadingNumbers 测试切分开头的数字段
+func TestSplitRPMLeadingNumbers(t *testing.T) {
+	numbers, rest := splitRPMLeadingNumbers("2_0.1a")
+	assert.Equal(t, VersionNumbers{2, 0, 1}, numbers)
+	assert.Equal(t, "a", rest)
+
+	numbers, rest = splitRPMLeadingNumbers("1.0^git1")
+	assert.Equal(t, VersionNumbers{1, 0}, numbers)
+	assert.Equal(t, "^git1", rest)
+}
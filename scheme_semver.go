@@ -0,0 +1,355 @@
+package versions
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// SchemeSemVer 严格的 SemVer 2.0.0 版本号方案的名称
+//
+// 规范参见 https://semver.org/spec/v2.0.0.html
+const SchemeSemVer = "semver"
+
+// SemVer 表示一个按照 SemVer 2.0.0 规范解析出来的版本号
+//
+// 例如对于版本号 "1.0.0-rc.1+build.5"：
+// - Major、Minor、Patch 分别为 1、0、0
+// - Prerelease 为 ["rc", "1"]
+// - Build 为 ["build", "5"]
+type SemVer struct {
+
+	// Major 主版本号
+	Major int
+
+	// Minor 次版本号
+	Minor int
+
+	// Patch 修订号
+	Patch int
+
+	// Prerelease 预发布版本的标识符，按点号切分
+	Prerelease []string
+
+	// Build 构建元数据的标识符，按点号切分，比较时会被忽略
+	Build []string
+
+	// NumberDigits 主版本号、次版本号和修订号的十进制字符串，只有在某个数字超出 int 的范围时才有，
+	// 此时 Major、Minor 或 Patch 为 math.MaxInt，比较时使用这里的精确数字
+	NumberDigits []string
+}
+
+// ParseSemVer 按照 SemVer 2.0.0 规范解析版本号字符串
+//
+// 与 VersionStringParser 不同，该方法是严格的：不允许前缀、不允许数字部分有前导0、
+// 必须恰好有三段数字，预发布标识符和构建元数据只能包含 [0-9A-Za-z-]。
+//
+// 参数:
+//   - versionStr: 要解析的版本号字符串，如 "1.0.0-rc.1+build.5"
+//
+// 返回:
+//   - *SemVer: 解析结果
+//   - error: 版本号不符合规范时返回包装了 ErrVersionInvalid 的错误
+//
+// 使用示例:
+//
+//	sv, err := versions.ParseSemVer("1.0.0-rc.1+build.5")
+//	if err != nil {
+//	    log.Fatalf("不是合法的SemVer: %v", err)
+//	}
+//	fmt.Println(sv.Prerelease) // 输出: [rc 1]
+func ParseSemVer(versionStr string) (*SemVer, error) {
+	s := versionStr
+
+	// 先把构建元数据切下来，构建元数据中允许出现"-"，所以要先处理"+"
+	var build []string
+	if i := strings.IndexByte(s, '+'); i >= 0 {
+		var err error
+		build, err = splitSemVerIdentifiers(versionStr, s[i+1:], false)
+		if err != nil {
+			return nil, err
+		}
+		s = s[:i]
+	}
+
+	var prerelease []string
+	if i := strings.IndexByte(s, '-'); i >= 0 {
+		var err error
+		prerelease, err = splitSemVerIdentifiers(versionStr, s[i+1:], true)
+		if err != nil {
+			return nil, err
+		}
+		s = s[:i]
+	}
+
+	parts := strings.Split(s, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: %q must have exactly three numeric parts", ErrVersionInvalid, versionStr)
+	}
+	numbers := make([]int, 3)
+	overflow := false
+	for i, part := range parts {
+		if !isNumericIdentifier(part) {
+			return nil, fmt.Errorf("%w: %q has invalid numeric part %q", ErrVersionInvalid, versionStr, part)
+		}
+		if len(part) > 1 && part[0] == '0' {
+			return nil, fmt.Errorf("%w: %q has leading zero in %q", ErrVersionInvalid, versionStr, part)
+		}
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			// 规范没有限制数字的大小，超出 int 范围的数字按照十进制字符串比较
+			n, overflow = math.MaxInt, true
+		}
+		numbers[i] = n
+	}
+
+	sv := &SemVer{
+		Major:      numbers[0],
+		Minor:      numbers[1],
+		Patch:      numbers[2],
+		Prerelease: prerelease,
+		Build:      build,
+	}
+	if overflow {
+		sv.NumberDigits = parts
+	}
+	return sv, nil
+}
+
+// splitSemVerIdentifiers 把预发布或构建元数据部分按点号切分为标识符并校验
+func splitSemVerIdentifiers(versionStr, s string, isPrerelease bool) ([]string, error) {
+	identifiers := strings.Split(s, ".")
+	for _, identifier := range identifiers {
+		if identifier == "" {
+			return nil, fmt.Errorf("%w: %q has empty identifier", ErrVersionInvalid, versionStr)
+		}
+		for _, c := range identifier {
+			if !isSemVerIdentifierChar(c) {
+				return nil, fmt.Errorf("%w: %q has illegal character %q", ErrVersionInvalid, versionStr, c)
+			}
+		}
+		// 预发布版本中的数字标识符不允许有前导0，构建元数据则没有这个限制
+		if isPrerelease && len(identifier) > 1 && identifier[0] == '0' && isNumericIdentifier(identifier) {
+			return nil, fmt.Errorf("%w: %q has leading zero in %q", ErrVersionInvalid, versionStr, identifier)
+		}
+	}
+	return identifiers, nil
+}
+
+// isSemVerIdentifierChar 判断是否是标识符中允许出现的字符 [0-9A-Za-z-]
+func isSemVerIdentifierChar(c rune) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c == '-'
+}
+
+// isNumericIdentifier 判断是否是非空的纯数字标识符
+func isNumericIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// IsPrerelease 是否是预发布版本
+func (x *SemVer) IsPrerelease() bool {
+	return len(x.Prerelease) > 0
+}
+
+// numberDigits 返回主版本号、次版本号和修订号的十进制字符串
+func (x *SemVer) numberDigits() []string {
+	if x.NumberDigits != nil {
+		return x.NumberDigits
+	}
+	return []string{strconv.Itoa(x.Major), strconv.Itoa(x.Minor), strconv.Itoa(x.Patch)}
+}
+
+// String 返回 SemVer 的字符串表示，包括构建元数据
+func (x *SemVer) String() string {
+	s := strings.Builder{}
+	s.WriteString(strings.Join(x.numberDigits(), "."))
+	if len(x.Prerelease) > 0 {
+		s.WriteString("-")
+		s.WriteString(strings.Join(x.Prerelease, "."))
+	}
+	if len(x.Build) > 0 {
+		s.WriteString("+")
+		s.WriteString(strings.Join(x.Build, "."))
+	}
+	return s.String()
+}
+
+// CompareTo 按照 SemVer 2.0.0 规范的优先级比较两个版本，构建元数据不参与比较
+//
+// 比较规则:
+// 1. 依次比较主版本号、次版本号、修订号
+// 2. 预发布版本的优先级低于对应的正式版本
+// 3. 预发布标识符逐个比较：纯数字的按数值比较，否则按ASCII字典序比较，数字标识符低于非数字标识符
+// 4. 所有前面的标识符都相等时，标识符多的优先级更高
+//
+// 参数:
+//   - target: 要比较的目标版本
+//
+// 返回:
+//   - int: 小于返回-1，等于返回0，大于返回1
+func (x *SemVer) CompareTo(target *SemVer) int {
+	if x.NumberDigits != nil || target.NumberDigits != nil {
+		if r := compareNumberDigits(x.numberDigits(), target.numberDigits()); r != 0 {
+			return r
+		}
+		return compareSemVerPrerelease(x.Prerelease, target.Prerelease)
+	}
+	if r := compareInt(x.Major, target.Major); r != 0 {
+		return r
+	}
+	if r := compareInt(x.Minor, target.Minor); r != 0 {
+		return r
+	}
+	if r := compareInt(x.Patch, target.Patch); r != 0 {
+		return r
+	}
+	return compareSemVerPrerelease(x.Prerelease, target.Prerelease)
+}
+
+// compareSemVerPrerelease 按照 SemVer 规范比较两个预发布标识符列表
+func compareSemVerPrerelease(a, b []string) int {
+	// 没有预发布部分的版本优先级更高
+	if len(a) == 0 || len(b) == 0 {
+		return compareInt(len(b), len(a))
+	}
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if r := compareSemVerIdentifier(a[i], b[i]); r != 0 {
+			return r
+		}
+	}
+	return compareInt(len(a), len(b))
+}
+
+// compareSemVerIdentifier 比较单个预发布标识符
+func compareSemVerIdentifier(a, b string) int {
+	aNumeric, bNumeric := isNumericIdentifier(a), isNumericIdentifier(b)
+	switch {
+	case aNumeric && bNumeric:
+		return compareDigitString(a, b)
+	case aNumeric:
+		return -1
+	case bNumeric:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+// compareDigitString 比较两个纯数字字符串表示的数值大小，不会溢出
+func compareDigitString(a, b string) int {
+	a = strings.TrimLeft(a, "0")
+	b = strings.TrimLeft(b, "0")
+	if len(a) != len(b) {
+		return compareInt(len(a), len(b))
+	}
+	return strings.Compare(a, b)
+}
+
+// compareInt 比较两个整数，小于返回-1，等于返回0，大于返回1
+func compareInt(a, b int) int {
+	if a < b {
+		return -1
+	} else if a > b {
+		return 1
+	}
+	return 0
+}
+
+// SemVerScheme 严格的 SemVer 2.0.0 版本号方案
+//
+// 解析得到的 Version 中，VersionNumbers 为 [Major, Minor, Patch]，Suffix 为预发布和构建元数据部分
+// （如 "-rc.1+build.5"），Detail 为 *SemVer。
+//
+// 使用示例:
+//
+//	v1 := versions.MustParse("1.0.0-rc.2", versions.SchemeSemVer)
+//	v2 := versions.MustParse("1.0.0-rc.10", versions.SchemeSemVer)
+//	fmt.Println(v1.CompareTo(v2)) // 输出: -1
+type SemVerScheme struct {
+}
+
+var _ Scheme = &SemVerScheme{}
+
+func init() {
+	RegisterScheme(&SemVerScheme{})
+}
+
+// Name 返回方案名称 SchemeSemVer
+func (x *SemVerScheme) Name() string {
+	return SchemeSemVer
+}
+
+// Parse 按照 SemVer 2.0.0 规范解析版本号
+func (x *SemVerScheme) Parse(versionStr string) (*Version, error) {
+	sv, err := ParseSemVer(versionStr)
+	if err != nil {
+		return nil, err
+	}
+	// 数字部分只会包含数字和点号，因此第一个"-"或"+"就是后缀的开始
+	suffix := EmptyVersionSuffix
+	if i := strings.IndexAny(versionStr, "-+"); i >= 0 {
+		suffix = VersionSuffix(versionStr[i:])
+	}
+	return &Version{
+		Raw:            versionStr,
+		VersionNumbers: NewVersionNumbers([]int{sv.Major, sv.Minor, sv.Patch}),
+		NumberDigits:   sv.NumberDigits,
+		NumbersRaw:     versionStr[:len(versionStr)-len(suffix)],
+		Prefix:         EmptyVersionPrefix,
+		Suffix:         suffix,
+		Scheme:         x,
+		Detail:         sv,
+	}, nil
+}
+
+// Validate 校验是否是合法的 SemVer 2.0.0 版本号
+func (x *SemVerScheme) Validate(versionStr string) error {
+	_, err := ParseSemVer(versionStr)
+	return err
+}
+
+// Compare 按照 SemVer 2.0.0 规范的优先级比较两个版本
+func (x *SemVerScheme) Compare(a, b *Version) int {
+	return semVerOf(a).CompareTo(semVerOf(b))
+}
+
+// Canonical 返回 SemVer 的字符串表示
+func (x *SemVerScheme) Canonical(v *Version) string {
+	return semVerOf(v).String()
+}
+
+// semVerOf 取出版本中缓存的 SemVer 结构，没有的话则根据版本的各部分构造
+func semVerOf(v *Version) *SemVer {
+	if sv, ok := v.Detail.(*SemVer); ok {
+		return sv
+	}
+	if sv, err := ParseSemVer(v.Raw); err == nil {
+		return sv
+	}
+	// 不是合法的SemVer时尽量从已经解析出来的各个部分构造
+	numbers := make([]int, 3)
+	copy(numbers, v.VersionNumbers)
+	sv := &SemVer{
+		Major: numbers[0],
+		Minor: numbers[1],
+		Patch: numbers[2],
+	}
+	if v.NumberDigits != nil {
+		sv.NumberDigits = append(make([]string, 0, 3), v.NumberDigits...)
+		for len(sv.NumberDigits) < 3 {
+			sv.NumberDigits = append(sv.NumberDigits, "0")
+		}
+		sv.NumberDigits = sv.NumberDigits[:3]
+	}
+	sv.Prerelease, sv.Build = v.Suffix.SemVerParts()
+	return sv
+}
@@ -0,0 +1,176 @@
+package versions
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang-infrastructure/go-shuffle"
+	"github.com/stretchr/testify/assert"
+)
+
+// semVerValidCorpus 来自 semver.org 官方正则测试用例中的合法版本号
+var semVerValidCorpus = []string{
+	"0.0.4",
+	"1.2.3",
+	"10.20.30",
+	"1.1.2-prerelease+meta",
+	"1.1.2+meta",
+	"1.1.2+meta-valid",
+	"1.0.0-alpha",
+	"1.0.0-beta",
+	"1.0.0-alpha.beta",
+	"1.0.0-alpha.beta.1",
+	"1.0.0-alpha.1",
+	"1.0.0-alpha0.valid",
+	"1.0.0-alpha.0valid",
+	"1.0.0-alpha-a.b-c-somethinglong+build.1-aef.1-its-okay",
+	"1.0.0-rc.1+build.1",
+	"2.0.0-rc.1+build.123",
+	"1.2.3-beta",
+	"10.2.3-DEV-SNAPSHOT",
+	"1.2.3-SNAPSHOT-123",
+	"1.0.0",
+	"2.0.0",
+	"1.1.7",
+	"2.0.0+build.1848",
+	"2.0.1-alpha.1227",
+	"1.0.0-alpha+beta",
+	"1.2.3----RC-SNAPSHOT.12.9.1--.12+788",
+	"1.2.3----R-S.12.9.1--.12+meta",
+	"1.2.3----RC-SNAPSHOT.12.9.1--.12",
+	"1.0.0+0.build.1-rc.10000aaa-kk-0.1",
+	"1.0.0-0A.is.legal",
+	"99999999999999999999999.999999999999999999.99999999999999999",
+}
+
+// semVerInvalidCorpus 来自 semver.org 官方正则测试用例中的非法版本号
+var semVerInvalidCorpus = []string{
+	"1",
+	"1.2",
+	"1.2.3-0123",
+	"1.2.3-0123.0123",
+	"1.1.2+.123",
+	"+invalid",
+	"-invalid",
+	"-invalid+invalid",
+	"-invalid.01",
+	"alpha",
+	"alpha.beta",
+	"alpha.beta.1",
+	"alpha.1",
+	"alpha+beta",
+	"alpha_beta",
+	"alpha.",
+	"alpha..",
+	"beta",
+	"1.0.0-alpha_beta",
+	"-alpha.",
+	"1.0.0-alpha..",
+	"1.0.0-alpha..1",
+	"1.0.0-alpha...1",
+	"1.0.0-alpha....1",
+	"1.0.0-alpha.....1",
+	"1.0.0-alpha......1",
+	"1.0.0-alpha.......1",
+	"01.1.1",
+	"1.01.1",
+	"1.1.01",
+	"1.2",
+	"1.2.3.DEV",
+	"1.2-SNAPSHOT",
+	"1.2.31.2.3----RC-SNAPSHOT.12.09.1--..12+788",
+	"1.2-RC-SNAPSHOT",
+	"-1.0.3-gamma+b7718",
+	"+justmeta",
+	"9.8.7+meta+meta",
+	"9.8.7-whatever+meta+meta",
+	"99999999999999999999999.999999999999999999.99999999999999999----RC-SNAPSHOT.12.09.1--------------------------------..12",
+}
+
+// TestParseSemVer 使用官方测试用例验证 SemVer 的解析和校验
+func TestParseSemVer(t *testing.T) {
+	for _, s := range semVerValidCorpus {
+		sv, err := ParseSemVer(s)
+		assert.Nil(t, err, s)
+		if err == nil {
+			assert.Equal(t, s, sv.String())
+		}
+	}
+	for _, s := range semVerInvalidCorpus {
+		_, err := ParseSemVer(s)
+		assert.True(t, errors.Is(err, ErrVersionInvalid), s)
+	}
+
+	sv, err := ParseSemVer("1.0.0-rc.1+build.5")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, sv.Major)
+	assert.Equal(t, []string{"rc", "1"}, sv.Prerelease)
+	assert.Equal(t, []string{"build", "5"}, sv.Build)
+	assert.True(t, sv.IsPrerelease())
+}
+
+// TestSemVer_CompareTo 测试 SemVer 规范中的优先级示例
+func TestSemVer_CompareTo(t *testing.T) {
+	ordered := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0-rc.2",
+		"1.0.0-rc.10",
+		"1.0.0",
+		"2.0.0",
+		"2.1.0",
+		"2.1.1",
+	}
+	for i := 0; i < len(ordered); i++ {
+		for j := 0; j < len(ordered); j++ {
+			a := MustParse(ordered[i], SchemeSemVer)
+			b := MustParse(ordered[j], SchemeSemVer)
+			assert.Equal(t, compareInt(i, j), a.CompareTo(b), "%s vs %s", ordered[i], ordered[j])
+		}
+	}
+
+	// 超出 int 范围的数字按照十进制字符串比较
+	huge := []string{"1.0.0", "9223372036854775807.0.0", "9223372036854775808.0.0-rc.1", "9223372036854775808.0.0", "99999999999999999999999.999999999999999999.99999999999999999"}
+	for i := 0; i < len(huge); i++ {
+		for j := 0; j < len(huge); j++ {
+			assert.Equal(t, compareInt(i, j), MustParse(huge[i], SchemeSemVer).CompareTo(MustParse(huge[j], SchemeSemVer)), "%s vs %s", huge[i], huge[j])
+		}
+	}
+	v := MustParse("99999999999999999999999.999999999999999999.99999999999999999", SchemeSemVer)
+	assert.Equal(t, []string{"99999999999999999999999", "999999999999999999", "99999999999999999"}, v.NumberDigits)
+	assert.Equal(t, v.Raw, v.Canonical())
+
+	// 构建元数据不参与比较
+	assert.Equal(t, 0, MustParse("1.0.0+build.1", SchemeSemVer).CompareTo(MustParse("1.0.0+build.2", SchemeSemVer)))
+
+	// 使用排序也能得到一样的顺序
+	versions, err := ParseAll(SchemeSemVer, ordered...)
+	assert.Nil(t, err)
+	shuffle.Shuffle(versions)
+	sorted := SortVersionSlice(versions)
+	for i, v := range sorted {
+		assert.Equal(t, ordered[i], v.Raw)
+	}
+}
+
+// TestSemVerScheme_Parse 测试 SemVer 方案解析出的 Version
+func TestSemVerScheme_Parse(t *testing.T) {
+	v, err := Parse("1.0.0-rc.1+build.5", SchemeSemVer)
+	assert.Nil(t, err)
+	assert.Equal(t, VersionNumbers{1, 0, 0}, v.VersionNumbers)
+	assert.Equal(t, VersionSuffix("-rc.1+build.5"), v.Suffix)
+	assert.Equal(t, SchemeSemVer, v.SchemeName())
+	assert.Equal(t, "1.0.0-rc.1+build.5", v.Canonical())
+
+	_, err = Parse("v1.0.0", SchemeSemVer)
+	assert.True(t, errors.Is(err, ErrVersionInvalid))
+	assert.NotNil(t, (&SemVerScheme{}).Validate("1.0"))
+
+	// 不同方案的版本之间退化为通用的比较规则
+	assert.Equal(t, -1, NewVersion("1.0.0").CompareTo(MustParse("1.0.1", SchemeSemVer)))
+}
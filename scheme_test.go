@@ -0,0 +1,145 @@
+package versions
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/golang-infrastructure/go-shuffle"
+	"github.com/stretchr/testify/assert"
+)
+
+// revisionScheme 测试用的版本号方案，形如 "r42"，只比较r后面的数字
+type revisionScheme struct {
+}
+
+func (x *revisionScheme) Name() string {
+	return "test-revision"
+}
+
+func (x *revisionScheme) Parse(versionStr string) (*Version, error) {
+	if err := x.Validate(versionStr); err != nil {
+		return nil, err
+	}
+	n, _ := strconv.Atoi(versionStr[1:])
+	return &Version{
+		Raw:            versionStr,
+		VersionNumbers: NewVersionNumbers([]int{n}),
+		Prefix:         "r",
+		Scheme:         x,
+	}, nil
+}
+
+func (x *revisionScheme) Validate(versionStr string) error {
+	if !strings.HasPrefix(versionStr, "r") {
+		return ErrVersionInvalid
+	}
+	if _, err := strconv.Atoi(versionStr[1:]); err != nil {
+		return ErrVersionInvalid
+	}
+	return nil
+}
+
+func (x *revisionScheme) Compare(a, b *Version) int {
+	return a.VersionNumbers.CompareTo(b.VersionNumbers)
+}
+
+func (x *revisionScheme) Canonical(v *Version) string {
+	return "r" + v.VersionNumbers.BuildGroupID()
+}
+
+// TestParse 测试按方案名称解析版本号
+func TestParse(t *testing.T) {
+	v, err := Parse("v1.2.3-rc1", SchemeGeneric)
+	assert.Nil(t, err)
+	assert.Equal(t, VersionPrefix("v"), v.Prefix)
+	assert.Equal(t, VersionNumbers{1, 2, 3}, v.VersionNumbers)
+	assert.Equal(t, VersionSuffix("-rc1"), v.Suffix)
+	assert.Equal(t, SchemeGeneric, v.SchemeName())
+
+	_, err = Parse("abc", SchemeGeneric)
+	assert.True(t, errors.Is(err, ErrVersionInvalid))
+
+	_, err = Parse("1.0.0", "no-such-scheme")
+	assert.True(t, errors.Is(err, ErrSchemeNotFound))
+
+	assert.Panics(t, func() {
+		MustParse("abc", SchemeGeneric)
+	})
+}
+
+// TestRegisterScheme 测试注册自定义方案，并验证分组、排序对自定义方案同样有效
+func TestRegisterScheme(t *testing.T) {
+	RegisterScheme(&revisionScheme{})
+	assert.Contains(t, SchemeNames(), "test-revision")
+	assert.Contains(t, SchemeNames(), SchemeGeneric)
+
+	scheme, ok := LookupScheme("test-revision")
+	assert.True(t, ok)
+	assert.Equal(t, "test-revision", scheme.Name())
+
+	versions, err := ParseAll("test-revision", "r10", "r2", "r33", "r1")
+	assert.Nil(t, err)
+	sorted := SortVersionSlice(versions)
+	raws := make([]string, 0)
+	for _, v := range sorted {
+		raws = append(raws, v.Raw)
+	}
+	assert.Equal(t, []string{"r1", "r2", "r10", "r33"}, raws)
+	assert.Equal(t, "r10", sorted[2].Canonical())
+
+	groups := NewSortedVersionGroups(versions)
+	assert.Equal(t, []string{"1", "2", "10", "33"}, groups.GroupIDs())
+
+	_, err = ParseAll("test-revision", "r1", "x2")
+	assert.Equal(t, ErrVersionInvalid, err)
+}
+
+// TestVersion_Canonical 测试通用方案的规范化字符串
+func TestVersion_Canonical(t *testing.T) {
+	assert.Equal(t, "v1.2.3-rc1", NewVersion("v1.2.3-rc1").Canonical())
+	assert.Equal(t, "1.5.1", NewVersion("01.05.01").Canonical())
+	assert.Equal(t, "1.99999999999999999999-rc1", NewVersion("1.099999999999999999999-rc1").Canonical())
+	assert.NotEqual(t, NewVersion("1.99999999999999999998").Canonical(), NewVersion("1.99999999999999999999").Canonical())
+	assert.Nil(t, (&GenericScheme{}).Validate("1.0"))
+	assert.NotNil(t, (&GenericScheme{}).Validate(""))
+}
+
+// TestScheme_GroupOrder 测试各个方案按组排序的结果与只用 Compare 排序的结果一致
+//
+// SortedVersionGroups 在按组拼接起来的版本上二分查找，依赖这个顺序一致性
+func TestScheme_GroupOrder(t *testing.T) {
+	corpus := map[string][]string{
+		SchemeGeneric:  {"1", "1.0", "1.0.0", "v1.2", "1.2-rc1", "1.2.0", "1:0.5", "2.0", "1.10", "1.2.3+build", "0.9.9-SNAPSHOT"},
+		SchemeSemVer:   {"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0", "1.0.1-rc.1", "1.0.1", "0.9.9", "2.0.0-0", "10.0.0", "1.0.0+build.1"},
+		SchemeGo:       {"v1.0.0", "v1.0.1-0.20200101000000-abcdefabcdef", "v1.0.0-rc.1", "v2.0.0+incompatible", "v0.0.0-20190101000000-abcdefabcdef", "v1.2.3"},
+		SchemeNuGet:    {"1.0", "1.0.0.1", "1.0.0-beta", "1.0.0.0", "2.0", "1.0.1-rc", "1.0.0-Beta.2", "1"},
+		SchemeMaven:    {"1", "1.0", "1.0.0", "1-alpha-1", "1.0-SNAPSHOT", "1.0.1", "1.0-sp", "1.0.a", "1.1", "1-1", "1.0-ga", "1.0.0-final", "2-rc", "1.0-rc1", "0.9"},
+		SchemePEP440:   {"1!0.5", "1.0", "1.0.0", "1.0a1", "1.0.post1", "1.0.dev1", "0.9", "1.0.1", "1.1rc1", "1.0+local", "1.0.0.post1"},
+		SchemeDebian:   {"1:0.1", "1.0", "1.0~rc1", "1.0-1", "1.0.1", "1.0+b1", "1.0a", "1.00", "1.0.0", "0.9"},
+		SchemeRPM:      {"1.0", "1.0~rc1", "1.0^post", "1.0.1", "1.0a", "1:0.1", "1.0-2", "1.00", "1.a", "1.0.0", "0.9"},
+		SchemePacman:   {"1.0", "1.0rc", "1.0.a", "1.0.1", "1..0", "1.5b", "1.5", "2_0a", "1:0.1", "r1234.5b55fc2", "1.0-2"},
+		SchemeAPK:      {"1.0", "1.0_rc1", "1.0-r1", "1.0.1", "1.0a", "1.0_p1", "1.0.0", "0.9", "1.1_alpha"},
+		SchemeComposer: {"1.0", "1.0.0", "1.0.0-beta", "1.0.0-RC1", "1.0.x-dev", "v1.0.1", "1.0.0.0", "2.0-alpha2", "0.9", "dev-main", "dev-feature"},
+		SchemeCargo:    {"1.0.0", "1.0.0-alpha", "1.0.1", "0.1.0", "1.0.0+build", "2.0.0-rc.1"},
+		SchemeGem:      {"1", "1.0.a", "0.9", "1.0.0.pre.rc1", "1.1", "1.0.0", "1.0", "0.0.a"},
+		SchemeCalVer:   {"2024.1", "2024.1.1", "2024.01.02", "2024.100", "2024", "2023.12.31", "2024.2", "2024.12.1"},
+	}
+	for scheme, versionStrs := range corpus {
+		allVersions, err := ParseAll(scheme, versionStrs...)
+		assert.Nil(t, err, scheme)
+		for i := 0; i < 10; i++ {
+			shuffle.Shuffle(allVersions)
+			expected := append([]*Version{}, allVersions...)
+			sort.SliceStable(expected, func(i, j int) bool {
+				return expected[i].CompareTo(expected[j]) < 0
+			})
+			actual := SortVersionSlice(allVersions)
+			for j := range expected {
+				assert.Equal(t, 0, expected[j].CompareTo(actual[j]), "%s: %v, want %v", scheme, versionRaws(actual), versionRaws(expected))
+			}
+		}
+	}
+}
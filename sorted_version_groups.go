@@ -1,6 +1,10 @@
 package versions
 
-import "github.com/golang-infrastructure/go-tuple"
+import (
+	"sort"
+
+	"github.com/golang-infrastructure/go-tuple"
+)
 
 // SortedVersionGroups 表示已排序的版本组集合
 //
@@ -35,6 +39,9 @@ type SortedVersionGroups struct {
 	// groupSlice 排好序的版本组切片
 	// 按照版本组的大小顺序排列
 	groupSlice []*VersionGroup
+
+	// sortedVersions 所有版本按从小到大的顺序平铺后的切片，用于对区间和约束做二分查找
+	sortedVersions []*Version
 }
 
 // NewSortedVersionGroups 为版本号创建有序的分组
@@ -71,8 +78,10 @@ func NewSortedVersionGroups(versions []*Version) *SortedVersionGroups {
 		groupIdToIndexMap: make(map[string]int),
 		groupSlice:        groupSlice,
 	}
+	groups.sortedVersions = make([]*Version, 0, len(versions))
 	for i, g := range groupSlice {
 		groups.groupIdToIndexMap[g.ID()] = i
+		groups.sortedVersions = append(groups.sortedVersions, g.SortVersions()...)
 	}
 	return groups
 }
@@ -148,3 +157,137 @@ func (x *SortedVersionGroups) QueryRange(start, end *tuple.Tuple2[*Version, Cont
 	}
 	return versions
 }
+
+// QueryInterval 在有序版本组中查询落在区间内的版本
+//
+// 通过二分查找定位区间的上下界，返回的版本按从小到大排列。
+//
+// 参数:
+//   - interval: 要查询的版本区间
+//
+// 返回:
+//   - []*Version: 落在区间内的版本
+//
+// 使用示例:
+//
+//	sortedGroups := versions.NewSortedVersionGroups(allVersions)
+//	interval := versions.NewVersionInterval(
+//	    versions.NewVersion("1.0.0"), versions.ContainsPolicyYes,
+//	    versions.NewVersion("2.0.0"), versions.ContainsPolicyNo,
+//	)
+//	result := sortedGroups.QueryInterval(interval)
+func (x *SortedVersionGroups) QueryInterval(interval *VersionInterval) []*Version {
+	begin, end := x.searchInterval(interval)
+	result := make([]*Version, 0, end-begin)
+	return append(result, x.sortedVersions[begin:end]...)
+}
+
+// QueryConstraint 在有序版本组中查询满足约束的版本
+//
+// 参数:
+//   - constraint: 版本约束
+//
+// 返回:
+//   - []*Version: 满足约束的版本，按从小到大排列
+//
+// 使用示例:
+//
+//	sortedGroups := versions.NewSortedVersionGroups(allVersions)
+//	c, _ := versions.ParseConstraint(">=1.2.0 <2.0.0 || ^3.1")
+//	result := sortedGroups.QueryConstraint(c)
+func (x *SortedVersionGroups) QueryConstraint(constraint *Constraint) []*Version {
+	return x.QueryMatcher(constraint)
+}
+
+// QueryMatcher 在有序版本组中查询满足条件的版本
+//
+// 如果条件实现了 BoundedVersionMatcher，会先用二分查找定位每一个外接区间，只检查区间内的版本，
+// 否则逐个检查所有版本。返回的版本按从小到大排列并且不会重复。
+//
+// 注意外接区间是按照版本自身的比较规则计算的，所以版本应该使用与条件一致的方案解析，
+// 例如查询 NpmRange 时应该使用 SchemeSemVer 解析版本。
+//
+// 参数:
+//   - matcher: 查询条件，如 *Constraint、*NpmRange
+//
+// 返回:
+//   - []*Version: 满足条件的版本
+func (x *SortedVersionGroups) QueryMatcher(matcher VersionMatcher) []*Version {
+	bounded, ok := matcher.(BoundedVersionMatcher)
+	if !ok {
+		return FilterVersions(x.sortedVersions, matcher)
+	}
+	matched := make([]bool, len(x.sortedVersions))
+	for _, interval := range bounded.BoundingIntervals() {
+		if interval.IsEmpty() {
+			continue
+		}
+		begin, end := x.searchInterval(interval)
+		for i := begin; i < end; i++ {
+			if !matched[i] && matcher.Check(x.sortedVersions[i]) {
+				matched[i] = true
+			}
+		}
+	}
+	result := make([]*Version, 0)
+	for i, ok := range matched {
+		if ok {
+			result = append(result, x.sortedVersions[i])
+		}
+	}
+	return result
+}
+
+// searchInterval 二分查找区间在 sortedVersions 中对应的下标范围 [begin, end)
+//
+// sortedVersions 是按组拼接起来的，只有组的顺序与 CompareTo 的顺序一致时（见 GroupingScheme）二分查找才是正确的
+func (x *SortedVersionGroups) searchInterval(interval *VersionInterval) (int, int) {
+	begin := sort.Search(len(x.sortedVersions), func(i int) bool {
+		return interval.aboveLower(x.sortedVersions[i])
+	})
+	end := sort.Search(len(x.sortedVersions), func(i int) bool {
+		return !interval.belowUpper(x.sortedVersions[i])
+	})
+	if end < begin {
+		end = begin
+	}
+	return begin, end
+}
+
+// QueryHighest 在有序版本组中查询满足条件的最高版本
+//
+// 如果条件实现了 BoundedVersionMatcher，会先用二分查找定位每一个外接区间，然后从区间的末尾向前检查。
+//
+// 参数:
+//   - matcher: 查询条件
+//
+// 返回:
+//   - *Version: 满足条件的最高版本，没有满足条件的版本时返回 nil
+func (x *SortedVersionGroups) QueryHighest(matcher VersionMatcher) *Version {
+	bounded, ok := matcher.(BoundedVersionMatcher)
+	if !ok {
+		for i := len(x.sortedVersions) - 1; i >= 0; i-- {
+			if matcher.Check(x.sortedVersions[i]) {
+				return x.sortedVersions[i]
+			}
+		}
+		return nil
+	}
+	highest := -1
+	for _, interval := range bounded.BoundingIntervals() {
+		if interval.IsEmpty() {
+			continue
+		}
+		begin, end := x.searchInterval(interval)
+		for i := end - 1; i >= begin && i > highest; i-- {
+			if matcher.Check(x.sortedVersions[i]) {
+				highest = i
+				break
+			}
+		}
+	}
+	if highest < 0 {
+		return nil
+	}
+	return x.sortedVersions[highest]
+}
@@ -136,3 +136,39 @@ func TestSortedVersionGroups_QueryRange(t *testing.T) {
 	//	fmt.Println(v.Raw)
 	//}
 }
+
+// TestSortedVersionGroups_QueryInterval 测试使用区间进行二分查询
+func TestSortedVersionGroups_QueryInterval(t *testing.T) {
+	allVersions := NewVersions("2.1.0", "1.0.0", "1.1.0", "1.2.0", "2.0.0", "0.9")
+	shuffle.Shuffle(allVersions)
+	groups := NewSortedVersionGroups(allVersions)
+
+	interval := NewVersionInterval(NewVersion("1.0.0"), ContainsPolicyNo, NewVersion("2.0.0"), ContainsPolicyYes)
+	assert.Equal(t, []string{"1.1.0", "1.2.0", "2.0.0"}, versionRaws(groups.QueryInterval(interval)))
+
+	empty := NewVersionInterval(NewVersion("3.0"), ContainsPolicyYes, NewVersion("1.0"), ContainsPolicyYes)
+	assert.Equal(t, []string{}, versionRaws(groups.QueryInterval(empty)))
+}
+
+// TestSortedVersionGroups_QueryConstraint 测试使用约束进行查询，结果应与逐个过滤一致
+func TestSortedVersionGroups_QueryConstraint(t *testing.T) {
+	allVersions := NewVersions("2.1.0", "1.0.0", "1.5.0", "1.6.0", "3.1.2", "3.0.0", "2.0.0", "4.0.0")
+	shuffle.Shuffle(allVersions)
+	groups := NewSortedVersionGroups(allVersions)
+
+	c := MustParseConstraint(">=1.2.0 <2.0.0 !=1.6.0 || ^3.1 || >=1.5 <=1.6.0")
+	assert.Equal(t, []string{"1.5.0", "1.6.0", "3.1.2"}, versionRaws(groups.QueryConstraint(c)))
+	assert.Equal(t, versionRaws(SortVersionSlice(c.Filter(allVersions))), versionRaws(groups.QueryConstraint(c)))
+}
+
+// TestSortedVersionGroups_QueryHighest 测试查询满足条件的最高版本
+func TestSortedVersionGroups_QueryHighest(t *testing.T) {
+	groups := NewSortedVersionGroups(NewVersions("1.0.0", "1.5.0", "2.0.0", "2.1.0"))
+	assert.Equal(t, "1.5.0", groups.QueryHighest(MustParseConstraint("<2.0.0")).Raw)
+	assert.Equal(t, "2.1.0", groups.QueryHighest(MustParseConstraint("<=1.5.0 || >2.0.0")).Raw)
+	assert.Nil(t, groups.QueryHighest(MustParseConstraint(">3")))
+
+	// 没有外接区间的条件会逐个检查
+	interval := NewVersionInterval(nil, ContainsPolicyNone, NewVersion("2.0.0"), ContainsPolicyYes)
+	assert.Equal(t, "2.0.0", groups.QueryHighest(interval).Raw)
+}
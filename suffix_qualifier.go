@@ -0,0 +1,172 @@
+package versions
+
+import (
+	"strings"
+	"sync"
+)
+
+// 后缀限定词的权重，权重越大版本越新，顺序参考 Maven 的 ComparableVersion，
+// 相邻的权重之间留有空隙，方便通过 RegisterSuffixQualifier 插入自定义的限定词
+const (
+
+	// QualifierRankDev 开发版本，如 "1.0-dev"，比 alpha 更早
+	QualifierRankDev = 10
+
+	// QualifierRankAlpha 内测版本，如 "1.0-alpha1"、"1.0-a1"
+	QualifierRankAlpha = 20
+
+	// QualifierRankBeta 公测版本，如 "1.0-beta2"、"1.0-b2"
+	QualifierRankBeta = 30
+
+	// QualifierRankMilestone 里程碑版本，如 "1.0-M3"、"1.0-milestone3"
+	QualifierRankMilestone = 40
+
+	// QualifierRankPreview 预览版本，如 "1.0-preview1"
+	QualifierRankPreview = 45
+
+	// QualifierRankRC 候选发布版本，如 "1.0-rc1"、"1.0-CR1"
+	QualifierRankRC = 50
+
+	// QualifierRankSnapshot 快照版本，如 "1.0-SNAPSHOT"，与 Maven 一样排在候选发布版本和正式版本之间
+	QualifierRankSnapshot = 60
+
+	// QualifierRankRelease 正式版本，没有后缀以及 "1.0-RELEASE"、"1.0.GA"、"1.0-final" 都是正式版本
+	QualifierRankRelease = 70
+
+	// QualifierRankServicePack 正式版本之后的补丁版本，如 "1.0-SP1"
+	QualifierRankServicePack = 80
+
+	// QualifierRankUnknown 没有注册过的限定词，排在所有已知的限定词之后，相互之间按字典序比较
+	QualifierRankUnknown = 90
+)
+
+var (
+	suffixQualifierLock sync.RWMutex
+
+	// suffixQualifiers 限定词（小写）到权重的映射
+	suffixQualifiers = map[string]int{
+		"dev":       QualifierRankDev,
+		"alpha":     QualifierRankAlpha,
+		"beta":      QualifierRankBeta,
+		"milestone": QualifierRankMilestone,
+		"preview":   QualifierRankPreview,
+		"pre":       QualifierRankPreview,
+		"rc":        QualifierRankRC,
+		"cr":        QualifierRankRC,
+		"snapshot":  QualifierRankSnapshot,
+		"release":   QualifierRankRelease,
+		"ga":        QualifierRankRelease,
+		"final":     QualifierRankRelease,
+		"sp":        QualifierRankServicePack,
+	}
+
+	// suffixQualifierShortAliases 单字母的缩写，只有紧跟着数字时才生效，避免 "1.0.1a" 这样的版本被当作 alpha
+	suffixQualifierShortAliases = map[string]string{
+		"a": "alpha",
+		"b": "beta",
+		"m": "milestone",
+	}
+)
+
+// RegisterSuffixQualifier 注册后缀限定词的权重，已经存在的限定词会被覆盖
+//
+// 限定词不区分大小写。注册之后 VersionSuffix.CompareTo 和通用方案的版本比较会立即使用新的权重。
+//
+// 参数:
+//   - qualifier: 限定词，只能由字母组成，如 "sec"
+//   - rank: 权重，可以使用 QualifierRankXxx 常量或者它们之间的值
+//
+// 使用示例:
+//
+//	// fastjson 的安全修复版本 "1.1.31.sec06" 排在 "1.1.31" 之后、"1.1.31.SP1" 之前
+//	versions.RegisterSuffixQualifier("sec", versions.QualifierRankRelease+5)
+func RegisterSuffixQualifier(qualifier string, rank int) {
+	suffixQualifierLock.Lock()
+	defer suffixQualifierLock.Unlock()
+	suffixQualifiers[strings.ToLower(qualifier)] = rank
+}
+
+// LookupSuffixQualifier 查询后缀限定词的权重
+//
+// 参数:
+//   - qualifier: 限定词，不区分大小写
+//
+// 返回:
+//   - int: 限定词的权重，没有注册过时为 QualifierRankUnknown
+//   - bool: 是否注册过
+func LookupSuffixQualifier(qualifier string) (int, bool) {
+	suffixQualifierLock.RLock()
+	defer suffixQualifierLock.RUnlock()
+	rank, ok := suffixQualifiers[strings.ToLower(qualifier)]
+	if !ok {
+		return QualifierRankUnknown, false
+	}
+	return rank, true
+}
+
+// suffixItem 后缀中的一个限定词或者数字
+type suffixItem struct {
+
+	// qualifier 小写的限定词，为空时表示这是一个数字
+	qualifier string
+
+	// rank 限定词的权重
+	rank int
+
+	// digits 去掉前导0的数字
+	digits string
+}
+
+// splitSuffixItems 把后缀切分为参与比较的限定词和数字，分隔符和提交哈希不参与比较，日期按数字比较
+func splitSuffixItems(suffix string) []*suffixItem {
+	tokens := VersionSuffix(suffix).Tokens()
+	items := make([]*suffixItem, 0, len(tokens))
+	for i, token := range tokens {
+		switch token.Kind {
+		case SuffixTokenNumber, SuffixTokenDate:
+			digits := strings.TrimLeft(token.Text, "0")
+			if digits == "" {
+				digits = "0"
+			}
+			items = append(items, &suffixItem{digits: digits})
+		case SuffixTokenQualifier:
+			qualifier := strings.ToLower(token.Text)
+			if alias, ok := suffixQualifierShortAliases[qualifier]; ok && i+1 < len(tokens) && tokens[i+1].Kind == SuffixTokenNumber {
+				qualifier = alias
+			}
+			rank, _ := LookupSuffixQualifier(qualifier)
+			items = append(items, &suffixItem{qualifier: qualifier, rank: rank})
+		}
+	}
+	return items
+}
+
+// compareSuffixItem 比较两个后缀元素，b 为nil时表示已经没有元素了
+//
+// 数字按数值比较，限定词按权重比较，权重都是 QualifierRankUnknown 时按字典序比较；
+// 数字比任何限定词都新；没有元素等价于正式版本的限定词，或者与数字比较时等价于0。
+func compareSuffixItem(a, b *suffixItem) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case b == nil:
+		return -compareSuffixItem(b, a)
+	case a == nil && b.qualifier == "":
+		return compareDigitString("0", b.digits)
+	case a == nil:
+		return compareInt(QualifierRankRelease, b.rank)
+	case a.qualifier == "" && b.qualifier == "":
+		return compareDigitString(a.digits, b.digits)
+	case a.qualifier == "":
+		return 1
+	case b.qualifier == "":
+		return -1
+	}
+	if r := compareInt(a.rank, b.rank); r != 0 {
+		return r
+	}
+	if a.rank == QualifierRankUnknown {
+		return strings.Compare(a.qualifier, b.qualifier)
+	}
+	return 0
+}
@@ -0,0 +1,62 @@
+package versions
+
+import (
+	"testing"
+
+	"github.com/golang-infrastructure/go-shuffle"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestLookupSuffixQualifier 测试限定词权重的查询，别名与对应的限定词权重相同
+func TestLookupSuffixQualifier(t *testing.T) {
+	rank, ok := LookupSuffixQualifier("SNAPSHOT")
+	assert.True(t, ok)
+	assert.Equal(t, QualifierRankSnapshot, rank)
+
+	for qualifier, expected := range map[string]int{"cr": QualifierRankRC, "GA": QualifierRankRelease, "final": QualifierRankRelease, "Release": QualifierRankRelease} {
+		rank, ok := LookupSuffixQualifier(qualifier)
+		assert.True(t, ok, qualifier)
+		assert.Equal(t, expected, rank, qualifier)
+	}
+
+	rank, ok = LookupSuffixQualifier("sec")
+	assert.False(t, ok)
+	assert.Equal(t, QualifierRankUnknown, rank)
+}
+
+// TestRegisterSuffixQualifier 测试运行时注册自定义限定词
+func TestRegisterSuffixQualifier(t *testing.T) {
+	defer func() {
+		suffixQualifierLock.Lock()
+		delete(suffixQualifiers, "sec")
+		suffixQualifierLock.Unlock()
+	}()
+
+	// 没有注册时 "sec" 是未知的限定词，排在 "SP" 之后
+	assert.Equal(t, 1, VersionSuffix(".sec06").CompareTo("-SP1"))
+
+	RegisterSuffixQualifier("SEC", QualifierRankRelease+5)
+	ordered := []string{"1.1.31-rc1", "1.1.31", "1.1.31.sec01", "1.1.31.sec06", "1.1.31.sec10", "1.1.31-SP1", "1.1.32"}
+	fastjsonVersions := make([]*Version, 0, len(ordered))
+	for _, raw := range ordered {
+		fastjsonVersions = append(fastjsonVersions, NewVersion(raw))
+	}
+	shuffle.Shuffle(fastjsonVersions)
+	assert.Equal(t, ordered, versionRaws(SortVersionSlice(fastjsonVersions)))
+}
+
+// TestSplitSuffixItems 测试后缀切分为限定词和数字，单字母缩写只在紧跟数字时生效
+func TestSplitSuffixItems(t *testing.T) {
+	items := splitSuffixItems("-M10.RELEASE")
+	assert.Equal(t, []*suffixItem{
+		{qualifier: "milestone", rank: QualifierRankMilestone},
+		{digits: "10"},
+		{qualifier: "release", rank: QualifierRankRelease},
+	}, items)
+
+	items = splitSuffixItems("a")
+	assert.Equal(t, []*suffixItem{{qualifier: "a", rank: QualifierRankUnknown}}, items)
+
+	items = splitSuffixItems("_007")
+	assert.Equal(t, []*suffixItem{{digits: "7"}}, items)
+}
@@ -0,0 +1,254 @@
+package versions
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SuffixTokenKind 版本后缀中元素的种类
+type SuffixTokenKind int
+
+const (
+
+	// SuffixTokenSeparator 分隔符，连续的非字母数字字符，如 "-"、"."、"+"
+	SuffixTokenSeparator SuffixTokenKind = iota
+
+	// SuffixTokenQualifier 限定词，连续的字母，如 "rc1" 中的 "rc"
+	SuffixTokenQualifier
+
+	// SuffixTokenNumber 数字，如 "rc1" 中的 "1"
+	SuffixTokenNumber
+
+	// SuffixTokenDate 形如日期的数字，格式为 yyyyMMdd、yyyyMMddHHmm 或者 yyyyMMddHHmmss，如 "20201012"
+	SuffixTokenDate
+
+	// SuffixTokenCommitHash 提交哈希，7到40位的小写十六进制串，同时包含数字和字母，可以带有 git describe 的 "g" 前缀，如 "af92198d"、"gf8bff24"
+	SuffixTokenCommitHash
+)
+
+// suffixTokenKindNames 元素种类的名称
+var suffixTokenKindNames = map[SuffixTokenKind]string{
+	SuffixTokenSeparator:  "separator",
+	SuffixTokenQualifier:  "qualifier",
+	SuffixTokenNumber:     "number",
+	SuffixTokenDate:       "date",
+	SuffixTokenCommitHash: "commit-hash",
+}
+
+// String 返回元素种类的名称，如 "qualifier"
+func (x SuffixTokenKind) String() string {
+	if name, ok := suffixTokenKindNames[x]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// suffixDateLayouts 日期元素的长度到格式的映射
+var suffixDateLayouts = map[int]string{
+	8:  "20060102",
+	12: "200601021504",
+	14: "20060102150405",
+}
+
+// SuffixToken 版本后缀中的一个元素
+//
+// 例如后缀 "-snapshot.20201012.5405.0.af92198d" 被切分为：
+// 分隔符 "-"、限定词 "snapshot"、分隔符 "."、日期 "20201012"、分隔符 "."、数字 "5405"、
+// 分隔符 "."、数字 "0"、分隔符 "."、提交哈希 "af92198d"。
+type SuffixToken struct {
+
+	// Kind 元素的种类
+	Kind SuffixTokenKind
+
+	// Text 元素在后缀中的原始文本
+	Text string
+}
+
+// Number 返回数字或者日期元素的数值，其它种类的元素或者数值溢出时返回 false
+func (x *SuffixToken) Number() (int, bool) {
+	if x.Kind != SuffixTokenNumber && x.Kind != SuffixTokenDate {
+		return 0, false
+	}
+	n, err := strconv.Atoi(x.Text)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+// Time 返回日期元素表示的时间（UTC），不是日期元素时返回 false
+func (x *SuffixToken) Time() (time.Time, bool) {
+	if x.Kind != SuffixTokenDate {
+		return time.Time{}, false
+	}
+	return parseSuffixDate(x.Text)
+}
+
+// CommitHash 返回提交哈希元素去掉 "g" 前缀之后的哈希，不是提交哈希元素时返回空字符串
+func (x *SuffixToken) CommitHash() string {
+	if x.Kind != SuffixTokenCommitHash {
+		return ""
+	}
+	return strings.TrimPrefix(x.Text, "g")
+}
+
+// String 返回元素的原始文本
+func (x *SuffixToken) String() string {
+	return x.Text
+}
+
+// Tokens 把后缀切分为有类型的元素，所有元素的文本连接起来就是原始的后缀
+//
+// 先按照分隔符切分为单词，整个单词是日期或者提交哈希时作为一个元素，否则再按照字母和数字的切换切分为限定词和数字。
+//
+// 返回:
+//   - []*SuffixToken: 切分得到的元素，后缀为空时返回空切片
+//
+// 使用示例:
+//
+//	for _, token := range versions.VersionSuffix("-1-f8bff243").Tokens() {
+//	    fmt.Println(token.Kind, token.Text)
+//	}
+//	// 输出:
+//	// separator -
+//	// number 1
+//	// separator -
+//	// commit-hash f8bff243
+func (x VersionSuffix) Tokens() []*SuffixToken {
+	s := string(x)
+	tokens := make([]*SuffixToken, 0)
+	i := 0
+	for i < len(s) {
+		j := i
+		if !isASCIIAlnum(rune(s[i])) {
+			for j < len(s) && !isASCIIAlnum(rune(s[j])) {
+				j++
+			}
+			tokens = append(tokens, &SuffixToken{Kind: SuffixTokenSeparator, Text: s[i:j]})
+			i = j
+			continue
+		}
+		for j < len(s) && isASCIIAlnum(rune(s[j])) {
+			j++
+		}
+		tokens = append(tokens, tokenizeSuffixWord(s[i:j])...)
+		i = j
+	}
+	return tokens
+}
+
+// tokenizeSuffixWord 切分一个由字母和数字组成的单词
+func tokenizeSuffixWord(word string) []*SuffixToken {
+	if isSuffixCommitHash(word) {
+		return []*SuffixToken{{Kind: SuffixTokenCommitHash, Text: word}}
+	}
+	if _, ok := parseSuffixDate(word); ok {
+		return []*SuffixToken{{Kind: SuffixTokenDate, Text: word}}
+	}
+	tokens := make([]*SuffixToken, 0)
+	i := 0
+	for i < len(word) {
+		j := i
+		kind := SuffixTokenQualifier
+		if isASCIIDigit(word[i]) {
+			kind = SuffixTokenNumber
+		}
+		for j < len(word) && isASCIIDigit(word[j]) == (kind == SuffixTokenNumber) {
+			j++
+		}
+		tokens = append(tokens, &SuffixToken{Kind: kind, Text: word[i:j]})
+		i = j
+	}
+	return tokens
+}
+
+// isSuffixCommitHash 判断单词是否是提交哈希
+func isSuffixCommitHash(word string) bool {
+	// "g" 不是十六进制字符，所以可以直接去掉 git describe 的前缀
+	hash := strings.TrimPrefix(word, "g")
+	if len(hash) < 7 || len(hash) > 40 {
+		return false
+	}
+	hasDigit, hasLetter := false, false
+	for i := 0; i < len(hash); i++ {
+		switch c := hash[i]; {
+		case isASCIIDigit(c):
+			hasDigit = true
+		case c >= 'a' && c <= 'f':
+			hasLetter = true
+		default:
+			return false
+		}
+	}
+	return hasDigit && hasLetter
+}
+
+// parseSuffixDate 把形如日期的数字解析为时间，年份必须在1970年到2999年之间
+func parseSuffixDate(s string) (time.Time, bool) {
+	layout, ok := suffixDateLayouts[len(s)]
+	if !ok {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(layout, s)
+	if err != nil || t.Year() < 1970 || t.Year() > 2999 {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// Qualifier 返回后缀中第一个限定词的小写形式，没有限定词时返回空字符串
+//
+// 使用示例:
+//
+//	fmt.Println(versions.VersionSuffix("-RC1").Qualifier()) // 输出: rc
+func (x VersionSuffix) Qualifier() string {
+	for _, token := range x.Tokens() {
+		if token.Kind == SuffixTokenQualifier {
+			return strings.ToLower(token.Text)
+		}
+	}
+	return ""
+}
+
+// Number 返回后缀中第一个数字的数值，日期和提交哈希不算数字
+//
+// 返回:
+//   - int: 数值，如 "-rc1" 返回 1
+//   - bool: 没有数字或者数值溢出时返回 false
+func (x VersionSuffix) Number() (int, bool) {
+	for _, token := range x.Tokens() {
+		if token.Kind == SuffixTokenNumber {
+			return token.Number()
+		}
+	}
+	return 0, false
+}
+
+// CommitHash 返回后缀中第一个提交哈希（去掉 git describe 的 "g" 前缀），没有时返回空字符串
+//
+// 使用示例:
+//
+//	fmt.Println(versions.VersionSuffix("-1-f8bff243").CommitHash()) // 输出: f8bff243
+func (x VersionSuffix) CommitHash() string {
+	for _, token := range x.Tokens() {
+		if token.Kind == SuffixTokenCommitHash {
+			return token.CommitHash()
+		}
+	}
+	return ""
+}
+
+// BuildDate 返回后缀中第一个日期表示的时间（UTC）
+//
+// 返回:
+//   - time.Time: 日期，如 "-snapshot.20201012.5405" 返回 2020-10-12
+//   - bool: 没有日期时返回 false
+func (x VersionSuffix) BuildDate() (time.Time, bool) {
+	for _, token := range x.Tokens() {
+		if token.Kind == SuffixTokenDate {
+			return token.Time()
+		}
+	}
+	return time.Time{}, false
+}
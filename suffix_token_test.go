@@ -0,0 +1,80 @@
+package versions
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestVersionSuffix_Tokens 测试后缀切分为有类型的元素
+func TestVersionSuffix_Tokens(t *testing.T) {
+	cases := map[VersionSuffix][]SuffixToken{
+		"": {},
+		"-snapshot.20201012.5405.0.af92198d": {
+			{SuffixTokenSeparator, "-"}, {SuffixTokenQualifier, "snapshot"}, {SuffixTokenSeparator, "."},
+			{SuffixTokenDate, "20201012"}, {SuffixTokenSeparator, "."}, {SuffixTokenNumber, "5405"},
+			{SuffixTokenSeparator, "."}, {SuffixTokenNumber, "0"}, {SuffixTokenSeparator, "."},
+			{SuffixTokenCommitHash, "af92198d"},
+		},
+		"-1-f8bff243": {
+			{SuffixTokenSeparator, "-"}, {SuffixTokenNumber, "1"}, {SuffixTokenSeparator, "-"}, {SuffixTokenCommitHash, "f8bff243"},
+		},
+		"-RC1": {
+			{SuffixTokenSeparator, "-"}, {SuffixTokenQualifier, "RC"}, {SuffixTokenNumber, "1"},
+		},
+		"-3-gf8bff24": {
+			{SuffixTokenSeparator, "-"}, {SuffixTokenNumber, "3"}, {SuffixTokenSeparator, "-"}, {SuffixTokenCommitHash, "gf8bff24"},
+		},
+		"+build.202301011530": {
+			{SuffixTokenSeparator, "+"}, {SuffixTokenQualifier, "build"}, {SuffixTokenSeparator, "."}, {SuffixTokenDate, "202301011530"},
+		},
+		// 不是合法的日期，也不是提交哈希
+		"--20231345.abcdefg": {
+			{SuffixTokenSeparator, "--"}, {SuffixTokenNumber, "20231345"}, {SuffixTokenSeparator, "."}, {SuffixTokenQualifier, "abcdefg"},
+		},
+	}
+	for suffix, expected := range cases {
+		tokens := suffix.Tokens()
+		actual := make([]SuffixToken, 0, len(tokens))
+		texts := make([]string, 0, len(tokens))
+		for _, token := range tokens {
+			actual = append(actual, *token)
+			texts = append(texts, token.Text)
+		}
+		assert.Equal(t, expected, actual, string(suffix))
+		assert.Equal(t, string(suffix), strings.Join(texts, ""))
+	}
+}
+
+// TestVersionSuffix_Accessors 测试从后缀中取出限定词、数字、提交哈希和日期
+func TestVersionSuffix_Accessors(t *testing.T) {
+	suffix := VersionSuffix("-SNAPSHOT.20201012.5405.0.af92198d")
+	assert.Equal(t, "snapshot", suffix.Qualifier())
+	n, ok := suffix.Number()
+	assert.True(t, ok)
+	assert.Equal(t, 5405, n)
+	assert.Equal(t, "af92198d", suffix.CommitHash())
+	date, ok := suffix.BuildDate()
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2020, 10, 12, 0, 0, 0, 0, time.UTC), date)
+
+	suffix = VersionSuffix("-3-gf8bff24")
+	assert.Equal(t, "", suffix.Qualifier())
+	assert.Equal(t, "f8bff24", suffix.CommitHash())
+	_, ok = suffix.BuildDate()
+	assert.False(t, ok)
+
+	_, ok = EmptyVersionSuffix.Number()
+	assert.False(t, ok)
+	assert.Equal(t, "qualifier", SuffixTokenQualifier.String())
+	assert.Equal(t, "unknown", SuffixTokenKind(100).String())
+
+	token := &SuffixToken{Kind: SuffixTokenQualifier, Text: "rc"}
+	_, ok = token.Number()
+	assert.False(t, ok)
+	_, ok = token.Time()
+	assert.False(t, ok)
+	assert.Equal(t, "", token.CommitHash())
+}
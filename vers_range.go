@@ -0,0 +1,565 @@
+package versions
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var (
+	// ErrVersRangeInvalid 表示 vers 版本范围格式无效的错误
+	//
+	// 当尝试解析不符合 package-url vers 规范的版本范围时返回此错误
+	ErrVersRangeInvalid = errors.New("vers range invalid")
+)
+
+// vers 规范中的比较运算符
+const (
+	VersComparatorStar           = "*"
+	VersComparatorEqual          = "="
+	VersComparatorNotEqual       = "!="
+	VersComparatorLess           = "<"
+	VersComparatorLessOrEqual    = "<="
+	VersComparatorGreater        = ">"
+	VersComparatorGreaterOrEqual = ">="
+)
+
+var (
+	versSchemeLock sync.RWMutex
+
+	// versSchemes vers 中的版本号方案名称到本库中的方案名称的映射
+	versSchemes = map[string]string{
+		"generic":  SchemeGeneric,
+		"semver":   SchemeSemVer,
+		"npm":      SchemeSemVer,
+		"maven":    SchemeMaven,
+		"pypi":     SchemePEP440,
+		"deb":      SchemeDebian,
+		"rpm":      SchemeRPM,
+		"golang":   SchemeGo,
+		"gem":      SchemeGem,
+		"cargo":    SchemeCargo,
+		"nuget":    SchemeNuGet,
+		"composer": SchemeComposer,
+		"alpine":   SchemeAPK,
+		"apk":      SchemeAPK,
+		"alpm":     SchemePacman,
+	}
+)
+
+// RegisterVersScheme 注册 vers 中的版本号方案名称对应的本库方案
+//
+// 参数:
+//   - versScheme: vers 中的方案名称，如 "npm"、"pypi"
+//   - schemeName: 本库中已经注册的方案名称，如 SchemeSemVer
+//
+// 使用示例:
+//
+//	versions.RegisterVersScheme("conan", versions.SchemeSemVer)
+func RegisterVersScheme(versScheme string, schemeName string) {
+	versSchemeLock.Lock()
+	defer versSchemeLock.Unlock()
+	versSchemes[strings.ToLower(versScheme)] = schemeName
+}
+
+// LookupVersScheme 查找 vers 中的版本号方案对应的本库方案
+//
+// 参数:
+//   - versScheme: vers 中的方案名称，不区分大小写，如 "npm"
+//
+// 返回:
+//   - Scheme: 对应的本库方案
+//   - bool: 没有通过 RegisterVersScheme 注册过，或者对应的本库方案没有注册时返回 false
+func LookupVersScheme(versScheme string) (Scheme, bool) {
+	versSchemeLock.RLock()
+	schemeName, ok := versSchemes[strings.ToLower(versScheme)]
+	versSchemeLock.RUnlock()
+	if !ok {
+		return nil, false
+	}
+	return LookupScheme(schemeName)
+}
+
+// VersConstraint vers 版本范围中的一个约束，如 ">=1.0.0"
+type VersConstraint struct {
+
+	// Comparator 比较运算符，为 VersComparatorStar 时表示匹配任意版本，此时 Version 为 nil
+	Comparator string
+
+	// Version 约束的版本
+	Version *Version
+}
+
+// String 返回约束的字符串形式，版本号中的特殊字符会被转义
+func (x *VersConstraint) String() string {
+	if x.Comparator == VersComparatorStar {
+		return VersComparatorStar
+	}
+	comparator := x.Comparator
+	if comparator == VersComparatorEqual {
+		comparator = ""
+	}
+	return comparator + url.PathEscape(x.Version.Raw)
+}
+
+// isGreater 是否是 ">" 或者 ">="
+func (x *VersConstraint) isGreater() bool {
+	return x.Comparator == VersComparatorGreater || x.Comparator == VersComparatorGreaterOrEqual
+}
+
+// isLess 是否是 "<" 或者 "<="
+func (x *VersConstraint) isLess() bool {
+	return x.Comparator == VersComparatorLess || x.Comparator == VersComparatorLessOrEqual
+}
+
+// VersRange 表示一个 package-url 的 vers 版本范围，如 "vers:npm/>=1.0.0|<2.0.0"
+//
+// 约束中的版本号会根据 vers 中的方案名称使用对应的版本号方案解析和比较，
+// 例如 "npm" 使用 SchemeSemVer，"pypi" 使用 SchemePEP440，其它方案需要先通过 RegisterVersScheme 注册。
+//
+// 使用示例:
+//
+//	r, err := versions.ParseVersRange("vers:npm/>=1.0.0|<2.0.0")
+//	if err != nil {
+//	    log.Fatalf("不是合法的vers: %v", err)
+//	}
+//	fmt.Println(r.Check(versions.NewVersion("1.5.0"))) // 输出: true
+type VersRange struct {
+
+	// VersioningScheme vers 中的版本号方案名称，如 "npm"
+	VersioningScheme string
+
+	// Constraints 按版本从小到大排列的约束，与输入的一致，可能含有冗余的约束，见 Simplify
+	Constraints []*VersConstraint
+
+	// scheme 解析和比较版本时使用的方案
+	scheme Scheme
+}
+
+var _ BoundedVersionMatcher = &VersRange{}
+
+// ParseVersRange 按照 vers 规范解析版本范围
+//
+// 解析时会忽略所有的空白字符，并对约束按版本排序。像 "vers:npm/>=1.0.0|>=1.5.0|<2.0.0" 这样
+// 含有冗余约束的版本范围也能解析，Check 等判断按照去掉冗余约束之后的含义进行，可以调用 Simplify 得到规范的形式；
+// 需要严格按照 vers 规范校验约束时再调用 Validate。
+//
+// 参数:
+//   - versStr: vers 字符串，如 "vers:pypi/>=1.0|!=1.5|<2.0"
+//
+// 返回:
+//   - *VersRange: 解析后的版本范围
+//   - error: 方案没有注册时返回包装了 ErrSchemeNotFound 的错误，格式无效时返回包装了 ErrVersRangeInvalid 的错误
+func ParseVersRange(versStr string) (*VersRange, error) {
+	s := strings.Join(strings.Fields(versStr), "")
+	if !strings.HasPrefix(strings.ToLower(s), "vers:") {
+		return nil, fmt.Errorf("%w: %q must start with \"vers:\"", ErrVersRangeInvalid, versStr)
+	}
+	s = s[len("vers:"):]
+	index := strings.IndexByte(s, '/')
+	if index <= 0 {
+		return nil, fmt.Errorf("%w: %q has no versioning scheme", ErrVersRangeInvalid, versStr)
+	}
+	versioningScheme := strings.ToLower(s[:index])
+	constraintsStr := strings.Trim(s[index+1:], "|")
+	if constraintsStr == "" {
+		return nil, fmt.Errorf("%w: %q has no constraints", ErrVersRangeInvalid, versStr)
+	}
+	scheme, ok := LookupVersScheme(versioningScheme)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q versioning scheme %s", ErrSchemeNotFound, versStr, versioningScheme)
+	}
+
+	r := &VersRange{
+		VersioningScheme: versioningScheme,
+		Constraints:      make([]*VersConstraint, 0),
+		scheme:           scheme,
+	}
+	if constraintsStr == VersComparatorStar {
+		r.Constraints = append(r.Constraints, &VersConstraint{Comparator: VersComparatorStar})
+		return r, nil
+	}
+	for _, constraintStr := range strings.Split(constraintsStr, "|") {
+		c, err := r.parseConstraint(constraintStr)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q %s", ErrVersRangeInvalid, versStr, err.Error())
+		}
+		r.Constraints = append(r.Constraints, c)
+	}
+	r.sortConstraints()
+	return r, nil
+}
+
+// NewVersRange 使用已经解析好的约束创建版本范围，约束会按版本排序，但是不会校验
+//
+// 通常用于把其它格式的版本范围转换为 vers，转换之后可以调用 Simplify 去掉冗余的约束，再调用 Validate 校验。
+//
+// 参数:
+//   - versioningScheme: vers 中的版本号方案名称，如 "npm"
+//   - constraints: 约束，版本应该使用该方案对应的本库方案解析
+//
+// 返回:
+//   - *VersRange: 新创建的版本范围
+//   - error: 方案没有注册时返回包装了 ErrSchemeNotFound 的错误
+func NewVersRange(versioningScheme string, constraints ...*VersConstraint) (*VersRange, error) {
+	scheme, ok := LookupVersScheme(versioningScheme)
+	if !ok {
+		return nil, fmt.Errorf("%w: versioning scheme %s", ErrSchemeNotFound, versioningScheme)
+	}
+	r := &VersRange{
+		VersioningScheme: strings.ToLower(versioningScheme),
+		Constraints:      append(make([]*VersConstraint, 0, len(constraints)), constraints...),
+		scheme:           scheme,
+	}
+	r.sortConstraints()
+	return r, nil
+}
+
+// sortConstraints 按版本从小到大对约束排序，"*" 没有版本，排在最前面
+func (x *VersRange) sortConstraints() {
+	sort.SliceStable(x.Constraints, func(i, j int) bool {
+		a, b := x.Constraints[i].Version, x.Constraints[j].Version
+		if a == nil || b == nil {
+			return a == nil && b != nil
+		}
+		return a.CompareTo(b) < 0
+	})
+}
+
+// MustParseVersRange 与 ParseVersRange 相同，但是在解析失败时会 panic
+func MustParseVersRange(versStr string) *VersRange {
+	r, err := ParseVersRange(versStr)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// parseConstraint 解析一个约束，版本号需要先进行 URL 解码
+func (x *VersRange) parseConstraint(constraintStr string) (*VersConstraint, error) {
+	comparator := VersComparatorEqual
+	for _, op := range []string{VersComparatorNotEqual, VersComparatorLessOrEqual, VersComparatorGreaterOrEqual, VersComparatorLess, VersComparatorGreater, VersComparatorEqual} {
+		if strings.HasPrefix(constraintStr, op) {
+			comparator = op
+			constraintStr = constraintStr[len(op):]
+			break
+		}
+	}
+	if constraintStr == "" {
+		return nil, fmt.Errorf("constraint %q has no version", comparator)
+	}
+	if constraintStr == VersComparatorStar {
+		return nil, fmt.Errorf("\"*\" must be the only constraint")
+	}
+	versionStr, err := url.PathUnescape(constraintStr)
+	if err != nil {
+		return nil, err
+	}
+	v, err := x.scheme.Parse(versionStr)
+	if err != nil {
+		return nil, err
+	}
+	return &VersConstraint{Comparator: comparator, Version: v}, nil
+}
+
+// Validate 严格校验约束是否符合 vers 规范，ParseVersRange 不会做这个校验
+//
+// 同一个版本只能出现一次；忽略 "!=" 之后，"=" 后面只能是 "="、">"、">="；
+// 再忽略 "=" 之后，大于和小于必须交替出现。不符合规范的版本范围可以先调用 Simplify 规范化。
+//
+// 返回:
+//   - error: 不符合规范时返回包装了 ErrVersRangeInvalid 的错误
+func (x *VersRange) Validate() error {
+	if err := x.validateConstraints(); err != nil {
+		return fmt.Errorf("%w: %q %s", ErrVersRangeInvalid, x.String(), err.Error())
+	}
+	return nil
+}
+
+// validateConstraints 按照 vers 规范校验已经排好序的约束
+func (x *VersRange) validateConstraints() error {
+	if len(x.Constraints) == 1 && x.Constraints[0].Comparator == VersComparatorStar {
+		return nil
+	}
+	for _, c := range x.Constraints {
+		if c.Comparator == VersComparatorStar {
+			return fmt.Errorf("\"*\" must be the only constraint")
+		}
+	}
+	for i := 1; i < len(x.Constraints); i++ {
+		if x.Constraints[i-1].Version.CompareTo(x.Constraints[i].Version) == 0 {
+			return fmt.Errorf("version %s appears more than once", x.Constraints[i].Version.Raw)
+		}
+	}
+
+	// 忽略 "!=" 之后，"=" 后面只能是 "="、">"、">="
+	var previous *VersConstraint
+	for _, c := range x.Constraints {
+		if c.Comparator == VersComparatorNotEqual {
+			continue
+		}
+		if previous != nil && previous.Comparator == VersComparatorEqual && c.isLess() {
+			return fmt.Errorf("%s can not follow %s", c, previous)
+		}
+		previous = c
+	}
+
+	// 再忽略 "=" 之后，大于和小于必须交替出现
+	previous = nil
+	for _, c := range x.Constraints {
+		if c.Comparator == VersComparatorNotEqual || c.Comparator == VersComparatorEqual {
+			continue
+		}
+		if previous != nil && previous.isGreater() == c.isGreater() {
+			return fmt.Errorf("%s can not follow %s", c, previous)
+		}
+		previous = c
+	}
+	return nil
+}
+
+// Scheme 返回解析和比较版本时使用的方案
+func (x *VersRange) Scheme() Scheme {
+	return x.scheme
+}
+
+// Check 判断版本是否在版本范围内，算法与 vers 规范中的 "Version containment" 一致，作用于 Simplify 之后的约束
+//
+// 如果版本不是使用 vers 对应的方案解析的，会先尝试使用该方案重新解析，以便按照该生态的规则比较。
+//
+// 参数:
+//   - v: 要检查的版本
+//
+// 返回:
+//   - bool: 版本在范围内（受影响）时返回 true
+func (x *VersRange) Check(v *Version) bool {
+	return versConstraintsContain(x.Simplify().Constraints, x.convert(v))
+}
+
+// versConstraintsContain 按照 vers 规范中的 "Version containment" 判断版本是否满足已经排好序并且符合规范的约束
+func versConstraintsContain(constraints []*VersConstraint, v *Version) bool {
+	if len(constraints) == 1 && constraints[0].Comparator == VersComparatorStar {
+		return true
+	}
+
+	// 先处理与约束的版本相等的情况，然后只留下大于和小于的约束
+	ranges := make([]*VersConstraint, 0, len(constraints))
+	for _, c := range constraints {
+		if v.CompareTo(c.Version) == 0 {
+			switch c.Comparator {
+			case VersComparatorEqual, VersComparatorLessOrEqual, VersComparatorGreaterOrEqual:
+				return true
+			case VersComparatorNotEqual:
+				return false
+			}
+		}
+		if c.Comparator != VersComparatorEqual && c.Comparator != VersComparatorNotEqual {
+			ranges = append(ranges, c)
+		}
+	}
+	if len(ranges) == 0 {
+		return false
+	}
+
+	for i, current := range ranges {
+		if i == 0 && current.isLess() && v.CompareTo(current.Version) < 0 {
+			return true
+		}
+		if i == len(ranges)-1 {
+			return current.isGreater() && v.CompareTo(current.Version) > 0
+		}
+		next := ranges[i+1]
+		if current.isGreater() && next.isLess() && v.CompareTo(current.Version) > 0 && v.CompareTo(next.Version) < 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// convert 使用版本范围的方案重新解析版本，解析失败时返回原来的版本
+func (x *VersRange) convert(v *Version) *Version {
+	if v.SchemeName() == x.scheme.Name() {
+		return v
+	}
+	if converted, err := x.scheme.Parse(v.Raw); err == nil {
+		return converted
+	}
+	return v
+}
+
+// Filter 过滤出在版本范围内的版本，返回的版本保持输入时的顺序
+func (x *VersRange) Filter(versions []*Version) []*Version {
+	return FilterVersions(versions, x)
+}
+
+// BoundingIntervals 返回 Simplify 之后的版本范围对应的区间，"!=" 约束不影响区间
+func (x *VersRange) BoundingIntervals() []*VersionInterval {
+	intervals := make([]*VersionInterval, 0)
+	constraints := x.Simplify().Constraints
+	if len(constraints) == 1 && constraints[0].Comparator == VersComparatorStar {
+		return append(intervals, &VersionInterval{})
+	}
+	policyOf := func(c *VersConstraint) ContainsPolicy {
+		if c.Comparator == VersComparatorLessOrEqual || c.Comparator == VersComparatorGreaterOrEqual {
+			return ContainsPolicyYes
+		}
+		return ContainsPolicyNo
+	}
+	var lower *VersConstraint
+	for i, c := range constraints {
+		switch {
+		case c.Comparator == VersComparatorEqual:
+			intervals = append(intervals, NewVersionInterval(c.Version, ContainsPolicyYes, c.Version, ContainsPolicyYes))
+		case c.isGreater():
+			lower = c
+		case c.isLess():
+			interval := NewVersionInterval(nil, ContainsPolicyNone, c.Version, policyOf(c))
+			if lower != nil {
+				interval.Lower, interval.LowerPolicy = lower.Version, policyOf(lower)
+			}
+			intervals = append(intervals, interval)
+			lower = nil
+		}
+		if i == len(constraints)-1 && lower != nil {
+			intervals = append(intervals, NewVersionInterval(lower.Version, policyOf(lower), nil, ContainsPolicyNone))
+		}
+	}
+	return intervals
+}
+
+// Simplify 返回去掉冗余约束之后的版本范围，算法在 vers 规范中的 "Version constraints simplification" 的基础上
+// 还会去掉落在某个区间内的 "="、不在范围内的 "!=" 以及重复的约束，并把与边界相同的 "=" 或 "!=" 合并到边界中，
+// 所以 "vers:npm/>=1.0.0|>=1.5.0|<2.0.0" 这样含有冗余约束的版本范围简化之后能通过 Validate
+//
+// 例如 "vers:npm/>1.0.0|>=2.0.0|<3.0.0|<4.0.0" 简化后为 "vers:npm/>1.0.0|<4.0.0"，
+// "vers:npm/1.5.0|>=1.0.0|<2.0.0" 简化后为 "vers:npm/>=1.0.0|<2.0.0"，"vers:npm/2.0.0|<2.0.0" 简化后为 "vers:npm/<=2.0.0"
+//
+// 返回:
+//   - *VersRange: 简化之后的新版本范围
+func (x *VersRange) Simplify() *VersRange {
+	result := &VersRange{
+		VersioningScheme: x.VersioningScheme,
+		Constraints:      make([]*VersConstraint, 0, len(x.Constraints)),
+		scheme:           x.scheme,
+	}
+	for _, c := range x.Constraints {
+		if c.Comparator == VersComparatorStar {
+			result.Constraints = append(result.Constraints, c)
+			return result
+		}
+	}
+	if len(x.Constraints) <= 1 {
+		result.Constraints = append(result.Constraints, x.Constraints...)
+		return result
+	}
+
+	unequal := make([]*VersConstraint, 0)
+	equal := make([]*VersConstraint, 0)
+	bounds := make([]*VersConstraint, 0)
+	for _, c := range x.Constraints {
+		switch {
+		case c.Comparator == VersComparatorNotEqual:
+			unequal = appendDistinctVersConstraint(unequal, c)
+		case c.Comparator == VersComparatorEqual:
+			equal = appendDistinctVersConstraint(equal, c)
+		default:
+			bounds = append(bounds, c)
+		}
+	}
+
+	// 大于和小于交替出现：连续的大于只保留第一个，连续的小于只保留最后一个，版本相同时保留包含该版本的
+	kept := make([]*VersConstraint, 0, len(bounds))
+	for _, current := range bounds {
+		if len(kept) > 0 {
+			previous := kept[len(kept)-1]
+			sameVersion := previous.Version.CompareTo(current.Version) == 0
+			if previous.isGreater() && current.isGreater() {
+				if sameVersion && current.Comparator == VersComparatorGreaterOrEqual {
+					kept[len(kept)-1] = current
+				}
+				continue
+			}
+			if previous.isLess() && current.isLess() {
+				if sameVersion && previous.Comparator == VersComparatorLessOrEqual {
+					continue
+				}
+				kept = kept[:len(kept)-1]
+			}
+		}
+		kept = append(kept, current)
+	}
+
+	// 落在某个区间内的 "=" 是冗余的，与不包含边界的区间的边界相同时把边界改为包含
+	isolated := make([]*VersConstraint, 0, len(equal))
+	for _, c := range equal {
+		i := sort.Search(len(kept), func(i int) bool {
+			return kept[i].Version.CompareTo(c.Version) >= 0
+		})
+		switch {
+		case i < len(kept) && kept[i].Version.CompareTo(c.Version) == 0:
+			switch kept[i].Comparator {
+			case VersComparatorGreater:
+				kept[i] = &VersConstraint{Comparator: VersComparatorGreaterOrEqual, Version: kept[i].Version}
+			case VersComparatorLess:
+				kept[i] = &VersConstraint{Comparator: VersComparatorLessOrEqual, Version: kept[i].Version}
+			}
+		case i < len(kept) && kept[i].isLess():
+		case i == len(kept) && i > 0 && kept[i-1].isGreater():
+		default:
+			isolated = append(isolated, c)
+		}
+	}
+
+	result.Constraints = append(append(result.Constraints, isolated...), kept...)
+	result.sortConstraints()
+
+	// 不在范围内的版本不需要 "!="，与包含边界的约束版本相同时改为不包含边界
+	for _, c := range unequal {
+		if !versConstraintsContain(result.Constraints, c.Version) {
+			continue
+		}
+		excluded := false
+		for i, existing := range result.Constraints {
+			if existing.Version.CompareTo(c.Version) != 0 {
+				continue
+			}
+			switch existing.Comparator {
+			case VersComparatorEqual:
+				result.Constraints = append(result.Constraints[:i], result.Constraints[i+1:]...)
+			case VersComparatorGreaterOrEqual:
+				result.Constraints[i] = &VersConstraint{Comparator: VersComparatorGreater, Version: existing.Version}
+			case VersComparatorLessOrEqual:
+				result.Constraints[i] = &VersConstraint{Comparator: VersComparatorLess, Version: existing.Version}
+			}
+			excluded = true
+			break
+		}
+		if !excluded {
+			result.Constraints = append(result.Constraints, c)
+		}
+	}
+	result.sortConstraints()
+	return result
+}
+
+// appendDistinctVersConstraint 追加约束，已经有相同版本的约束时不再追加
+func appendDistinctVersConstraint(constraints []*VersConstraint, c *VersConstraint) []*VersConstraint {
+	for _, existing := range constraints {
+		if existing.Version.CompareTo(c.Version) == 0 {
+			return constraints
+		}
+	}
+	return append(constraints, c)
+}
+
+// String 返回规范形式的 vers 字符串
+func (x *VersRange) String() string {
+	constraints := make([]string, 0, len(x.Constraints))
+	for _, c := range x.Constraints {
+		constraints = append(constraints, c.String())
+	}
+	return "vers:" + x.VersioningScheme + "/" + strings.Join(constraints, "|")
+}
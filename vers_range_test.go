@@ -0,0 +1,164 @@
+package versions
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseVersRange 测试 vers 的解析、排序和规范形式，用例来自 vers 规范中的示例
+func TestParseVersRange(t *testing.T) {
+	cases := []struct {
+		versStr  string
+		expected string
+	}{
+		{"vers:npm/1.2.3", "vers:npm/1.2.3"},
+		{"vers:npm/>=1.2.3", "vers:npm/>=1.2.3"},
+		{"VERS:NPM/ <5.0.0 | >=2.0.0 | 1.2.3 ", "vers:npm/1.2.3|>=2.0.0|<5.0.0"},
+		{"vers:pypi/0.0.0|0.0.1|0.0.2|0.0.3|1.0|2.0pre1", "vers:pypi/0.0.0|0.0.1|0.0.2|0.0.3|1.0|2.0pre1"},
+		{"vers:gem/>=2.2.0|!=2.2.1|<2.3.0", "vers:gem/>=2.2.0|!=2.2.1|<2.3.0"},
+		{"vers:maven/>=1.0.0-beta1|<=1.7.5|>=7.0.0-M1|<=7.0.7|>=7.1.0|<=7.1.2|>=8.0.0-M1|<=8.0.1", "vers:maven/>=1.0.0-beta1|<=1.7.5|>=7.0.0-M1|<=7.0.7|>=7.1.0|<=7.1.2|>=8.0.0-M1|<=8.0.1"},
+		{"vers:deb/>=1:1.0~rc1|<1:2.0", "vers:deb/>=1:1.0~rc1|<1:2.0"},
+		{"vers:generic/1.0%7Cbeta", "vers:generic/1.0%7Cbeta"},
+		{"vers:npm/*", "vers:npm/*"},
+		{"vers:npm/|>=1.0.0|", "vers:npm/>=1.0.0"},
+
+		// 含有冗余约束的版本范围也能解析，约束保持输入的内容
+		{"vers:npm/>=1.0.0|>=1.5.0|<2.0.0", "vers:npm/>=1.0.0|>=1.5.0|<2.0.0"},
+		{"vers:npm/1.0.0|1.0.0", "vers:npm/1.0.0|1.0.0"},
+		{"vers:npm/1.0.0|<2.0.0", "vers:npm/1.0.0|<2.0.0"},
+	}
+	for _, c := range cases {
+		r, err := ParseVersRange(c.versStr)
+		if assert.Nil(t, err, c.versStr) {
+			assert.Equal(t, c.expected, r.String(), c.versStr)
+		}
+	}
+
+	assert.Equal(t, SchemePEP440, MustParseVersRange("vers:pypi/1.0").Scheme().Name())
+	assert.Equal(t, SchemeGeneric, MustParseVersRange("vers:generic/1.0").Scheme().Name())
+
+	invalid := []string{
+		"npm/1.0.0", "vers:/1.0.0", "vers:npm/", "vers:npm/>=", "vers:npm/*|1.0.0",
+		"vers:npm/not-semver", "vers:maven/[1.0,2.0)",
+	}
+	for _, versStr := range invalid {
+		_, err := ParseVersRange(versStr)
+		assert.True(t, errors.Is(err, ErrVersRangeInvalid), versStr)
+	}
+
+	// 没有注册的方案不会退回到通用方案
+	_, err := ParseVersRange("vers:tomee/>=1.0.0|<2.0.0")
+	assert.True(t, errors.Is(err, ErrSchemeNotFound))
+	_, ok := LookupVersScheme("tomee")
+	assert.False(t, ok)
+	_, err = NewVersRange("tomee")
+	assert.True(t, errors.Is(err, ErrSchemeNotFound))
+}
+
+// TestVersRange_Validate 测试严格按照 vers 规范校验约束，以及冗余的约束简化之后能通过校验
+func TestVersRange_Validate(t *testing.T) {
+	cases := []struct {
+		versStr    string
+		simplified string
+	}{
+		{"vers:npm/1.0.0|1.0.0", "vers:npm/1.0.0"},
+		{"vers:npm/>=1.0.0|>=2.0.0", "vers:npm/>=1.0.0"},
+		{"vers:npm/<1.0.0|<2.0.0", "vers:npm/<2.0.0"},
+		{"vers:npm/1.0.0|<2.0.0", "vers:npm/<2.0.0"},
+		{"vers:npm/>=1.0.0|>=1.5.0|<2.0.0", "vers:npm/>=1.0.0|<2.0.0"},
+		{"vers:npm/>1.0.0|1.5.0|<2.0.0|3.0.0", "vers:npm/>1.0.0|<2.0.0|3.0.0"},
+		{"vers:npm/2.0.0|<2.0.0", "vers:npm/<=2.0.0"},
+		{"vers:npm/1.0.0|>1.0.0", "vers:npm/>=1.0.0"},
+		{"vers:npm/>=1.0.0|!=1.0.0|<2.0.0", "vers:npm/>1.0.0|<2.0.0"},
+		{"vers:npm/>=1.0.0|>=1.2.0|!=3.0.0|<2.0.0", "vers:npm/>=1.0.0|<2.0.0"},
+		{"vers:npm/>=1.0.0|!=1.5.0|!=1.5.0|<2.0.0", "vers:npm/>=1.0.0|!=1.5.0|<2.0.0"},
+	}
+	for _, c := range cases {
+		r := MustParseVersRange(c.versStr)
+		assert.True(t, errors.Is(r.Validate(), ErrVersRangeInvalid), c.versStr)
+		simplified := r.Simplify()
+		assert.Equal(t, c.simplified, simplified.String(), c.versStr)
+		assert.Nil(t, simplified.Validate(), c.versStr)
+	}
+	assert.Nil(t, MustParseVersRange("vers:npm/>=1.0.0|!=1.5.0|<2.0.0").Validate())
+}
+
+// TestVersRange_Check 测试 vers 的包含判断会使用对应生态的比较规则
+func TestVersRange_Check(t *testing.T) {
+	cases := []struct {
+		versStr  string
+		version  string
+		expected bool
+	}{
+		{"vers:npm/*", "0.0.1", true},
+		{"vers:npm/1.2.3|>=2.0.0|<5.0.0", "1.2.3", true},
+		{"vers:npm/1.2.3|>=2.0.0|<5.0.0", "1.2.4", false},
+		{"vers:npm/1.2.3|>=2.0.0|<5.0.0", "2.0.0", true},
+		{"vers:npm/1.2.3|>=2.0.0|<5.0.0", "4.9.9", true},
+		{"vers:npm/1.2.3|>=2.0.0|<5.0.0", "5.0.0", false},
+		{"vers:npm/>=1.0.0|<2.0.0", "2.0.0-rc.1", true},
+		{"vers:npm/<1.0.0|>2.0.0", "0.1.0", true},
+		{"vers:npm/<1.0.0|>2.0.0", "1.5.0", false},
+		{"vers:npm/<1.0.0|>2.0.0", "2.0.1", true},
+		{"vers:gem/>=2.2.0|!=2.2.1|<2.3.0", "2.2.1", false},
+		{"vers:gem/>=2.2.0|!=2.2.1|<2.3.0", "2.2.2", true},
+		{"vers:gem/!=2.2.1", "2.2.2", false},
+		{"vers:pypi/>=1.0|<2.0", "2.0rc1", true},
+		{"vers:pypi/>=1.0|<2.0", "1.0.dev1", false},
+		{"vers:pypi/<=1.0", "1.0.0", true},
+		{"vers:maven/>=1.0|<2.0", "2.0-SNAPSHOT", true},
+		{"vers:maven/>=1.0|<2.0", "1.0-SNAPSHOT", false},
+		{"vers:deb/>=1:1.0~rc1|<1:2.0", "1:1.0", true},
+		{"vers:deb/>=1:1.0~rc1|<1:2.0", "1.5", false},
+		{"vers:rpm/<1.0", "1.0~rc1", true},
+
+		// 含有冗余约束时按照简化之后的含义判断
+		{"vers:npm/>=1.0.0|>=1.5.0|<2.0.0", "1.2.0", true},
+		{"vers:npm/>=1.0.0|>=1.5.0|<2.0.0", "2.0.0", false},
+		{"vers:npm/<1.0.0|<2.0.0|>=3.0.0", "1.5.0", true},
+		{"vers:npm/1.0.0|<2.0.0", "0.5.0", true},
+		{"vers:npm/>=1.0.0|!=1.0.0|<2.0.0", "1.0.0", false},
+	}
+	for _, c := range cases {
+		r := MustParseVersRange(c.versStr)
+		assert.Equal(t, c.expected, r.Check(NewVersion(c.version)), "%s contains %s", c.versStr, c.version)
+	}
+}
+
+// TestVersRange_Filter 测试过滤受影响的版本以及在有序版本组中查询
+func TestVersRange_Filter(t *testing.T) {
+	allVersions, err := ParseAll(SchemeSemVer, "0.9.0", "1.0.0", "1.2.3", "1.5.0", "2.0.0", "3.0.0")
+	assert.Nil(t, err)
+	r := MustParseVersRange("vers:npm/<1.0.0|>=1.5.0|!=2.0.0|<3.0.0")
+	assert.Equal(t, []string{"0.9.0", "1.5.0"}, versionRaws(r.Filter(allVersions)))
+	assert.Equal(t, []string{"0.9.0", "1.5.0"}, versionRaws(NewSortedVersionGroups(allVersions).QueryMatcher(r)))
+
+	intervals := r.BoundingIntervals()
+	assert.Equal(t, 2, len(intervals))
+	assert.Equal(t, "(,1.0.0)", intervals[0].String())
+	assert.Equal(t, "[1.5.0,3.0.0)", intervals[1].String())
+}
+
+// TestVersRange_Simplify 测试去掉冗余的约束
+func TestVersRange_Simplify(t *testing.T) {
+	constraint := func(comparator, version string) *VersConstraint {
+		return &VersConstraint{Comparator: comparator, Version: MustParse(version, SchemeSemVer)}
+	}
+	r, err := NewVersRange("npm",
+		constraint(VersComparatorLess, "4.0.0"),
+		constraint(VersComparatorGreater, "1.0.0"),
+		constraint(VersComparatorGreaterOrEqual, "2.0.0"),
+		constraint(VersComparatorNotEqual, "2.5.0"),
+		constraint(VersComparatorLess, "3.0.0"),
+	)
+	assert.Nil(t, err)
+	assert.True(t, errors.Is(r.Validate(), ErrVersRangeInvalid))
+	simplified := r.Simplify()
+	assert.Equal(t, "vers:npm/>1.0.0|!=2.5.0|<4.0.0", simplified.String())
+	assert.Nil(t, simplified.Validate())
+
+	single := MustParseVersRange("vers:npm/*").Simplify()
+	assert.Equal(t, "vers:npm/*", single.String())
+}
@@ -3,6 +3,7 @@ package versions
 import (
 	"encoding/json"
 	"errors"
+	"strconv"
 	"time"
 
 	compare_anything "github.com/golang-infrastructure/go-compare-anything"
@@ -51,10 +52,24 @@ type Version struct {
 	// PublicTime 此版本的发布时间
 	PublicTime time.Time `json:"public_time"`
 
+	// Epoch 版本号的纪元，纪元不同的版本之间直接按纪元大小排序，为0时表示没有纪元
+	// 例如对于 Debian 版本号 "1:2.30-3ubuntu1"，Epoch 为 1
+	Epoch int `json:"epoch,omitempty"`
+
 	// VersionNumbers 版本号中的数字部分
 	// 例如对于版本号 "v1.2.3-beta1"，VersionNumbers 为 [1,2,3]
 	VersionNumbers VersionNumbers `json:"version_numbers"`
 
+	// NumberDigits 数字部分中有数字超出 int 范围时，每个数字去掉前导0之后的十进制字符串，否则为nil
+	// 例如对于版本号 "1.20201012054050123456"，VersionNumbers 为 [1, math.MaxInt]，NumberDigits 为 ["1", "20201012054050123456"]，
+	// 比较时使用 NumberDigits，所以这样的版本之间也能正确排序；分组时仍然使用 VersionNumbers
+	NumberDigits []string `json:"number_digits,omitempty"`
+
+	// NumbersRaw 数字部分的原始文本，保留了原本的分隔符和前导0，由通用解析器和各个方案的 Parse 填充
+	// 例如对于版本号 "v1.01-rc1"，VersionNumbers 为 [1,1]，NumbersRaw 为 "1.01"，
+	// 所以 Prefix + NumbersRaw + Suffix 就是 Raw，见 Version.Format
+	NumbersRaw string `json:"numbers_raw,omitempty"`
+
 	// Prefix 版本号数字部分之前的前缀
 	// 例如对于版本号 "v1.2.3"，Prefix 为 "v"
 	Prefix VersionPrefix `json:"prefix"`
@@ -62,6 +77,14 @@ type Version struct {
 	// Suffix 版本号数字部分之后的后缀
 	// 例如对于版本号 "1.2.3-beta1"，Suffix 为 "-beta1"
 	Suffix VersionSuffix `json:"suffix"`
+
+	// Scheme 解析此版本时使用的版本号方案，为 nil 时表示使用通用方案
+	// 两个版本属于同一个方案时，比较操作会委托给该方案
+	Scheme Scheme `json:"-"`
+
+	// Detail 版本号方案解析出来的结构化信息，具体类型由方案决定，例如 SemVer 方案为 *SemVer
+	// 方案在比较时优先使用这里缓存的结构，避免重复解析
+	Detail any `json:"-"`
 }
 
 var _ compare_anything.Comparable[*Version] = &Version{}
@@ -93,7 +116,7 @@ func NewVersion(versionStr string) *Version {
 //
 // 返回:
 //   - *Version: 解析后的 Version 对象，如果解析失败则为 nil
-//   - error: 如果版本号无效，则返回 ErrVersionInvalid 错误
+//   - error: 如果版本号无效，则返回 *ParseError，它包装了 ErrVersionInvalid，可以通过 errors.As 取出出错的位置和原因
 //
 // 使用示例:
 //
@@ -102,12 +125,29 @@ func NewVersion(versionStr string) *Version {
 //	    log.Fatalf("无效的版本号: %v", err)
 //	}
 func NewVersionE(versionStr string) (*Version, error) {
-	v := NewVersionStringParser(versionStr).Parse()
-	if v.IsValid() {
-		return v, nil
-	} else {
-		return nil, ErrVersionInvalid
-	}
+	return NewVersionStringParser(versionStr).ParseE()
+}
+
+// NewVersionWithDiagnostics 以诊断模式解析版本号，同时返回能够解析但是可疑的写法
+//
+// 参数:
+//   - versionStr: 要解析的版本号字符串
+//
+// 返回:
+//   - *Version: 解析后的 Version 对象，如果解析失败则为 nil
+//   - []*ParseError: 警告，如连续的分隔符、数字的前导0
+//   - error: 与 NewVersionE 相同
+//
+// 使用示例:
+//
+//	version, warnings, err := versions.NewVersionWithDiagnostics("15.05.01")
+//	for _, warning := range warnings {
+//	    log.Printf("可疑的版本号: %v", warning)
+//	}
+func NewVersionWithDiagnostics(versionStr string) (*Version, []*ParseError, error) {
+	parser := NewVersionStringParser(versionStr).EnableDiagnostics()
+	v, err := parser.ParseE()
+	return v, parser.Warnings(), err
 }
 
 // NewVersions 批量创建多个 Version 对象
@@ -152,9 +192,23 @@ func (v *Version) IsValid() bool {
 	return len(v.VersionNumbers) > 0
 }
 
+// numberDigits 返回数字部分中每个数字的十进制字符串，优先使用 NumberDigits
+func (x *Version) numberDigits() []string {
+	if x.NumberDigits != nil {
+		return x.NumberDigits
+	}
+	digits := make([]string, len(x.VersionNumbers))
+	for i, n := range x.VersionNumbers {
+		digits[i] = strconv.Itoa(n)
+	}
+	return digits
+}
+
 // BuildGroupID 构造版本所属的组的ID
 //
 // 该方法根据版本号的数字部分生成一个组ID，用于将相似版本分组。
+// 如果版本的方案实现了 GroupingScheme，则使用方案给出的分组数字。
+// 如果版本带有纪元（Epoch 不为0），组ID的前面会加上"纪元:"，如 "1:2.30"。
 //
 // 返回:
 //   - string: 表示版本组的ID字符串
@@ -165,15 +219,25 @@ func (v *Version) IsValid() bool {
 //	groupID := version.BuildGroupID()
 //	fmt.Printf("版本组ID: %s\n", groupID)
 func (x *Version) BuildGroupID() string {
-	return x.VersionNumbers.BuildGroupID()
+	return buildGroupID(x.Epoch, groupNumbersOf(x))
+}
+
+// buildGroupID 根据纪元和版本号数字部分构造组ID
+func buildGroupID(epoch int, versionNumbers VersionNumbers) string {
+	if epoch != 0 {
+		return strconv.Itoa(epoch) + ":" + versionNumbers.BuildGroupID()
+	}
+	return versionNumbers.BuildGroupID()
 }
 
 // CompareTo 比较两个版本号
 //
-// 该方法按以下顺序比较两个版本号：
+// 如果两个版本是由同一个版本号方案（Scheme）解析出来的，则直接使用该方案的比较规则，
+// 否则按以下顺序比较两个版本号：
+// 0. 纪元不同时直接比较纪元
 // 1. 首先比较主版本号数字部分
 // 2. 其次比较发布时间
-// 3. 然后比较后缀
+// 3. 然后按照限定词的权重比较后缀，没有后缀的版本等价于正式版本
 // 4. 最后比较原始版本号字符串
 //
 // 参数:
@@ -196,10 +260,29 @@ func (x *Version) BuildGroupID() string {
 //	    fmt.Println("v1 > v2")
 //	}
 func (x *Version) CompareTo(target *Version) int {
+	if scheme := sharedScheme(x, target); scheme != nil {
+		return scheme.Compare(x, target)
+	}
+	return compareGeneric(x, target)
+}
+
+// compareGeneric 通用方案的比较规则，不考虑版本所属的方案
+func compareGeneric(x, target *Version) int {
+
+	// 0. 纪元不同的版本直接按纪元排序
+	if x.Epoch != target.Epoch {
+		return compareInt(x.Epoch, target.Epoch)
+	}
 
 	// 1. 先按照主版本号排序，仅当两个的主版本号都存在的时候才会进行比较，它们的长度不必相等，但是不能有为空的
+	//    有数字超出 int 范围时按照十进制字符串比较
 	if len(x.VersionNumbers) != 0 && len(target.VersionNumbers) != 0 {
-		r := x.VersionNumbers.CompareTo(target.VersionNumbers)
+		var r int
+		if x.NumberDigits != nil || target.NumberDigits != nil {
+			r = compareNumberDigits(x.numberDigits(), target.numberDigits())
+		} else {
+			r = x.VersionNumbers.CompareTo(target.VersionNumbers)
+		}
 		if r != 0 {
 			return r
 		}
@@ -218,12 +301,9 @@ func (x *Version) CompareTo(target *Version) int {
 		}
 	}
 
-	// 3. 然后按照后缀的字典序排序，加入有后缀的话
-	if x.Suffix != EmptyVersionSuffix && target.Suffix != EmptyVersionSuffix {
-		r := x.Suffix.CompareTo(target.Suffix)
-		if r != 0 {
-			return r
-		}
+	// 3. 然后按照后缀中限定词的权重排序，没有后缀等价于正式版本，所以 "1.0-rc1" < "1.0" < "1.0-sp1"
+	if r := x.Suffix.CompareTo(target.Suffix); r != 0 {
+		return r
 	}
 
 	// 4. 最后实在不行就是比较原始版本号的字典序吧
@@ -239,6 +319,33 @@ func (x *Version) CompareTo(target *Version) int {
 	return 0
 }
 
+// SchemeName 返回此版本所属的版本号方案的名称，未指定方案时返回 SchemeGeneric
+//
+// 返回:
+//   - string: 版本号方案的名称
+func (x *Version) SchemeName() string {
+	if x.Scheme == nil {
+		return SchemeGeneric
+	}
+	return x.Scheme.Name()
+}
+
+// Canonical 返回版本在其所属方案下的规范化字符串表示
+//
+// 返回:
+//   - string: 规范化后的版本字符串
+//
+// 使用示例:
+//
+//	v := versions.NewVersion("v1.2.3-rc1")
+//	fmt.Println(v.Canonical()) // 输出: v1.2.3-rc1
+func (x *Version) Canonical() string {
+	if x.Scheme == nil {
+		return (&GenericScheme{}).Canonical(x)
+	}
+	return x.Scheme.Canonical(x)
+}
+
 // String 返回版本的JSON字符串表示
 //
 // 该方法将Version对象序列化为JSON字符串，便于打印和调试。
@@ -0,0 +1,398 @@
+package versions
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrPrereleaseUnsupported 表示版本号方案不能表示所要求的预发布版本的错误
+	//
+	// 当 NextPrerelease 等操作得到的版本号按照方案的规则不排在对应的正式版本之前时返回此错误
+	ErrPrereleaseUnsupported = errors.New("prerelease not supported by version scheme")
+)
+
+// Bump 把第 index 个数字加1，并把它之后的数字都置为0，返回新的数字部分，原来的数字部分不会被修改
+//
+// 数字个数不足 index+1 个时先补0，已经是 math.MaxInt 的数字不再增加。
+//
+// 参数:
+//   - index: 要增加的数字的下标，0为主版本号，1为次版本号，2为修订号，小于0时原样返回
+//
+// 返回:
+//   - VersionNumbers: 新的数字部分
+//
+// 使用示例:
+//
+//	numbers := versions.NewVersionNumbers([]int{1, 9, 3})
+//	fmt.Println(numbers.Bump(1)) // 输出: [1 10 0]
+func (x VersionNumbers) Bump(index int) VersionNumbers {
+	if index < 0 {
+		return x.Pad(len(x))
+	}
+	numbers := x.Pad(index + 1)
+	if numbers[index] < math.MaxInt {
+		numbers[index]++
+	}
+	for i := index + 1; i < len(numbers); i++ {
+		numbers[i] = 0
+	}
+	return numbers
+}
+
+// BumpMajor 主版本号加1，次版本号和修订号置为0，如 [1, 9, 3] 变为 [2, 0, 0]
+func (x VersionNumbers) BumpMajor() VersionNumbers {
+	return x.Bump(0)
+}
+
+// BumpMinor 次版本号加1，修订号置为0，如 [1, 9, 3] 变为 [1, 10, 0]
+func (x VersionNumbers) BumpMinor() VersionNumbers {
+	return x.Bump(1)
+}
+
+// BumpPatch 修订号加1，如 [1, 9, 3] 变为 [1, 9, 4]
+func (x VersionNumbers) BumpPatch() VersionNumbers {
+	return x.Bump(2)
+}
+
+// Truncate 只保留前 n 个数字，返回新的数字部分
+//
+// 参数:
+//   - n: 保留的数字个数，小于0时按0处理，超过数字个数时保留全部
+//
+// 返回:
+//   - VersionNumbers: 新的数字部分，如 [1, 2, 3, 4] 保留2个为 [1, 2]
+func (x VersionNumbers) Truncate(n int) VersionNumbers {
+	if n < 0 {
+		n = 0
+	}
+	if n > len(x) {
+		n = len(x)
+	}
+	return append(make(VersionNumbers, 0, n), x[:n]...)
+}
+
+// Pad 在末尾补0直到有 n 个数字，返回新的数字部分
+//
+// 参数:
+//   - n: 补齐之后的数字个数，不超过现有的数字个数时不补
+//
+// 返回:
+//   - VersionNumbers: 新的数字部分，如 [1, 2] 补齐到3个为 [1, 2, 0]
+func (x VersionNumbers) Pad(n int) VersionNumbers {
+	if n < len(x) {
+		n = len(x)
+	}
+	numbers := make(VersionNumbers, n)
+	copy(numbers, x)
+	return numbers
+}
+
+// Bump 把第 index 个数字加1，它之后的数字置为0，并且去掉后缀，返回新的版本
+//
+// 新版本保留原版本的纪元、前缀、数字之间的分隔符和数字的位数（如 "2024.05" 变为 "2024.06"），
+// 超出 int 范围的数字也能正确地加1。原版本属于通用方案以外的方案时，新的版本号会用该方案重新解析。
+//
+// 参数:
+//   - index: 要增加的数字的下标，0为主版本号，1为次版本号，2为修订号，小于0时只去掉后缀
+//
+// 返回:
+//   - *Version: 新的版本，原来的版本不会被修改
+//   - error: 原版本所属的方案不接受新的版本号时（如 CalVer 中不存在的日期 "2024.05.32"）返回该方案给出的错误
+//
+// 使用示例:
+//
+//	v := versions.NewVersion("v1.9.3-rc.2")
+//	next, err := v.Bump(2)
+//	if err != nil {
+//	    log.Fatalf("计算新版本失败: %v", err)
+//	}
+//	fmt.Println(next.Raw) // 输出: v1.9.4
+func (x *Version) Bump(index int) (*Version, error) {
+	return x.withComponents(x.bumpedDigits(index), EmptyVersionSuffix)
+}
+
+// bumpedDigits 返回把第 index 个数字加1、之后的数字置为0的数字部分，index 小于0时返回原本的数字部分的副本
+//
+// 有数字部分的原始文本时以原始文本中的数字为准，所以 Maven 的 "1.0.0" 不会因为 VersionNumbers 去掉了末尾的0而变成 "1"，
+// 有前导0的数字修改之后仍然保持原来的位数。
+func (x *Version) bumpedDigits(index int) []string {
+	digits := x.rawDigits()
+	if index < 0 {
+		return digits
+	}
+	widths := make([]int, len(digits))
+	for i, digit := range digits {
+		if len(digit) > 1 && digit[0] == '0' {
+			widths[i] = len(digit)
+		}
+	}
+	for len(digits) < index+1 {
+		digits = append(digits, "0")
+		widths = append(widths, 0)
+	}
+	digits[index] = incrementDigitString(digits[index])
+	for i := index + 1; i < len(digits); i++ {
+		digits[i] = "0"
+	}
+	for i := index; i < len(digits); i++ {
+		if len(digits[i]) < widths[i] {
+			digits[i] = strings.Repeat("0", widths[i]-len(digits[i])) + digits[i]
+		}
+	}
+	return digits
+}
+
+// rawDigits 返回数字部分原始文本中的各个数字，保留前导0；没有原始文本时为去掉前导0的数字
+func (x *Version) rawDigits() []string {
+	if x.NumbersRaw == "" {
+		return append(make([]string, 0, len(x.VersionNumbers)), x.numberDigits()...)
+	}
+	return strings.FieldsFunc(x.NumbersRaw, func(c rune) bool {
+		return c < '0' || c > '9'
+	})
+}
+
+// BumpMajor 主版本号加1，如 "v1.9.3-rc.2" 变为 "v2.0.0"，见 Bump
+func (x *Version) BumpMajor() (*Version, error) {
+	return x.Bump(0)
+}
+
+// BumpMinor 次版本号加1，如 "v1.9.3-rc.2" 变为 "v1.10.0"，见 Bump
+func (x *Version) BumpMinor() (*Version, error) {
+	return x.Bump(1)
+}
+
+// BumpPatch 修订号加1，如 "v1.9.3-rc.2" 变为 "v1.9.4"，见 Bump
+func (x *Version) BumpPatch() (*Version, error) {
+	return x.Bump(2)
+}
+
+// IsPrerelease 判断是否是预发布版本，即按照版本所属方案的规则排在去掉后缀之后的正式版本之前，
+// 如 "1.0-rc1"、"1.0-SNAPSHOT"，以及 Debian 的 "1.0~rc1"；而 Debian 的修订号 "1.0-1" 不是预发布版本
+func (x *Version) IsPrerelease() bool {
+	if x.Suffix == EmptyVersionSuffix {
+		return false
+	}
+	release, err := x.withComponents(x.bumpedDigits(-1), EmptyVersionSuffix)
+	if err != nil {
+		return false
+	}
+	return x.CompareTo(release) < 0
+}
+
+// NextPrerelease 计算下一个预发布版本，预发布后缀的写法由版本所属的方案决定，见 PrereleaseScheme
+//
+// - 已经是同一个限定词的预发布版本时，紧跟在限定词之后的数字加1，保留原本的写法以及构建元数据等后面的内容，如 "1.0-rc.1+build.5" 变为 "1.0-rc.2+build.5"，"1.0-RC1" 变为 "1.0-RC2"，限定词之后没有数字时补上序号1
+// - 是其它限定词的预发布版本时，换成新的限定词并保留构建元数据，如 "1.0-beta.3" 变为 "1.0-rc.1"；如果这样得到的版本反而更旧（如从 rc 换成 beta），则同时把修订号加1
+// - 不是预发布版本时，修订号加1并且开始新的预发布，如 "1.0.2" 变为 "1.0.3-rc.1"，Debian 的 "1.0.2-1" 变为 "1.0.3~rc1"
+//
+// 参数:
+//   - identifier: 预发布的限定词，如 "rc"、"beta"
+//
+// 返回:
+//   - *Version: 新的版本，原来的版本不会被修改
+//   - error: 方案不接受新的版本号时返回该方案给出的错误，新的版本号按照方案的规则不是预发布版本时（如 Maven 中未知的限定词排在正式版本之后）返回包装了 ErrPrereleaseUnsupported 的错误
+//
+// 使用示例:
+//
+//	v := versions.MustParse("1.0.2-1", versions.SchemeDebian)
+//	next, err := v.NextPrerelease("rc")
+//	if err != nil {
+//	    log.Fatalf("计算预发布版本失败: %v", err)
+//	}
+//	fmt.Println(next.Raw) // 输出: 1.0.3~rc1
+func (x *Version) NextPrerelease(identifier string) (*Version, error) {
+	if !x.IsPrerelease() {
+		return x.startPrerelease(2, identifier)
+	}
+
+	if strings.EqualFold(x.Suffix.Qualifier(), identifier) {
+		suffix := x.Suffix.nextPrerelease(prereleaseFirst(x.Scheme, identifier))
+		return x.checkPrerelease(x.withComponents(x.bumpedDigits(-1), suffix))
+	}
+
+	next, err := x.startPrerelease(-1, identifier)
+	if err != nil || next.CompareTo(x) <= 0 {
+		return x.startPrerelease(2, identifier)
+	}
+	return next, nil
+}
+
+// startPrerelease 把第 index 个数字加1之后开始以 identifier 为限定词的预发布，
+// index 小于0时数字不变，这时新版本仍然是同一个版本，保留原来的构建元数据
+func (x *Version) startPrerelease(index int, identifier string) (*Version, error) {
+	suffix := prereleaseSuffixOf(x.Scheme, identifier)
+	if index < 0 {
+		suffix += x.Suffix.buildMetadata()
+	}
+	return x.checkPrerelease(x.withComponents(x.bumpedDigits(index), suffix))
+}
+
+// checkPrerelease 检查新的版本按照方案的规则是预发布版本
+func (x *Version) checkPrerelease(v *Version, err error) (*Version, error) {
+	if err != nil {
+		return nil, err
+	}
+	if !v.IsPrerelease() {
+		return nil, fmt.Errorf("%w: %q is not a prerelease in scheme %s", ErrPrereleaseUnsupported, v.Raw, v.SchemeName())
+	}
+	return v, nil
+}
+
+// prereleaseSuffixOf 返回方案下以 identifier 为限定词的第一个预发布后缀，方案没有实现 PrereleaseScheme 时为 "-identifier.1"
+func prereleaseSuffixOf(scheme Scheme, identifier string) VersionSuffix {
+	if scheme, ok := scheme.(PrereleaseScheme); ok {
+		return scheme.PrereleaseSuffix(identifier)
+	}
+	return VersionSuffix("-" + identifier + ".1")
+}
+
+// prereleaseFirst 返回方案的预发布后缀中限定词之后的部分，即序号1的写法，如 "-rc.1" 中的 ".1"、"~rc1" 中的 "1"
+func prereleaseFirst(scheme Scheme, identifier string) string {
+	start := strings.ToLower(string(prereleaseSuffixOf(scheme, identifier)))
+	if i := strings.Index(start, strings.ToLower(identifier)); i >= 0 {
+		return start[i+len(identifier):]
+	}
+	return ".1"
+}
+
+// nextPrerelease 把第一个限定词之后紧跟着的数字加1，构建元数据（"+" 之后的部分）和提交哈希等其它内容不变，
+// 限定词之后没有数字时在限定词后面插入 first，即序号1的写法，如 ".1"
+func (x VersionSuffix) nextPrerelease(first string) VersionSuffix {
+	tokens := x.Tokens()
+	qualifier := -1
+	for i, token := range tokens {
+		if token.Kind == SuffixTokenQualifier {
+			qualifier = i
+			break
+		}
+	}
+	target := -1
+	for i := qualifier + 1; i < len(tokens); i++ {
+		token := tokens[i]
+		if token.Kind == SuffixTokenNumber {
+			target = i
+		}
+		if token.Kind != SuffixTokenSeparator || strings.Contains(token.Text, "+") {
+			break
+		}
+	}
+	s := &strings.Builder{}
+	for i, token := range tokens {
+		if i == target {
+			s.WriteString(incrementDigitString(token.Text))
+		} else {
+			s.WriteString(token.Text)
+		}
+		if i == qualifier && target < 0 {
+			s.WriteString(first)
+		}
+	}
+	return VersionSuffix(s.String())
+}
+
+// buildMetadata 返回后缀中从 "+" 开始的构建元数据，如 "-rc.1+build.5" 返回 "+build.5"，没有时返回空后缀
+func (x VersionSuffix) buildMetadata() VersionSuffix {
+	if i := strings.IndexByte(string(x), '+'); i >= 0 {
+		return x[i:]
+	}
+	return EmptyVersionSuffix
+}
+
+// Finalize 把预发布版本变为对应的正式版本，即去掉后缀但是保留构建元数据，如 "v1.9.3-rc.2" 变为 "v1.9.3"，
+// "1.0.0-rc.1+build.5" 变为 "1.0.0+build.5"；不是预发布版本时返回一个相同的新版本
+//
+// 返回:
+//   - *Version: 新的版本，原来的版本不会被修改
+//   - error: 方案不接受新的版本号时返回该方案给出的错误
+func (x *Version) Finalize() (*Version, error) {
+	if !x.IsPrerelease() {
+		return x.withComponents(x.bumpedDigits(-1), x.Suffix)
+	}
+	return x.withComponents(x.bumpedDigits(-1), x.Suffix.buildMetadata())
+}
+
+// Truncate 只保留数字部分的前 n 个数字，前缀和后缀不变，如 "v1.2.3-rc1" 保留2个数字为 "v1.2-rc1"
+//
+// 参数:
+//   - n: 保留的数字个数，小于1时按1处理
+//
+// 返回:
+//   - *Version: 新的版本，原来的版本不会被修改
+//   - error: 方案不接受新的版本号时返回该方案给出的错误
+func (x *Version) Truncate(n int) (*Version, error) {
+	if n < 1 {
+		n = 1
+	}
+	digits := x.bumpedDigits(-1)
+	if n < len(digits) {
+		digits = digits[:n]
+	}
+	return x.withComponents(digits, x.Suffix)
+}
+
+// Pad 在数字部分的末尾补0直到有 n 个数字，前缀和后缀不变，如 "v1.2-rc1" 补齐到3个数字为 "v1.2.0-rc1"
+//
+// 参数:
+//   - n: 补齐之后的数字个数，不超过现有的数字个数时不补
+//
+// 返回:
+//   - *Version: 新的版本，原来的版本不会被修改
+//   - error: 方案不接受新的版本号时（如 CalVer 的 "2024.05" 补齐为 "2024.05.0"）返回该方案给出的错误
+func (x *Version) Pad(n int) (*Version, error) {
+	digits := x.bumpedDigits(-1)
+	for len(digits) < n {
+		digits = append(digits, "0")
+	}
+	return x.withComponents(digits, x.Suffix)
+}
+
+// withComponents 使用原版本的纪元、前缀、分隔符和方案，以及新的数字和后缀构造一个版本
+//
+// 原版本属于通用方案以外的方案时，会用该方案重新解析，使得方案的结构化信息与新的版本号一致；
+// 该方案不接受新的版本号时（如 CalVer 的 "2024.05.32"）返回该方案给出的错误，而不是退回到通用方案。
+func (x *Version) withComponents(digits []string, suffix VersionSuffix) (*Version, error) {
+	numbers := make(VersionNumbers, len(digits))
+	overflow := false
+	for i, digit := range digits {
+		n, err := strconv.Atoi(digit)
+		if err != nil {
+			n, overflow = math.MaxInt, true
+		}
+		numbers[i] = n
+	}
+	v := &Version{
+		Epoch:          x.Epoch,
+		VersionNumbers: numbers,
+		NumbersRaw:     strings.Join(digits, x.numbersDelimiter()),
+		Prefix:         x.Prefix,
+		Suffix:         suffix,
+		Scheme:         x.Scheme,
+	}
+	if overflow {
+		v.NumberDigits = make([]string, len(digits))
+		for i, digit := range digits {
+			v.NumberDigits[i] = trimLeadingZeros(digit)
+		}
+	}
+	v.Raw = v.Format(VersionFormatOriginal)
+
+	if _, isGeneric := x.Scheme.(*GenericScheme); x.Scheme != nil && !isGeneric {
+		return x.Scheme.Parse(v.Raw)
+	}
+	return v, nil
+}
+
+// numbersDelimiter 返回数字部分原本使用的分隔符，没有时为 DefaultVersionDelimiter
+func (x *Version) numbersDelimiter() string {
+	for _, c := range x.NumbersRaw {
+		if c < '0' || c > '9' {
+			return string(c)
+		}
+	}
+	return DefaultVersionDelimiter
+}
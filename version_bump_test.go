@@ -0,0 +1,252 @@
+package versions
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestVersionNumbers_Bump 测试数字部分的加1、截断和补齐
+func TestVersionNumbers_Bump(t *testing.T) {
+	numbers := NewVersionNumbers([]int{1, 9, 3})
+	assert.Equal(t, VersionNumbers{2, 0, 0}, numbers.BumpMajor())
+	assert.Equal(t, VersionNumbers{1, 10, 0}, numbers.BumpMinor())
+	assert.Equal(t, VersionNumbers{1, 9, 4}, numbers.BumpPatch())
+	assert.Equal(t, VersionNumbers{1, 9, 3, 1}, numbers.Bump(3))
+	assert.Equal(t, VersionNumbers{1, 9, 3}, numbers.Bump(-1))
+	assert.Equal(t, VersionNumbers{1, 1}, NewVersionNumbers([]int{1}).BumpMinor())
+
+	assert.Equal(t, VersionNumbers{1, 9}, numbers.Truncate(2))
+	assert.Equal(t, VersionNumbers{1, 9, 3}, numbers.Truncate(10))
+	assert.Equal(t, VersionNumbers{}, numbers.Truncate(-1))
+	assert.Equal(t, VersionNumbers{1, 9, 3, 0, 0}, numbers.Pad(5))
+	assert.Equal(t, VersionNumbers{1, 9, 3}, numbers.Pad(2))
+
+	// 原来的数字部分不会被修改
+	assert.Equal(t, VersionNumbers{1, 9, 3}, numbers)
+	truncated := numbers.Truncate(2)
+	_ = append(truncated, 100)
+	assert.Equal(t, VersionNumbers{1, 9, 3}, numbers)
+}
+
+// TestVersion_Bump 测试版本号的加1
+func TestVersion_Bump(t *testing.T) {
+	v := NewVersion("v1.9.3-rc.2")
+	assert.Equal(t, "v1.9.4", mustVersion(v.BumpPatch()).Raw)
+	assert.Equal(t, "v1.10.0", mustVersion(v.BumpMinor()).Raw)
+	assert.Equal(t, "v2.0.0", mustVersion(v.BumpMajor()).Raw)
+	assert.Equal(t, "v1.9.3.1", mustVersion(v.Bump(3)).Raw)
+	assert.Equal(t, "v1.9.3-rc.2", v.Raw)
+
+	// 加1之后的版本与重新解析得到的版本相同，并且更新
+	for _, raw := range []string{"v1.9.3-rc.2", "release-1.02-rc1", "1:2.30", "curl-7.85.0", "1"} {
+		origin := NewVersion(raw)
+		for index := 0; index < 4; index++ {
+			bumped := mustVersion(origin.Bump(index))
+			assert.Equal(t, NewVersion(bumped.Raw), bumped, raw)
+			assert.Greater(t, bumped.CompareTo(origin), 0, raw)
+		}
+	}
+
+	// 保留纪元、数字之间的分隔符和有前导0的数字的位数
+	assert.Equal(t, "1:2.31", mustVersion(NewVersion("1:2.30").BumpMinor()).Raw)
+	assert.Equal(t, "15.05.02", mustVersion(NewVersion("15.05.01").BumpPatch()).Raw)
+	assert.Equal(t, "15.06.00", mustVersion(NewVersion("15.05.01").BumpMinor()).Raw)
+	assert.Equal(t, "15.10", mustVersion(NewVersion("15.09").BumpMinor()).Raw)
+	config := DefaultParserConfig()
+	config.NumberDelimiters = []rune{'.', '_'}
+	curl := NewVersionStringParserWithConfig("curl-7_85_0", config).Parse()
+	assert.Equal(t, "curl-7_86_0", mustVersion(curl.BumpMinor()).Raw)
+
+	// 超出 int 范围的数字
+	huge := NewVersion("1.99999999999999999999999")
+	bumped := mustVersion(huge.BumpMinor())
+	assert.Equal(t, "1.100000000000000000000000", bumped.Raw)
+	assert.Equal(t, []string{"1", "100000000000000000000000"}, bumped.NumberDigits)
+	assert.Greater(t, bumped.CompareTo(huge), 0)
+	assert.Equal(t, []string{"1", "99999999999999999999999"}, huge.NumberDigits)
+
+	// 其它方案的版本会重新解析
+	semver := mustVersion(MustParse("1.9.3-rc.2+build.5", SchemeSemVer).BumpMinor())
+	assert.Equal(t, "1.10.0", semver.Raw)
+	assert.Equal(t, SchemeSemVer, semver.Scheme.Name())
+	assert.Equal(t, &SemVer{Major: 1, Minor: 10, Patch: 0}, semver.Detail)
+
+	// 使用方案自己的纪元分隔符，数字部分以原始文本为准
+	pep440 := mustVersion(MustParse("1!2.0", SchemePEP440).BumpPatch())
+	assert.Equal(t, "1!2.0.1", pep440.Raw)
+	assert.Equal(t, SchemePEP440, pep440.SchemeName())
+	assert.NotNil(t, pep440.Detail)
+	assert.Equal(t, "1.0.1", mustVersion(MustParse("1.0.0", SchemeMaven).BumpPatch()).Raw)
+	calver := mustVersion(MustParse("2024.05.30", SchemeCalVer).BumpPatch())
+	assert.Equal(t, "2024.05.31", calver.Raw)
+	assert.Equal(t, SchemeCalVer, calver.SchemeName())
+
+	// 方案不接受新的版本号时返回错误，不会退回到通用方案
+	calver, err := MustParse("2024.05.31", SchemeCalVer).BumpPatch()
+	assert.True(t, errors.Is(err, ErrVersionInvalid))
+	assert.Nil(t, calver)
+	_, err = MustParse("2024.05", SchemeCalVer).Pad(3)
+	assert.True(t, errors.Is(err, ErrVersionInvalid))
+}
+
+// TestVersion_NextPrerelease 测试计算下一个预发布版本
+func TestVersion_NextPrerelease(t *testing.T) {
+	testCases := []struct {
+		raw        string
+		identifier string
+		want       string
+	}{
+		{"v1.9.3-rc.1", "rc", "v1.9.3-rc.2"},
+		{"1.0-RC1", "rc", "1.0-RC2"},
+		{"1.0-rc.9", "rc", "1.0-rc.10"},
+		{"1.0-rc", "rc", "1.0-rc.1"},
+		{"1.0-beta.3", "rc", "1.0-rc.1"},
+		{"1.0.0-rc.1", "beta", "1.0.1-beta.1"},
+		{"1.0.2", "rc", "1.0.3-rc.1"},
+		{"v2", "alpha", "v2.0.1-alpha.1"},
+		{"1.0.0-rc.1+build.5", "rc", "1.0.0-rc.2+build.5"},
+		{"1.0.0-beta.1+build.5", "rc", "1.0.0-rc.1+build.5"},
+		{"1.2.3-rc.1-5-gabc1234", "rc", "1.2.3-rc.2-5-gabc1234"},
+		{"1.0-rc+build.5", "rc", "1.0-rc.1+build.5"},
+		{"1.0-rc1.5", "rc", "1.0-rc2.5"},
+	}
+	for _, testCase := range testCases {
+		origin := NewVersion(testCase.raw)
+		next, err := origin.NextPrerelease(testCase.identifier)
+		assert.Nil(t, err, testCase.raw)
+		assert.Equal(t, testCase.want, next.Raw, testCase.raw)
+		assert.True(t, next.IsPrerelease(), testCase.raw)
+		assert.Greater(t, next.CompareTo(origin), 0, testCase.raw)
+		assert.Equal(t, testCase.raw, origin.Raw)
+	}
+
+	next := mustVersion(MustParse("1.0.0-rc.1", SchemeSemVer).NextPrerelease("rc"))
+	assert.Equal(t, "1.0.0-rc.2", next.Raw)
+	assert.Equal(t, &SemVer{Major: 1, Minor: 0, Patch: 0, Prerelease: []string{"rc", "2"}}, next.Detail)
+	next = mustVersion(MustParse("1.0.0-rc.1+build.5", SchemeSemVer).NextPrerelease("rc"))
+	assert.Equal(t, &SemVer{Major: 1, Minor: 0, Patch: 0, Prerelease: []string{"rc", "2"}, Build: []string{"build", "5"}}, next.Detail)
+
+	// 保留原本的数字部分和纪元分隔符
+	next = mustVersion(MustParse("1.0.0-alpha-1", SchemeMaven).NextPrerelease("rc"))
+	assert.Equal(t, "1.0.0-rc.1", next.Raw)
+	assert.Equal(t, SchemeMaven, next.SchemeName())
+	assert.Equal(t, "1!2.0rc2", mustVersion(MustParse("1!2.0rc1", SchemePEP440).NextPrerelease("rc")).Raw)
+}
+
+// TestVersion_NextPrereleaseScheme 测试各个方案使用自己的写法表示预发布版本，
+// 并且按照方案自己的规则排在原版本之后、修订号加1的正式版本之前
+func TestVersion_NextPrereleaseScheme(t *testing.T) {
+	testCases := []struct {
+		scheme string
+		raw    string
+		want   string
+		again  string
+	}{
+		{SchemeGeneric, "1.0.2", "1.0.3-rc.1", "1.0.3-rc.2"},
+		{SchemeSemVer, "1.0.2", "1.0.3-rc.1", "1.0.3-rc.2"},
+		{SchemeGo, "v1.0.2", "v1.0.3-rc.1", "v1.0.3-rc.2"},
+		{SchemeCargo, "1.0.2", "1.0.3-rc.1", "1.0.3-rc.2"},
+		{SchemeNuGet, "1.0.2", "1.0.3-rc.1", "1.0.3-rc.2"},
+		{SchemeMaven, "1.0.2", "1.0.3-rc.1", "1.0.3-rc.2"},
+		{SchemeCalVer, "2024.05.1", "2024.05.2-rc.1", "2024.05.2-rc.2"},
+		{SchemeDebian, "1.0.2-1", "1.0.3~rc1", "1.0.3~rc2"},
+		{SchemeDebian, "1:1.0.2", "1:1.0.3~rc1", "1:1.0.3~rc2"},
+		{SchemeRPM, "1.0.2-1.el8", "1.0.3~rc1", "1.0.3~rc2"},
+		{SchemePacman, "1.0.2-1", "1.0.3rc1", "1.0.3rc2"},
+		{SchemeAPK, "1.0.2-r1", "1.0.3_rc1", "1.0.3_rc2"},
+		{SchemePEP440, "1.0.2", "1.0.3rc1", "1.0.3rc2"},
+	}
+	for _, testCase := range testCases {
+		origin := MustParse(testCase.raw, testCase.scheme)
+		patch := mustVersion(origin.BumpPatch())
+		next, err := origin.NextPrerelease("rc")
+		assert.Nil(t, err, testCase.scheme)
+		assert.Equal(t, testCase.want, next.Raw, testCase.scheme)
+		assert.Equal(t, testCase.scheme, next.SchemeName())
+		assert.True(t, next.IsPrerelease(), testCase.scheme)
+		assert.Greater(t, next.CompareTo(origin), 0, testCase.scheme)
+		assert.Less(t, next.CompareTo(patch), 0, testCase.scheme)
+
+		again, err := next.NextPrerelease("rc")
+		assert.Nil(t, err, testCase.scheme)
+		assert.Equal(t, testCase.again, again.Raw, testCase.scheme)
+		assert.Greater(t, again.CompareTo(next), 0, testCase.scheme)
+		assert.Less(t, again.CompareTo(patch), 0, testCase.scheme)
+		assert.Equal(t, patch.Raw, mustVersion(again.Finalize()).Raw, testCase.scheme)
+	}
+
+	// 限定词之后没有数字时使用方案的写法补上序号1
+	assert.Equal(t, "1.0~rc1", mustVersion(MustParse("1.0~rc", SchemeDebian).NextPrerelease("rc")).Raw)
+	assert.Equal(t, "1.0_rc1", mustVersion(MustParse("1.0_rc", SchemeAPK).NextPrerelease("rc")).Raw)
+
+	// 方案不能表示的预发布版本返回错误，不会退回到通用方案
+	_, err := MustParse("1.0.2", SchemeMaven).NextPrerelease("foo")
+	assert.True(t, errors.Is(err, ErrPrereleaseUnsupported))
+	_, err = MustParse("1.0.2", SchemeAPK).NextPrerelease("p")
+	assert.True(t, errors.Is(err, ErrPrereleaseUnsupported))
+	_, err = MustParse("1.0.2", SchemePEP440).NextPrerelease("foo")
+	assert.True(t, errors.Is(err, ErrVersionInvalid))
+	_, err = MustParse("2024.05.31", SchemeCalVer).NextPrerelease("rc")
+	assert.True(t, errors.Is(err, ErrVersionInvalid))
+}
+
+// TestVersion_Finalize 测试预发布版本转为正式版本
+func TestVersion_Finalize(t *testing.T) {
+	assert.True(t, NewVersion("v1.9.3-rc.2").IsPrerelease())
+	assert.True(t, NewVersion("1.0-SNAPSHOT").IsPrerelease())
+	assert.False(t, NewVersion("1.0").IsPrerelease())
+	assert.False(t, NewVersion("1.0-SP1").IsPrerelease())
+
+	// 按照方案自己的规则判断是否是预发布版本
+	assert.True(t, MustParse("1.0~rc1-1", SchemeDebian).IsPrerelease())
+	assert.False(t, MustParse("1.0-1", SchemeDebian).IsPrerelease())
+	assert.False(t, MustParse("1.0^git1", SchemeRPM).IsPrerelease())
+	assert.True(t, MustParse("1.0rc1", SchemePacman).IsPrerelease())
+	assert.False(t, MustParse("1.0-r1", SchemeAPK).IsPrerelease())
+	assert.False(t, MustParse("1.0.post1", SchemePEP440).IsPrerelease())
+	assert.True(t, MustParse("v0.0.0-20190101000000-abcdefabcdef", SchemeGo).IsPrerelease())
+
+	assert.Equal(t, "v1.9.3", mustVersion(NewVersion("v1.9.3-rc.2").Finalize()).Raw)
+	assert.Equal(t, "1.0", mustVersion(NewVersion("1.0-SNAPSHOT").Finalize()).Raw)
+	assert.Equal(t, "1.0-SP1", mustVersion(NewVersion("1.0-SP1").Finalize()).Raw)
+	assert.Equal(t, "1.0", mustVersion(MustParse("1.0~rc1-1", SchemeDebian).Finalize()).Raw)
+
+	// 与 NextPrerelease 一样保留构建元数据
+	assert.Equal(t, "1.0.0+build.5", mustVersion(NewVersion("1.0.0-rc.1+build.5").Finalize()).Raw)
+	finalized := mustVersion(MustParse("1.0.0-rc.1+build.5", SchemeSemVer).Finalize())
+	assert.Equal(t, &SemVer{Major: 1, Minor: 0, Patch: 0, Build: []string{"build", "5"}}, finalized.Detail)
+	assert.Equal(t, "1.0+local", mustVersion(MustParse("1.0rc1+local", SchemePEP440).Finalize()).Raw)
+
+	v := NewVersion("1.0.0")
+	finalized = mustVersion(v.Finalize())
+	assert.Equal(t, v, finalized)
+	assert.NotSame(t, v, finalized)
+}
+
+// TestVersion_Truncate 测试版本号的截断和补齐
+func TestVersion_Truncate(t *testing.T) {
+	v := NewVersion("v1.2.3.4-rc1")
+	assert.Equal(t, "v1.2-rc1", mustVersion(v.Truncate(2)).Raw)
+	assert.Equal(t, "v1-rc1", mustVersion(v.Truncate(0)).Raw)
+	assert.Equal(t, "v1.2.3.4-rc1", mustVersion(v.Truncate(10)).Raw)
+	assert.Equal(t, "v1.2.3.4.0.0-rc1", mustVersion(v.Pad(6)).Raw)
+	assert.Equal(t, "v1.2.3.4-rc1", mustVersion(v.Pad(2)).Raw)
+	padded := mustVersion(NewVersion("v1.2-rc1").Pad(3))
+	assert.Equal(t, "v1.2.0-rc1", padded.Raw)
+	assert.Equal(t, 0, padded.CompareTo(NewVersion("v1.2.0-rc1")))
+
+	// 截断之后再补齐不会影响原来的版本
+	huge := NewVersion("1.99999999999999999999999.3")
+	assert.Equal(t, "1.99999999999999999999999.0", mustVersion(mustVersion(huge.Truncate(2)).Pad(3)).Raw)
+	assert.Equal(t, []string{"1", "99999999999999999999999", "3"}, huge.NumberDigits)
+}
+
+// mustVersion 取出测试用的新版本，计算失败时 panic
+func mustVersion(v *Version, err error) *Version {
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
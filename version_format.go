@@ -0,0 +1,107 @@
+package versions
+
+import (
+	"strconv"
+	"strings"
+)
+
+// 常用的版本号格式，用于 Version.Format
+const (
+
+	// VersionFormatOriginal 由各个组成部分原样还原版本号，解析得到的版本满足 v.Format(VersionFormatOriginal) == v.Raw
+	VersionFormatOriginal = "%E%P%n%S"
+
+	// VersionFormatNormalized 去掉数字的前导0并且统一使用点号分隔数字，如 "v1.01-rc1" 格式化为 "v1.1-rc1"
+	VersionFormatNormalized = "%E%P%N%S"
+
+	// VersionFormatMajorMinor 只保留主版本号和次版本号，如 "v1.2.3-rc1" 格式化为 "1.2"
+	VersionFormatMajorMinor = "%M.%m"
+
+	// VersionFormatMajorMinorPatch 只保留前三个数字，不足的补0，如 "v1.2-rc1" 格式化为 "1.2.0"
+	VersionFormatMajorMinorPatch = "%M.%m.%p"
+
+	// VersionFormatCanonicalV 以 "v" 开头的三段式版本号加上原本的后缀，如 "release-1.2-rc1" 格式化为 "v1.2.0-rc1"
+	VersionFormatCanonicalV = "v%M.%m.%p%S"
+)
+
+// Format 按照模板格式化版本号
+//
+// 模板中支持以下占位符，其它字符原样输出：
+// - %R 原始的版本号字符串 Raw
+// - %E 纪元加上分隔符，如 "1:"，PEP 440 等实现了 EpochSeparatorScheme 的方案使用自己的分隔符，没有纪元时为空
+// - %P 前缀
+// - %n 数字部分的原始文本，保留原本的分隔符和前导0，没有原始文本时与 %N 相同
+// - %N 以点号连接的数字部分，去掉了前导0
+// - %M、%m、%p、%r 第1到第4个数字，即主版本号、次版本号、修订号、第四位，不存在时为0
+// - %S 后缀
+// - %% 百分号本身
+//
+// 不认识的占位符原样输出。
+//
+// 参数:
+//   - layout: 模板，可以使用 VersionFormatXxx 常量
+//
+// 返回:
+//   - string: 格式化之后的版本号
+//
+// 使用示例:
+//
+//	v := versions.NewVersion("release-1.02-rc1")
+//	fmt.Println(v.Format(versions.VersionFormatOriginal))   // 输出: release-1.02-rc1
+//	fmt.Println(v.Format(versions.VersionFormatNormalized)) // 输出: release-1.2-rc1
+//	fmt.Println(v.Format(versions.VersionFormatCanonicalV)) // 输出: v1.2.0-rc1
+//	fmt.Println(v.Format("%M.x"))                           // 输出: 1.x
+func (x *Version) Format(layout string) string {
+	s := &strings.Builder{}
+	for i := 0; i < len(layout); i++ {
+		if layout[i] != '%' || i+1 >= len(layout) {
+			s.WriteByte(layout[i])
+			continue
+		}
+		i++
+		switch layout[i] {
+		case 'R':
+			s.WriteString(x.Raw)
+		case 'E':
+			if x.Epoch != 0 {
+				s.WriteString(strconv.Itoa(x.Epoch))
+				s.WriteString(epochSeparatorOf(x))
+			}
+		case 'P':
+			s.WriteString(string(x.Prefix))
+		case 'n':
+			if x.NumbersRaw != "" {
+				s.WriteString(x.NumbersRaw)
+			} else {
+				s.WriteString(strings.Join(x.numberDigits(), DefaultVersionDelimiter))
+			}
+		case 'N':
+			s.WriteString(strings.Join(x.numberDigits(), DefaultVersionDelimiter))
+		case 'M':
+			s.WriteString(x.numberDigitAt(0))
+		case 'm':
+			s.WriteString(x.numberDigitAt(1))
+		case 'p':
+			s.WriteString(x.numberDigitAt(2))
+		case 'r':
+			s.WriteString(x.numberDigitAt(3))
+		case 'S':
+			s.WriteString(string(x.Suffix))
+		case '%':
+			s.WriteByte('%')
+		default:
+			s.WriteByte('%')
+			s.WriteByte(layout[i])
+		}
+	}
+	return s.String()
+}
+
+// numberDigitAt 返回数字部分中第 i 个数字的十进制字符串，不存在时为 "0"
+func (x *Version) numberDigitAt(i int) string {
+	digits := x.numberDigits()
+	if i < len(digits) {
+		return digits[i]
+	}
+	return "0"
+}
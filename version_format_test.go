@@ -0,0 +1,90 @@
+package versions
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestVersion_Format_RoundTrip 测试通用解析器得到的版本能够由各个组成部分原样还原
+func TestVersion_Format_RoundTrip(t *testing.T) {
+	files, err := filepath.Glob("./test_data/*.txt")
+	assert.Nil(t, err)
+	assert.NotEmpty(t, files)
+
+	raws := []string{
+		"", "abc", "1.", ".1", "v1.01", "1.....1........alpha1", "15.05.01", "curl-7_85_0",
+		"17-0.15.0-alpha1", "v1-rev4-1.18.0-rc", "2.5.6-1-f8bff243", "1.20201012054050123456",
+		"0000000000000000000000001.007", "版本1.0-正式版",
+	}
+	for _, file := range files {
+		lines, err := ReadVersionsStringFromFile(file)
+		assert.Nil(t, err)
+		raws = append(raws, lines...)
+	}
+	for _, raw := range raws {
+		v := NewVersion(raw)
+		assert.Equal(t, v.Raw, v.Format(VersionFormatOriginal), raw)
+		assert.Equal(t, v, NewVersion(v.Format(VersionFormatOriginal)), raw)
+	}
+
+	// 数字的原始文本保留了分隔符和前导0
+	v := NewVersion("1.01")
+	assert.Equal(t, "1.01", v.NumbersRaw)
+	assert.Equal(t, "1.1", NewVersion("1.1").NumbersRaw)
+	assert.Equal(t, ".1", NewVersion(".1").NumbersRaw)
+
+	// 其它方案得到的版本
+	schemeRaws := map[string][]string{
+		SchemeSemVer:   {"1.0.0-rc.1+b", "99999999999999999999.0.0"},
+		SchemeGo:       {"v1.0.1-0.20200101000000-abcdefabcdef", "v2.0.0+incompatible"},
+		SchemeNuGet:    {"1.0", "1.0.0.0-beta", "01.2.3+meta"},
+		SchemeMaven:    {"1.0", "1.0.0-alpha-1", "1.0-SNAPSHOT", "1.0.a", "1..0", "01.2"},
+		SchemePEP440:   {"1!2.0", "1!1.0rc1", "v1.0.post1", "1.0a1", "1.0+local", "01.2"},
+		SchemeDebian:   {"1:2.30-3ubuntu1", "1.0~rc1", "01.2"},
+		SchemeRPM:      {"1:1.00-2", "1.0~rc1", "1.0^post"},
+		SchemePacman:   {"1:1..0-1", "1.0rc", "r1234.5b55fc2"},
+		SchemeAPK:      {"01.2_rc1-r1", "1.0a"},
+		SchemeComposer: {"1.0", "v1.0.0.0-RC1", "1.0.x-dev", "dev-main", "dev-feature/foo"},
+		SchemeCargo:    {"1.0.0-alpha"},
+		SchemeGem:      {"1.0", "1.0.0-rc1", "1.0.a", "01.2"},
+		SchemeCalVer:   {"2024.01.02", "2024.1-beta"},
+	}
+	for scheme, raws := range schemeRaws {
+		for _, raw := range raws {
+			v := MustParse(raw, scheme)
+			assert.Equal(t, raw, v.Format(VersionFormatOriginal), "%s %s", scheme, raw)
+		}
+	}
+	assert.Equal(t, "1!2", MustParse("1!2.0", SchemePEP440).Format(VersionFormatNormalized))
+}
+
+// TestVersion_Format 测试按照模板格式化版本号
+func TestVersion_Format(t *testing.T) {
+	v := NewVersion("release-1.02-rc1")
+	assert.Equal(t, "release-1.02-rc1", v.Format(VersionFormatOriginal))
+	assert.Equal(t, "release-1.2-rc1", v.Format(VersionFormatNormalized))
+	assert.Equal(t, "1.2", v.Format(VersionFormatMajorMinor))
+	assert.Equal(t, "1.2.0", v.Format(VersionFormatMajorMinorPatch))
+	assert.Equal(t, "v1.2.0-rc1", v.Format(VersionFormatCanonicalV))
+	assert.Equal(t, "1.x", v.Format("%M.x"))
+	assert.Equal(t, "release-1.02-rc1 => 1.2.0.0", v.Format("%R => %M.%m.%p.%r"))
+	assert.Equal(t, "100% %q %", v.Format("100%% %q %"))
+
+	v = NewVersion("1.2.99999999999999999999.4")
+	assert.Equal(t, "99999999999999999999", v.Format("%p"))
+	assert.Equal(t, "4", v.Format("%r"))
+	assert.Equal(t, "1.2.99999999999999999999.4", v.Format(VersionFormatNormalized))
+
+	v = MustParse("1:2.30-3ubuntu1", SchemeDebian)
+	assert.Equal(t, "1:2.30-3ubuntu1", v.Format(VersionFormatNormalized))
+	assert.Equal(t, "v2.30.0-3ubuntu1", v.Format(VersionFormatCanonicalV))
+
+	// 手动构造的版本没有数字的原始文本
+	v = &Version{VersionNumbers: VersionNumbers{1, 2}, Prefix: "v", Suffix: "-rc1"}
+	assert.Equal(t, "v1.2-rc1", v.Format(VersionFormatOriginal))
+
+	assert.Equal(t, "", NewVersion("").Format(VersionFormatOriginal))
+	assert.Equal(t, "0.0.0", NewVersion("abc").Format(VersionFormatMajorMinorPatch))
+}
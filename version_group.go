@@ -32,6 +32,9 @@ import (
 //	sortedVersions := group.SortVersions()
 type VersionGroup struct {
 
+	// GroupEpoch 组中版本的纪元，只有纪元相同的版本才会被分到同一个组
+	GroupEpoch int
+
 	// GroupVersionNumbers 组的版本号中的数字部分
 	// 例如对于版本号 "1.2.x"，GroupVersionNumbers 为 [1,2]
 	GroupVersionNumbers VersionNumbers
@@ -67,7 +70,7 @@ func NewVersionGroup(groupVersionNumbers VersionNumbers) *VersionGroup {
 // NewVersionGroupFromVersions 从版本数组创建一个版本组
 //
 // 该方法基于给定的版本数组创建一个版本组。所有版本将被添加到同一个组中，
-// 该组的数字部分取自第一个版本的数字部分。
+// 该组的纪元和数字部分取自第一个版本。
 //
 // 参数:
 //   - versions: 要添加到组中的版本数组
@@ -83,7 +86,8 @@ func NewVersionGroupFromVersions(versions []*Version) *VersionGroup {
 	if len(versions) == 0 {
 		return nil
 	}
-	group := NewVersionGroup(versions[0].VersionNumbers)
+	group := NewVersionGroup(groupNumbersOf(versions[0]))
+	group.GroupEpoch = versions[0].Epoch
 	for _, v := range versions {
 		group.Add(v)
 	}
@@ -138,7 +142,7 @@ func (x *VersionGroup) Contains(v *Version) bool {
 
 // ID 返回组的ID
 //
-// 该方法返回版本组的唯一标识符，由其数字部分生成。
+// 该方法返回版本组的唯一标识符，由其纪元和数字部分生成，与组内版本的 BuildGroupID 一致。
 //
 // 返回:
 //   - string: 版本组的ID，例如 "1.2"
@@ -148,12 +152,12 @@ func (x *VersionGroup) Contains(v *Version) bool {
 //	group := versions.NewVersionGroup(versions.NewVersionNumbers([]int{1, 2}))
 //	groupID := group.ID() // 返回 "1.2"
 func (x *VersionGroup) ID() string {
-	return x.GroupVersionNumbers.BuildGroupID()
+	return buildGroupID(x.GroupEpoch, x.GroupVersionNumbers)
 }
 
 // CompareTo 比较两个版本组的大小
 //
-// 该方法通过比较版本组的数字部分来确定两个版本组的先后顺序。
+// 该方法先比较版本组的纪元，纪元相同时再比较数字部分来确定两个版本组的先后顺序。
 //
 // 参数:
 //   - target: 要比较的目标版本组
@@ -170,6 +174,9 @@ func (x *VersionGroup) ID() string {
 //	    fmt.Println("group1 比 group2 旧")
 //	}
 func (x *VersionGroup) CompareTo(target *VersionGroup) int {
+	if x.GroupEpoch != target.GroupEpoch {
+		return compareInt(x.GroupEpoch, target.GroupEpoch)
+	}
 	return x.GroupVersionNumbers.CompareTo(target.GroupVersionNumbers)
 }
 
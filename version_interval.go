@@ -0,0 +1,150 @@
+package versions
+
+import "strings"
+
+// VersionInterval 表示一个版本区间
+//
+// 区间的上下界都是可选的，为 nil 时表示该方向上没有边界。边界是否包含在区间内由 ContainsPolicy 控制：
+// ContainsPolicyYes（以及未指定的 ContainsPolicyNone）表示闭区间，ContainsPolicyNo 表示开区间。
+//
+// 使用示例:
+//
+//	// [1.0.0, 2.0.0)
+//	interval := versions.NewVersionInterval(
+//	    versions.NewVersion("1.0.0"), versions.ContainsPolicyYes,
+//	    versions.NewVersion("2.0.0"), versions.ContainsPolicyNo,
+//	)
+//	fmt.Println(interval.Check(versions.NewVersion("1.5.0"))) // 输出: true
+type VersionInterval struct {
+
+	// Lower 区间的下界，为 nil 时表示没有下界
+	Lower *Version
+
+	// LowerPolicy 下界是否包含在区间内
+	LowerPolicy ContainsPolicy
+
+	// Upper 区间的上界，为 nil 时表示没有上界
+	Upper *Version
+
+	// UpperPolicy 上界是否包含在区间内
+	UpperPolicy ContainsPolicy
+}
+
+var _ VersionMatcher = &VersionInterval{}
+
+// NewVersionInterval 创建一个版本区间
+//
+// 参数:
+//   - lower: 下界，为 nil 时表示没有下界
+//   - lowerPolicy: 下界是否包含在区间内
+//   - upper: 上界，为 nil 时表示没有上界
+//   - upperPolicy: 上界是否包含在区间内
+//
+// 返回:
+//   - *VersionInterval: 新创建的版本区间
+func NewVersionInterval(lower *Version, lowerPolicy ContainsPolicy, upper *Version, upperPolicy ContainsPolicy) *VersionInterval {
+	return &VersionInterval{
+		Lower:       lower,
+		LowerPolicy: lowerPolicy,
+		Upper:       upper,
+		UpperPolicy: upperPolicy,
+	}
+}
+
+// Check 判断版本是否落在区间内
+func (x *VersionInterval) Check(v *Version) bool {
+	return x.aboveLower(v) && x.belowUpper(v)
+}
+
+// aboveLower 版本是否满足下界的要求
+func (x *VersionInterval) aboveLower(v *Version) bool {
+	if x.Lower == nil {
+		return true
+	}
+	r := v.CompareTo(x.Lower)
+	if x.LowerPolicy == ContainsPolicyNo {
+		return r > 0
+	}
+	return r >= 0
+}
+
+// belowUpper 版本是否满足上界的要求
+func (x *VersionInterval) belowUpper(v *Version) bool {
+	if x.Upper == nil {
+		return true
+	}
+	r := v.CompareTo(x.Upper)
+	if x.UpperPolicy == ContainsPolicyNo {
+		return r < 0
+	}
+	return r <= 0
+}
+
+// IsEmpty 判断区间是否一定不包含任何版本，例如 (2.0, 1.0) 或者 [1.0, 1.0)
+func (x *VersionInterval) IsEmpty() bool {
+	if x.Lower == nil || x.Upper == nil {
+		return false
+	}
+	r := x.Lower.CompareTo(x.Upper)
+	if r > 0 {
+		return true
+	}
+	if r == 0 {
+		return x.LowerPolicy == ContainsPolicyNo || x.UpperPolicy == ContainsPolicyNo
+	}
+	return false
+}
+
+// Intersect 求两个区间的交集，返回一个新的区间
+//
+// 参数:
+//   - target: 另一个区间
+//
+// 返回:
+//   - *VersionInterval: 交集，可以通过 IsEmpty 判断交集是否为空
+func (x *VersionInterval) Intersect(target *VersionInterval) *VersionInterval {
+	result := &VersionInterval{
+		Lower:       x.Lower,
+		LowerPolicy: x.LowerPolicy,
+		Upper:       x.Upper,
+		UpperPolicy: x.UpperPolicy,
+	}
+	if target.Lower != nil {
+		if result.Lower == nil {
+			result.Lower, result.LowerPolicy = target.Lower, target.LowerPolicy
+		} else if r := target.Lower.CompareTo(result.Lower); r > 0 || (r == 0 && target.LowerPolicy == ContainsPolicyNo) {
+			result.Lower, result.LowerPolicy = target.Lower, target.LowerPolicy
+		}
+	}
+	if target.Upper != nil {
+		if result.Upper == nil {
+			result.Upper, result.UpperPolicy = target.Upper, target.UpperPolicy
+		} else if r := target.Upper.CompareTo(result.Upper); r < 0 || (r == 0 && target.UpperPolicy == ContainsPolicyNo) {
+			result.Upper, result.UpperPolicy = target.Upper, target.UpperPolicy
+		}
+	}
+	return result
+}
+
+// String 返回区间的数学表示，如 "[1.0.0,2.0.0)"、"(,1.5]"
+func (x *VersionInterval) String() string {
+	s := strings.Builder{}
+	if x.Lower != nil && x.LowerPolicy != ContainsPolicyNo {
+		s.WriteString("[")
+	} else {
+		s.WriteString("(")
+	}
+	if x.Lower != nil {
+		s.WriteString(x.Lower.Raw)
+	}
+	s.WriteString(",")
+	if x.Upper != nil {
+		s.WriteString(x.Upper.Raw)
+	}
+	if x.Upper != nil && x.UpperPolicy != ContainsPolicyNo {
+		s.WriteString("]")
+	} else {
+		s.WriteString(")")
+	}
+	return s.String()
+}
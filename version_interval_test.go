@@ -0,0 +1,36 @@
+package versions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestVersionInterval_Check 测试区间的包含判断
+func TestVersionInterval_Check(t *testing.T) {
+	interval := NewVersionInterval(NewVersion("1.0.0"), ContainsPolicyYes, NewVersion("2.0.0"), ContainsPolicyNo)
+	assert.True(t, interval.Check(NewVersion("1.0.0")))
+	assert.True(t, interval.Check(NewVersion("1.9.9")))
+	assert.False(t, interval.Check(NewVersion("2.0.0")))
+	assert.False(t, interval.Check(NewVersion("0.9")))
+
+	unbounded := NewVersionInterval(nil, ContainsPolicyNone, NewVersion("1.5"), ContainsPolicyYes)
+	assert.True(t, unbounded.Check(NewVersion("0.0.1")))
+	assert.True(t, unbounded.Check(NewVersion("1.5")))
+	assert.False(t, unbounded.Check(NewVersion("1.5.1")))
+	assert.Equal(t, "(,1.5]", unbounded.String())
+	assert.Equal(t, "[1.0.0,2.0.0)", interval.String())
+}
+
+// TestVersionInterval_Intersect 测试区间求交集
+func TestVersionInterval_Intersect(t *testing.T) {
+	a := NewVersionInterval(NewVersion("1.0"), ContainsPolicyYes, NewVersion("3.0"), ContainsPolicyYes)
+	b := NewVersionInterval(NewVersion("2.0"), ContainsPolicyNo, nil, ContainsPolicyNone)
+	assert.Equal(t, "(2.0,3.0]", a.Intersect(b).String())
+	assert.False(t, a.Intersect(b).IsEmpty())
+
+	c := NewVersionInterval(nil, ContainsPolicyNone, NewVersion("1.0"), ContainsPolicyNo)
+	assert.Equal(t, "[1.0,1.0)", a.Intersect(c).String())
+	assert.True(t, a.Intersect(c).IsEmpty())
+	assert.True(t, NewVersionInterval(NewVersion("2.0"), ContainsPolicyYes, NewVersion("1.0"), ContainsPolicyYes).IsEmpty())
+}
@@ -0,0 +1,50 @@
+package versions
+
+// VersionMatcher 用于判断一个版本是否满足某种条件
+//
+// 版本约束（Constraint）、版本区间（VersionInterval）以及各个生态的版本范围表达式都实现了这个接口，
+// 因此可以用同一套 FilterVersions 对 []*Version 进行过滤。
+//
+// 使用示例:
+//
+//	c, _ := versions.ParseConstraint(">=1.2.0 <2.0.0")
+//	matched := versions.FilterVersions(allVersions, c)
+type VersionMatcher interface {
+
+	// Check 判断版本是否满足条件
+	Check(v *Version) bool
+}
+
+// FilterVersions 过滤出满足条件的版本，返回的版本保持输入时的顺序
+//
+// 参数:
+//   - versions: 待过滤的版本数组
+//   - matcher: 过滤条件
+//
+// 返回:
+//   - []*Version: 满足条件的版本数组
+//
+// 使用示例:
+//
+//	allVersions := versions.NewVersions("1.0.0", "1.5.0", "2.0.0")
+//	c, _ := versions.ParseConstraint("^1.0")
+//	matched := versions.FilterVersions(allVersions, c) // ["1.0.0", "1.5.0"]
+func FilterVersions(versions []*Version, matcher VersionMatcher) []*Version {
+	result := make([]*Version, 0)
+	for _, v := range versions {
+		if matcher.Check(v) {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// BoundedVersionMatcher 能够给出外接区间的 VersionMatcher
+//
+// 满足条件的版本一定落在某个外接区间内，SortedVersionGroups 可以据此用二分查找缩小需要检查的范围。
+type BoundedVersionMatcher interface {
+	VersionMatcher
+
+	// BoundingIntervals 返回外接区间，多个区间之间是"或者"的关系
+	BoundingIntervals() []*VersionInterval
+}
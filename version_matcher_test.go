@@ -0,0 +1,38 @@
+package versions
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestFilterVersions 测试使用 VersionMatcher 过滤版本
+func TestFilterVersions(t *testing.T) {
+	allVersions := NewVersions("2.0.0", "1.0.0", "1.5.0")
+	interval := NewVersionInterval(NewVersion("1.0.0"), ContainsPolicyNo, nil, ContainsPolicyNone)
+	assert.Equal(t, []string{"2.0.0", "1.5.0"}, versionRaws(FilterVersions(allVersions, interval)))
+	assert.Equal(t, []string{}, versionRaws(FilterVersions(nil, interval)))
+}
+
+// assertQueryMatcherMatchesFilter 断言 SortedVersionGroups.QueryMatcher 通过外接区间查到的版本与逐个过滤的结果一致
+//
+// 期望的结果只用 Scheme.Compare 排序，不依赖分组，这样分组顺序与比较顺序不一致时也能被发现；
+// 比较结果相等的版本之间的先后顺序不做要求。
+func assertQueryMatcherMatchesFilter(t *testing.T, scheme string, matcher VersionMatcher, versionStrs ...string) {
+	t.Helper()
+	allVersions, err := ParseAll(scheme, versionStrs...)
+	assert.Nil(t, err)
+	expected := FilterVersions(allVersions, matcher)
+	sort.SliceStable(expected, func(i, j int) bool {
+		return expected[i].CompareTo(expected[j]) < 0
+	})
+	actual := NewSortedVersionGroups(allVersions).QueryMatcher(matcher)
+	assert.ElementsMatch(t, versionRaws(expected), versionRaws(actual), matcher)
+	if len(expected) != len(actual) {
+		return
+	}
+	for i := range expected {
+		assert.Equal(t, 0, expected[i].CompareTo(actual[i]), "%v: %s at %d, want %s", matcher, actual[i].Raw, i, expected[i].Raw)
+	}
+}
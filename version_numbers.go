@@ -121,3 +121,22 @@ func (x VersionNumbers) BuildGroupID() string {
 	}
 	return s.String()
 }
+
+// trimLeadingZeros 去掉数字字符串的前导0，全是0时保留一个0
+func trimLeadingZeros(digits string) string {
+	digits = strings.TrimLeft(digits, "0")
+	if digits == "" {
+		return "0"
+	}
+	return digits
+}
+
+// compareNumberDigits 比较两个用数字字符串表示的数字部分，规则与 VersionNumbers.CompareTo 相同，但是不会溢出
+func compareNumberDigits(a, b []string) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if r := compareDigitString(a[i], b[i]); r != 0 {
+			return r
+		}
+	}
+	return compareInt(len(a), len(b))
+}
@@ -1,6 +1,10 @@
 package versions
 
-import compare_anything "github.com/golang-infrastructure/go-compare-anything"
+import (
+	"strings"
+
+	compare_anything "github.com/golang-infrastructure/go-compare-anything"
+)
 
 // VersionSuffix 表示版本号的后缀，版本号中数字后面的部分
 //
@@ -58,8 +62,13 @@ func (x VersionSuffix) IsEmpty() bool {
 
 // CompareTo 比较两个版本后缀的优先级
 //
-// 该方法根据字典序比较两个版本后缀的优先级。目前使用简单的字符串比较，
-// 未来计划引入权重系统，为不同类型的后缀（如 alpha、beta、rc）分配不同的权重。
+// 后缀通过 Tokens 切分为限定词、数字、日期等元素之后逐个比较，分隔符和提交哈希不参与比较：
+//   - 限定词按照权重比较，如 dev < alpha < beta < milestone < rc < snapshot < 正式版本 < sp，
+//     "a1"、"b1"、"m1"、"cr"、"ga"、"final"、"release" 等别名与对应的限定词等价，没有注册过的限定词排在最后并按字典序比较
+//   - 数字和日期按数值比较，所以 "-M10" > "-M3"
+//   - 后缀结束之后等价于正式版本，所以 "-rc1" < "" < "-sp1"
+//
+// 自定义的限定词可以通过 RegisterSuffixQualifier 注册。
 //
 // 参数:
 //   - target: 要比较的目标版本后缀
@@ -69,22 +78,58 @@ func (x VersionSuffix) IsEmpty() bool {
 //
 // 使用示例:
 //
-//	suffix1 := versions.VersionSuffix("-alpha1")
-//	suffix2 := versions.VersionSuffix("-beta1")
+//	suffix1 := versions.VersionSuffix("-rc1")
+//	suffix2 := versions.VersionSuffix("-RELEASE")
 //
 //	result := suffix1.CompareTo(suffix2)
 //	if result < 0 {
-//	    fmt.Println("alpha1 后缀的优先级低于 beta1 后缀")
+//	    fmt.Println("rc1 后缀的优先级低于 RELEASE 后缀")
 //	}
-//
-// 注意:
-//   - TODO 2023-5-16 17:26:02 未来将引入后缀权重系统，使不同类型后缀的比较更符合语义化版本规范
 func (x VersionSuffix) CompareTo(target VersionSuffix) int {
-	if x > target {
-		return 1
-	} else if x < target {
-		return -1
-	} else {
+	if x == target {
 		return 0
 	}
+	a, b := splitSuffixItems(string(x)), splitSuffixItems(string(target))
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var itemA, itemB *suffixItem
+		if i < len(a) {
+			itemA = a[i]
+		}
+		if i < len(b) {
+			itemB = b[i]
+		}
+		if r := compareSuffixItem(itemA, itemB); r != 0 {
+			return r
+		}
+	}
+	return 0
+}
+
+// SemVerParts 按照 SemVer 的约定把后缀拆分为预发布标识符和构建元数据标识符
+//
+// 后缀中第一个"+"之后的部分是构建元数据，之前的部分去掉开头的"-"后是预发布部分，两者都按点号切分。
+// 该方法是宽松的，不会校验标识符中的字符是否合法，需要严格校验时请使用 ParseSemVer。
+//
+// 返回:
+//   - prerelease: 预发布标识符，没有时为nil
+//   - build: 构建元数据标识符，没有时为nil
+//
+// 使用示例:
+//
+//	prerelease, build := versions.VersionSuffix("-rc.1+build.5").SemVerParts()
+//	// prerelease: ["rc", "1"]
+//	// build: ["build", "5"]
+func (x VersionSuffix) SemVerParts() (prerelease []string, build []string) {
+	s := string(x)
+	if i := strings.IndexByte(s, '+'); i >= 0 {
+		if i+1 < len(s) {
+			build = strings.Split(s[i+1:], ".")
+		}
+		s = s[:i]
+	}
+	s = strings.TrimLeft(s, "-.")
+	if s != "" {
+		prerelease = strings.Split(s, ".")
+	}
+	return prerelease, build
 }
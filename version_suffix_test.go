@@ -50,4 +50,39 @@ func TestVersionSuffix_CompareTo(t *testing.T) {
 	assert.Equal(t, -1, empty.CompareTo(nonEmpty)) // 空 < 非空
 	assert.Equal(t, 1, nonEmpty.CompareTo(empty))  // 非空 > 空
 	assert.Equal(t, 0, empty.CompareTo(empty))     // 空 = 空
+
+	// 限定词按照权重比较，数字按照数值比较
+	ordered := []VersionSuffix{"-dev", "-alpha1", "-a2", "-beta", "-b2", "-M3", "-M10", "-preview", "-rc1", "-CR2", "-rc10", "-SNAPSHOT", "", "-SP1", "-foo"}
+	for i := 0; i < len(ordered); i++ {
+		for j := i + 1; j < len(ordered); j++ {
+			assert.Equal(t, -1, ordered[i].CompareTo(ordered[j]), "%s < %s", ordered[i], ordered[j])
+			assert.Equal(t, 1, ordered[j].CompareTo(ordered[i]), "%s > %s", ordered[j], ordered[i])
+		}
+	}
+
+	// 别名与正式版本等价
+	for _, release := range []VersionSuffix{"-RELEASE", ".GA", "-final", "-0"} {
+		assert.Equal(t, 0, release.CompareTo(empty), release)
+	}
+	assert.Equal(t, 0, VersionSuffix("-rc1").CompareTo(".RC.01"))
+
+	// 日期按数值比较，提交哈希不参与比较
+	assert.Equal(t, -1, VersionSuffix("-snapshot.20201012.5405").CompareTo("-snapshot.20201013.1"))
+	assert.Equal(t, -1, VersionSuffix("-1-f8bff243").CompareTo("-2-0a1b2c3d"))
+	assert.Equal(t, 0, VersionSuffix("-1-f8bff243").CompareTo("-1-0a1b2c3d"))
+}
+
+// TestVersionSuffix_SemVerParts 测试后缀拆分为预发布和构建元数据
+func TestVersionSuffix_SemVerParts(t *testing.T) {
+	prerelease, build := VersionSuffix("-rc.1+build.5").SemVerParts()
+	assert.Equal(t, []string{"rc", "1"}, prerelease)
+	assert.Equal(t, []string{"build", "5"}, build)
+
+	prerelease, build = VersionSuffix("+meta").SemVerParts()
+	assert.Nil(t, prerelease)
+	assert.Equal(t, []string{"meta"}, build)
+
+	prerelease, build = EmptyVersionSuffix.SemVerParts()
+	assert.Nil(t, prerelease)
+	assert.Nil(t, build)
 }
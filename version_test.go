@@ -2,9 +2,13 @@ package versions
 
 import (
 	"encoding/json"
+	"errors"
+	"math"
+	"strconv"
 	"testing"
 	"time"
 
+	"github.com/golang-infrastructure/go-shuffle"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -19,12 +23,12 @@ func TestNewVersionE(t *testing.T) {
 
 	// 测试无效的版本字符串
 	invalidVersion, err := NewVersionE("")
-	assert.Equal(t, ErrVersionInvalid, err)
+	assert.True(t, errors.Is(err, ErrVersionInvalid))
 	assert.Nil(t, invalidVersion)
 
 	// 测试另一个无效的版本字符串（没有数字部分）
 	invalidVersion2, err := NewVersionE("abc")
-	assert.Equal(t, ErrVersionInvalid, err)
+	assert.True(t, errors.Is(err, ErrVersionInvalid))
 	assert.Nil(t, invalidVersion2)
 }
 
@@ -118,6 +122,12 @@ func TestVersion_CompareTo(t *testing.T) {
 	assert.Equal(t, -1, v5.CompareTo(v6))
 	assert.Equal(t, 1, v6.CompareTo(v5))
 
+	// 后缀按照限定词的权重比较，没有后缀等价于正式版本
+	assert.Equal(t, 1, NewVersion("1.0-M10").CompareTo(NewVersion("1.0-M3")))
+	assert.Equal(t, -1, NewVersion("1.0-rc1").CompareTo(NewVersion("1.0")))
+	assert.Equal(t, -1, NewVersion("1.0-SNAPSHOT").CompareTo(NewVersion("1.0-RELEASE")))
+	assert.Equal(t, 1, NewVersion("1.0-SNAPSHOT").CompareTo(NewVersion("1.0-rc1")))
+
 	// 原始字符串不同，其他都相同（极少见的情况）
 	v7 := &Version{
 		Raw:            "1.0.0",
@@ -134,3 +144,44 @@ func TestVersion_CompareTo(t *testing.T) {
 	empty2 := NewVersion("")
 	assert.Equal(t, 0, empty1.CompareTo(empty2))
 }
+
+// TestVersion_CompareTo_HugeNumbers 测试超出 int 范围的数字不会溢出回绕，仍然能够正确排序
+func TestVersion_CompareTo_HugeNumbers(t *testing.T) {
+	v := NewVersion("1.20201012054050123456")
+	assert.Equal(t, VersionNumbers{1, math.MaxInt}, v.VersionNumbers)
+	assert.Equal(t, []string{"1", "20201012054050123456"}, v.NumberDigits)
+	assert.Equal(t, "1."+strconv.Itoa(math.MaxInt), v.BuildGroupID())
+
+	// 普通的版本号没有 NumberDigits，比较结果和分组不受影响
+	assert.Nil(t, NewVersion("1.2.3").NumberDigits)
+	assert.Equal(t, "1.2.3", NewVersion("1.2.3").BuildGroupID())
+
+	assert.Equal(t, -1, NewVersion("1.20201012054050123456").CompareTo(NewVersion("1.20201012054050123457")))
+	assert.Equal(t, 1, NewVersion("1.20201012054050123456").CompareTo(NewVersion("1.9223372036854775807")))
+	assert.Equal(t, -1, NewVersion("1.20201012054050123456").CompareTo(NewVersion("2.0")))
+	assert.Equal(t, -1, NewVersion("1.20201012054050123456").CompareTo(NewVersion("1.20201012054050123456.1")))
+
+	// 前导0不影响数值
+	assert.Equal(t, []string{"20201012054050123456"}, NewVersion("00020201012054050123456").NumberDigits)
+	assert.Equal(t, 0, compareNumberDigits(NewVersion("00020201012054050123456-a").numberDigits(), NewVersion("20201012054050123456").numberDigits()))
+	assert.Equal(t, VersionNumbers{1, 7}, NewVersion("0000000000000000000000001.007").VersionNumbers)
+	assert.Nil(t, NewVersion("0000000000000000000000001.007").NumberDigits)
+
+	expected := []string{
+		"1.0.0",
+		"1.0.9223372036854775807",
+		"1.0.9223372036854775808",
+		"1.0.20201012054050123456",
+		"1.0.99999999999999999999999",
+		"1.1",
+		"99999999999999999999999.999999999999999999.99999999999999999",
+	}
+	versions := NewVersions(expected...)
+	shuffle.Shuffle(versions)
+	sorted := SortVersionSlice(versions)
+	actual := make([]string, len(sorted))
+	for i, v := range sorted {
+		actual[i] = v.Raw
+	}
+	assert.Equal(t, expected, actual)
+}